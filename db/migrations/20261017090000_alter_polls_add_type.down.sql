@@ -0,0 +1,42 @@
+CREATE OR REPLACE FUNCTION fn_after_poll_response_insert()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE poll_options
+    SET vote_count = vote_count + 1
+    WHERE id = NEW.poll_option_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION fn_after_poll_response_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE poll_options
+    SET vote_count = vote_count - 1
+    WHERE id = OLD.poll_option_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- sisakan satu response per participant sebelum unique constraint lama dikembalikan
+DELETE FROM poll_responses a
+    USING poll_responses b
+    WHERE a.poll_id = b.poll_id
+      AND a.participant_id = b.participant_id
+      AND a.id > b.id;
+
+ALTER TABLE poll_responses
+    DROP CONSTRAINT IF EXISTS unique_poll_response_option;
+
+ALTER TABLE poll_responses
+    ADD CONSTRAINT unique_poll_response UNIQUE (poll_id, participant_id);
+
+ALTER TABLE poll_responses
+    DROP COLUMN IF EXISTS rank;
+
+ALTER TABLE polls
+    DROP CONSTRAINT IF EXISTS polls_type_check;
+
+ALTER TABLE polls
+    DROP COLUMN IF EXISTS max_selections,
+    DROP COLUMN IF EXISTS type;
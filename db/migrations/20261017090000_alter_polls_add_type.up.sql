@@ -0,0 +1,42 @@
+ALTER TABLE polls
+    ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'single_choice',
+    ADD COLUMN max_selections SMALLINT NOT NULL DEFAULT 1;
+
+ALTER TABLE polls
+    ADD CONSTRAINT polls_type_check
+    CHECK (type IN ('single_choice', 'multiple_choice', 'ranked_choice', 'rating'));
+
+-- satu participant bisa punya beberapa response (multiple / ranked choice), tapi tidak untuk option yang sama
+ALTER TABLE poll_responses
+    ADD COLUMN rank SMALLINT NOT NULL DEFAULT 0;
+
+ALTER TABLE poll_responses
+    DROP CONSTRAINT IF EXISTS unique_poll_response;
+
+ALTER TABLE poll_responses
+    ADD CONSTRAINT unique_poll_response_option UNIQUE (poll_id, participant_id, poll_option_id);
+
+-- vote_count hanya menghitung pilihan pertama untuk ranked choice (rank 0 = non-ranked, rank 1 = pilihan pertama)
+CREATE OR REPLACE FUNCTION fn_after_poll_response_insert()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.rank <= 1 THEN
+        UPDATE poll_options
+        SET vote_count = vote_count + 1
+        WHERE id = NEW.poll_option_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION fn_after_poll_response_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.rank <= 1 THEN
+        UPDATE poll_options
+        SET vote_count = vote_count - 1
+        WHERE id = OLD.poll_option_id;
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,6 @@
+ALTER TABLE polls
+    DROP CONSTRAINT IF EXISTS polls_type_check;
+
+ALTER TABLE polls
+    ADD CONSTRAINT polls_type_check
+    CHECK (type IN ('single_choice', 'multiple_choice', 'ranked_choice', 'rating', 'open_text'));
@@ -0,0 +1,15 @@
+CREATE TABLE poll_text_responses (
+    id BIGSERIAL PRIMARY KEY,
+    poll_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    content VARCHAR(100) NOT NULL,
+    normalized VARCHAR(100) NOT NULL,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_poll_text_responses_poll FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_poll_text_responses_participant FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_poll_text_responses_poll ON poll_text_responses (poll_id);
+CREATE INDEX idx_poll_text_responses_poll_participant ON poll_text_responses (poll_id, participant_id);
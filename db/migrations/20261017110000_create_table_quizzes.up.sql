@@ -0,0 +1,12 @@
+CREATE TABLE quizzes (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'finished')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ NULL,
+
+    CONSTRAINT fk_quizzes_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_quizzes_room ON quizzes (room_id);
@@ -0,0 +1,11 @@
+ALTER TABLE poll_options
+    DROP COLUMN IF EXISTS is_correct;
+
+DROP INDEX IF EXISTS idx_polls_quiz;
+
+ALTER TABLE polls
+    DROP CONSTRAINT IF EXISTS fk_polls_quiz;
+
+ALTER TABLE polls
+    DROP COLUMN IF EXISTS time_limit_seconds,
+    DROP COLUMN IF EXISTS quiz_id;
@@ -0,0 +1,12 @@
+-- poll yang menjadi pertanyaan quiz, time_limit_seconds = countdown setelah activated_at (0 = tanpa timer)
+ALTER TABLE polls
+    ADD COLUMN quiz_id BIGINT NULL,
+    ADD COLUMN time_limit_seconds SMALLINT NOT NULL DEFAULT 0;
+
+ALTER TABLE polls
+    ADD CONSTRAINT fk_polls_quiz FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_polls_quiz ON polls (quiz_id);
+
+ALTER TABLE poll_options
+    ADD COLUMN is_correct BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+CREATE TABLE quiz_answers (
+    id BIGSERIAL PRIMARY KEY,
+    quiz_id BIGINT NOT NULL,
+    poll_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    is_correct BOOLEAN NOT NULL DEFAULT FALSE,
+    response_ms INT NOT NULL DEFAULT 0,
+    points INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_quiz_answers_quiz FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_answers_poll FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_answers_participant FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE CASCADE,
+    CONSTRAINT unique_quiz_answer UNIQUE (poll_id, participant_id)
+);
+
+CREATE INDEX idx_quiz_answers_quiz ON quiz_answers (quiz_id);
+CREATE INDEX idx_quiz_answers_participant ON quiz_answers (participant_id);
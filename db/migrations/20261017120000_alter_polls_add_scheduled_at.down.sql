@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_polls_scheduled_at;
+
+ALTER TABLE polls
+    DROP COLUMN IF EXISTS scheduled_at;
@@ -0,0 +1,5 @@
+-- draft poll bisa dijadwalkan aktif otomatis oleh scheduler
+ALTER TABLE polls
+    ADD COLUMN scheduled_at TIMESTAMPTZ NULL;
+
+CREATE INDEX idx_polls_scheduled_at ON polls (scheduled_at) WHERE status = 'draft' AND scheduled_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- jika true, pertanyaan baru masuk antrian moderasi sebelum tampil di room
+ALTER TABLE rooms
+    ADD COLUMN question_moderation BOOLEAN NOT NULL DEFAULT FALSE;
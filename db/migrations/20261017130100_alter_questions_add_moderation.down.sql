@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_questions_moderation;
+
+ALTER TABLE questions
+    DROP CONSTRAINT IF EXISTS fk_questions_moderated_by;
+
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderation_status;
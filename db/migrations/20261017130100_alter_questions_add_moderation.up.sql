@@ -0,0 +1,10 @@
+-- pertanyaan lama dianggap sudah approved
+ALTER TABLE questions
+    ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved' CHECK (moderation_status IN ('queued', 'approved', 'rejected')),
+    ADD COLUMN moderated_by BIGINT NULL,
+    ADD COLUMN moderated_at TIMESTAMPTZ NULL;
+
+ALTER TABLE questions
+    ADD CONSTRAINT fk_questions_moderated_by FOREIGN KEY (moderated_by) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_questions_moderation ON questions (room_id, moderation_status);
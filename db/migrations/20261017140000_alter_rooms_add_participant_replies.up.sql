@@ -0,0 +1,3 @@
+-- jika true, participant selain presenter juga boleh membalas pertanyaan
+ALTER TABLE rooms
+    ADD COLUMN participant_replies BOOLEAN NOT NULL DEFAULT FALSE;
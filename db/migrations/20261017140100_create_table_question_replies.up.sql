@@ -0,0 +1,14 @@
+CREATE TABLE question_replies (
+    id BIGSERIAL PRIMARY KEY,
+    question_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    content TEXT NOT NULL,
+    is_presenter BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_question_replies_question FOREIGN KEY (question_id) REFERENCES questions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_question_replies_participant FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_question_replies_question ON question_replies (question_id, created_at);
+CREATE INDEX idx_question_replies_participant ON question_replies (participant_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_messages_deleted_at;
+
+ALTER TABLE messages
+    DROP CONSTRAINT IF EXISTS fk_messages_deleted_by,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
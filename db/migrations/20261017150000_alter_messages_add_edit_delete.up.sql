@@ -0,0 +1,7 @@
+ALTER TABLE messages
+    ADD COLUMN edited_at TIMESTAMPTZ NULL,
+    ADD COLUMN deleted_at TIMESTAMPTZ NULL,
+    ADD COLUMN deleted_by BIGINT NULL,
+    ADD CONSTRAINT fk_messages_deleted_by FOREIGN KEY (deleted_by) REFERENCES participants(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_messages_deleted_at ON messages (deleted_at);
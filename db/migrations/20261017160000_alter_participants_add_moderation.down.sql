@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_participants_fingerprint;
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS muted_until,
+    DROP COLUMN IF EXISTS fingerprint;
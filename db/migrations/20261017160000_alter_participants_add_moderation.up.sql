@@ -0,0 +1,7 @@
+-- fingerprint: hash device anonymous untuk blokir rejoin setelah ban
+-- muted_until: participant tidak bisa chat / bertanya sampai waktu ini
+ALTER TABLE participants
+    ADD COLUMN fingerprint VARCHAR(64) NULL,
+    ADD COLUMN muted_until TIMESTAMPTZ NULL;
+
+CREATE INDEX idx_participants_fingerprint ON participants (room_id, fingerprint);
@@ -0,0 +1,20 @@
+CREATE TABLE room_bans (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT NOT NULL,
+    participant_id BIGINT NULL,
+    user_id BIGINT NULL,
+    fingerprint VARCHAR(64) NULL,
+    display_name VARCHAR(100) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    banned_by BIGINT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_room_bans_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_bans_participant FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE SET NULL,
+    CONSTRAINT fk_room_bans_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_bans_banned_by FOREIGN KEY (banned_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_room_bans_target CHECK (user_id IS NOT NULL OR fingerprint IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX idx_room_bans_user ON room_bans (room_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_room_bans_fingerprint ON room_bans (room_id, fingerprint) WHERE fingerprint IS NOT NULL;
@@ -0,0 +1,20 @@
+CREATE TABLE room_content_filters (
+    room_id BIGINT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    blocked_words TEXT NOT NULL DEFAULT '[]',
+    word_action VARCHAR(10) NOT NULL DEFAULT 'mask',
+    block_links BOOLEAN NOT NULL DEFAULT FALSE,
+    link_action VARCHAR(10) NOT NULL DEFAULT 'reject',
+    repeat_window_seconds INT NOT NULL DEFAULT 0,
+    repeat_action VARCHAR(10) NOT NULL DEFAULT 'reject',
+    flood_max_messages INT NOT NULL DEFAULT 0,
+    flood_window_seconds INT NOT NULL DEFAULT 10,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_room_content_filters_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT chk_room_content_filters_word_action CHECK (word_action IN ('mask', 'reject', 'moderate')),
+    CONSTRAINT chk_room_content_filters_link_action CHECK (link_action IN ('mask', 'reject', 'moderate')),
+    CONSTRAINT chk_room_content_filters_repeat_action CHECK (repeat_action IN ('reject', 'moderate')),
+    CONSTRAINT chk_room_content_filters_repeat_window CHECK (repeat_window_seconds >= 0),
+    CONSTRAINT chk_room_content_filters_flood CHECK (flood_max_messages >= 0 AND flood_window_seconds > 0)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE room_moderators (
+    room_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (room_id, user_id),
+    CONSTRAINT fk_room_moderators_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_moderators_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_room_moderators_user ON room_moderators (user_id);
+
+-- co-host juga boleh memoderasi Q&A
+INSERT INTO room_moderators (room_id, user_id, created_at)
+SELECT room_id, user_id, created_at FROM room_roles;
+
+DROP TABLE IF EXISTS room_roles;
@@ -0,0 +1,21 @@
+CREATE TABLE room_roles (
+    room_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    granted_by BIGINT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (room_id, user_id),
+    CONSTRAINT fk_room_roles_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_roles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_roles_granted_by FOREIGN KEY (granted_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_room_roles_role CHECK (role IN ('co_host', 'moderator'))
+);
+
+CREATE INDEX idx_room_roles_user ON room_roles (user_id);
+
+-- moderator Q&A lama pindah ke room_roles
+INSERT INTO room_roles (room_id, user_id, role, created_at)
+SELECT room_id, user_id, 'moderator', created_at FROM room_moderators;
+
+DROP TABLE room_moderators;
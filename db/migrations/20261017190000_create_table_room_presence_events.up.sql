@@ -0,0 +1,14 @@
+CREATE TABLE room_presence_events (
+    id BIGSERIAL PRIMARY KEY,
+    room_id BIGINT NOT NULL,
+    participant_id BIGINT NOT NULL,
+    event VARCHAR(10) NOT NULL,
+    online_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_room_presence_events_room FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_presence_events_participant FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE CASCADE,
+    CONSTRAINT chk_room_presence_events_event CHECK (event IN ('join', 'leave'))
+);
+
+CREATE INDEX idx_room_presence_events_room_created ON room_presence_events (room_id, created_at);
@@ -1,10 +1,18 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
+	"time"
+
 	"reisify/internal/delivery/http"
 	"reisify/internal/delivery/http/middleware"
 	"reisify/internal/delivery/http/route"
+	"reisify/internal/delivery/scheduler"
 	"reisify/internal/delivery/websocket"
+	"reisify/internal/mail"
+	"reisify/internal/model"
+	"reisify/internal/oidc"
 	"reisify/internal/repository"
 	"reisify/internal/sfu"
 	"reisify/internal/usecase"
@@ -39,39 +47,96 @@ func Bootstrap(config *BootstrapConfig) {
 	voteRepository := repository.NewVoteRepository(config.Log)
 	pollRepository := repository.NewPollRepository(config.Log)
 	activityRepository := repository.NewActivityRepository(config.Log)
+	quizRepository := repository.NewQuizRepository(config.Log)
+	roomRoleRepository := repository.NewRoomRoleRepository(config.Log)
+	questionReplyRepository := repository.NewQuestionReplyRepository(config.Log)
+	roomBanRepository := repository.NewRoomBanRepository(config.Log)
+	roomContentFilterRepository := repository.NewRoomContentFilterRepository(config.Log)
+	analyticsRepository := repository.NewAnalyticsRepository(config.Log)
+	roomPresenceEventRepository := repository.NewRoomPresenceEventRepository(config.Log)
+	webhookRepository := repository.NewWebhookRepository(config.Log)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepository(config.Log)
+	apiKeyRepository := repository.NewAPIKeyRepository(config.Log)
+	userTokenRepository := repository.NewUserTokenRepository(config.Log)
+	userIdentityRepository := repository.NewUserIdentityRepository(config.Log)
 
 	// configure cookie Secure flag from env (true in production/HTTPS, false for local HTTP dev)
 	http.SetCookieSecure(config.Config.GetBool("COOKIE_SECURE"))
 
 	// setup utils
-	tokenUtil := util.NewTokenUtil(config.Config.GetString("JWT_SECRET"), config.Redis)
+	tokenUtil := util.NewTokenUtil(
+		config.Config.GetString("JWT_SECRET"),
+		config.Redis,
+		time.Duration(config.Config.GetInt("auth.access_token_ttl"))*time.Second,
+		time.Duration(config.Config.GetInt("auth.refresh_token_ttl"))*time.Second,
+	)
+	http.SetAuthCookieMaxAge(tokenUtil.AccessTTL, tokenUtil.RefreshTTL)
 
 	// setup use cases
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validator, userRepository, participantRepository, roomRepository, tokenUtil)
-	roomUseCase := usecase.NewRoomUseCase(config.DB, config.Log, config.Validator, roomRepository, participantRepository)
-	participantUseCase := usecase.NewParticipantUseCase(config.DB, config.Log, config.Validator, participantRepository, roomRepository, userRepository, tokenUtil)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validator, userRepository, participantRepository, roomRepository, roomBanRepository, tokenUtil)
+	roomUseCase := usecase.NewRoomUseCase(config.DB, config.Log, config.Validator, roomRepository, participantRepository, roomRoleRepository, userRepository, tokenUtil)
+	participantUseCase := usecase.NewParticipantUseCase(config.DB, config.Log, config.Validator, participantRepository, roomRepository, userRepository, roomBanRepository, roomRoleRepository, tokenUtil)
 	xpTransactionUseCase := usecase.NewXPTransactionUseCase(config.DB, config.Validator, config.Log, xpTransactionRepository, roomRepository)
-	messageUseCase := usecase.NewMessageUseCase(config.DB, config.Validator, config.Log, messageRepository, roomRepository, participantRepository, xpTransactionUseCase)
-	questionUseCase := usecase.NewQuestionUseCase(config.DB, config.Log, config.Validator, questionRepository, voteRepository, roomRepository, participantRepository, xpTransactionRepository)
-	pollUseCase := usecase.NewPollUseCase(config.DB, config.Log, config.Validator, pollRepository, roomRepository, participantRepository, xpTransactionRepository)
+	contentFilterUseCase := usecase.NewContentFilterUseCase(config.DB, config.Log, config.Validator, config.Redis, roomRepository, participantRepository, roomContentFilterRepository)
+	messageUseCase := usecase.NewMessageUseCase(config.DB, config.Validator, config.Log, messageRepository, roomRepository, participantRepository, roomRoleRepository, xpTransactionUseCase, contentFilterUseCase)
+	questionUseCase := usecase.NewQuestionUseCase(config.DB, config.Log, config.Validator, questionRepository, voteRepository, roomRepository, participantRepository, xpTransactionRepository, roomRoleRepository, questionReplyRepository, contentFilterUseCase)
+	pollUseCase := usecase.NewPollUseCase(config.DB, config.Log, config.Validator, pollRepository, roomRepository, participantRepository, xpTransactionRepository, quizRepository, roomRoleRepository)
+	quizUseCase := usecase.NewQuizUseCase(config.DB, config.Log, config.Validator, quizRepository, pollRepository, roomRepository, roomRoleRepository, pollUseCase)
 	activityUseCase := usecase.NewActivityUseCase(config.DB, config.Log, config.Validator, activityRepository, roomRepository)
+	exportUseCase := usecase.NewExportUseCase(config.DB, config.Log, config.Validator, roomRepository, activityRepository, questionRepository, questionReplyRepository, pollRepository, participantRepository)
+	analyticsUseCase := usecase.NewAnalyticsUseCase(config.DB, config.Log, config.Validator, roomRepository, participantRepository, pollRepository, analyticsRepository, roomPresenceEventRepository)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(config.DB, config.Log, config.Validator, apiKeyRepository, userRepository)
+	sessionUseCase := usecase.NewSessionUseCase(config.Log, config.Validator, tokenUtil)
+	oidcUseCase := usecase.NewOIDCUseCase(config.DB, config.Log, config.Validator, config.Redis, newOIDCProvider(config), userRepository, userIdentityRepository, tokenUtil, config.Config.GetString("oidc.default_role"))
+	accountUseCase := usecase.NewAccountUseCase(config.DB, config.Log, config.Validator, userRepository, userTokenRepository, roomRepository, participantRepository, tokenUtil, newMailSender(config), config.Config.GetString("mail.base_url"))
+	webhookUseCase := usecase.NewWebhookUseCase(config.DB, config.Log, config.Validator, time.Duration(config.Config.GetInt("webhook.timeout"))*time.Second, config.Config.GetInt("webhook.max_attempts"), config.Config.GetBool("webhook.allow_private_targets"), webhookRepository, webhookDeliveryRepository, roomRepository)
 
 	// configuration websocket hub (sebelum controller yang membutuhkan hub)
-	hub := websocket.NewHub(config.Log)
+	hub := websocket.NewHub(config.Log, newBackplane(config))
+	hub.SetReplayBuffer(newReplayBuffer(config))
+	hub.SetPresenceRecorder(func(roomID, participantID uint, event string, onlineCount int, at time.Time) {
+		_ = analyticsUseCase.RecordPresence(context.Background(), &model.RecordPresenceRequest{
+			RoomID:        roomID,
+			ParticipantID: participantID,
+			Event:         event,
+			OnlineCount:   onlineCount,
+			At:            at,
+		})
+	})
+	hub.SetBroadcastListener(func(roomID uint, event string, data json.RawMessage) {
+		_ = webhookUseCase.Dispatch(context.Background(), roomID, event, data)
+	})
 	go hub.Run() // start hub run goroutine
 
+	// background scheduler untuk aktivasi draft poll terjadwal
+	pollScheduler := scheduler.NewPollScheduler(config.Log, pollUseCase, hub, time.Duration(config.Config.GetInt("poll.scheduler_interval"))*time.Second)
+	go pollScheduler.Run(context.Background())
+
+	// background worker untuk retry webhook delivery
+	webhookWorker := scheduler.NewWebhookWorker(config.Log, webhookUseCase, time.Duration(config.Config.GetInt("webhook.worker_interval"))*time.Second)
+	go webhookWorker.Run(context.Background())
+
 	// setup HTTP controllers
-	userController := http.NewUserController(config.Log, userUseCase)
+	userController := http.NewUserController(config.Log, userUseCase, accountUseCase, hub)
 	roomController := http.NewRoomController(config.Log, roomUseCase, tokenUtil, hub)
-	participantController := http.NewParticipantController(config.Log, participantUseCase)
-	messageController := http.NewMessageController(config.Log, messageUseCase)
+	participantController := http.NewParticipantController(config.Log, participantUseCase, hub)
+	messageController := http.NewMessageController(config.Log, messageUseCase, participantUseCase, hub)
 	questionController := http.NewQuestionController(config.Log, questionUseCase, hub)
 	pollController := http.NewPollController(config.Log, pollUseCase, participantUseCase, hub)
+	quizController := http.NewQuizController(config.Log, quizUseCase, hub)
 	xpTransactionController := http.NewXPTransactionController(config.Log, xpTransactionUseCase)
 	activityController := http.NewActivityController(config.Log, activityUseCase)
+	contentFilterController := http.NewContentFilterController(config.Log, contentFilterUseCase)
+	exportController := http.NewExportController(config.Log, exportUseCase)
+	analyticsController := http.NewAnalyticsController(config.Log, analyticsUseCase, hub)
+	webhookController := http.NewWebhookController(config.Log, webhookUseCase)
+	apiKeyController := http.NewAPIKeyController(config.Log, apiKeyUseCase)
+	sessionController := http.NewSessionController(config.Log, sessionUseCase, hub)
+	accountController := http.NewAccountController(config.Log, accountUseCase, hub)
+	oidcController := http.NewOIDCController(config.Log, oidcUseCase, config.Config.GetString("oidc.frontend_url"))
 
 	// setup HTTP middleware
-	authMiddleware := middleware.NewAuth(userUseCase, tokenUtil)
+	authMiddleware := middleware.NewAuth(userUseCase, tokenUtil, apiKeyUseCase)
 
 	// websocket handler
 	sfuManager := sfu.NewSFUManager(config.Log)
@@ -87,8 +152,17 @@ func Bootstrap(config *BootstrapConfig) {
 		MessageController:       messageController,
 		QuestionController:      questionController,
 		PollController:          pollController,
+		QuizController:          quizController,
 		XPTransactionController: xpTransactionController,
 		ActivityController:      activityController,
+		ContentFilterController: contentFilterController,
+		ExportController:        exportController,
+		AnalyticsController:     analyticsController,
+		WebhookController:       webhookController,
+		APIKeyController:        apiKeyController,
+		SessionController:       sessionController,
+		AccountController:       accountController,
+		OIDCController:          oidcController,
 		AuthMiddleware:          authMiddleware,
 		WSHandler:               wsHandler,
 		Redis:                   config.Redis,
@@ -96,3 +170,52 @@ func Bootstrap(config *BootstrapConfig) {
 	}
 	routeConfig.Setup()
 }
+
+// newBackplane memilih backplane websocket dari config "websocket.backplane" (redis | memory)
+func newBackplane(config *BootstrapConfig) websocket.Backplane {
+	if config.Config.GetString("websocket.backplane") == "redis" && config.Redis != nil {
+		return websocket.NewRedisBackplane(config.Redis, config.Log)
+	}
+	return websocket.NewMemoryBackplane()
+}
+
+// newReplayBuffer memilih replay buffer websocket dari config "websocket.replay.store" (redis | memory)
+func newReplayBuffer(config *BootstrapConfig) websocket.ReplayBuffer {
+	size := config.Config.GetInt("websocket.replay.size")
+	ttl := time.Duration(config.Config.GetInt("websocket.replay.ttl")) * time.Second
+
+	if config.Config.GetString("websocket.replay.store") == "redis" && config.Redis != nil {
+		return websocket.NewRedisReplayBuffer(config.Redis, size, ttl)
+	}
+	return websocket.NewMemoryReplayBuffer(size, ttl)
+}
+
+// newMailSender memilih pengirim email dari config "mail.driver" (smtp | log)
+func newMailSender(config *BootstrapConfig) mail.Sender {
+	if config.Config.GetString("mail.driver") == "smtp" {
+		return mail.NewSMTPSender(
+			config.Config.GetString("SMTP_HOST"),
+			config.Config.GetInt("SMTP_PORT"),
+			config.Config.GetString("SMTP_USERNAME"),
+			config.Config.GetString("SMTP_PASSWORD"),
+			config.Config.GetString("mail.from"),
+			time.Duration(config.Config.GetInt("mail.timeout"))*time.Second,
+		)
+	}
+	return mail.NewLogSender(config.Log, config.Config.GetString("mail.file_path"))
+}
+
+// newOIDCProvider identity provider SSO dari config "oidc.*", nil jika "oidc.issuer" kosong (SSO nonaktif)
+func newOIDCProvider(config *BootstrapConfig) *oidc.Provider {
+	issuer := config.Config.GetString("oidc.issuer")
+	if issuer == "" {
+		return nil
+	}
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       issuer,
+		ClientID:     config.Config.GetString("oidc.client_id"),
+		ClientSecret: config.Config.GetString("OIDC_CLIENT_SECRET"),
+		RedirectURL:  config.Config.GetString("oidc.redirect_url"),
+		Scopes:       config.Config.GetStringSlice("oidc.scopes"),
+	})
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AccountController handler profil, hapus akun, reset password dan verifikasi email
+type AccountController struct {
+	Log            *logrus.Logger
+	AccountUseCase *usecase.AccountUseCase
+	WSHub          *websocket.Hub
+}
+
+// NewAccountController create new instance of AccountController
+func NewAccountController(log *logrus.Logger, accountUseCase *usecase.AccountUseCase, wsHub *websocket.Hub) *AccountController {
+	return &AccountController{
+		Log:            log,
+		AccountUseCase: accountUseCase,
+		WSHub:          wsHub,
+	}
+}
+
+// ForgotPassword handler untuk minta link reset password lewat email
+func (c *AccountController) ForgotPassword(ctx *fiber.Ctx) error {
+	request := new(model.ForgotPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Body parse failed: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.IP = ctx.IP()
+
+	if err := c.AccountUseCase.ForgotPassword(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("ForgotPassword failed: %s", err)
+		return err
+	}
+
+	// respons sama untuk email terdaftar maupun tidak
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "If the email is registered, a password reset link has been sent",
+		},
+	})
+}
+
+// ResetPassword handler untuk set password baru dari token reset, semua session user dicabut
+func (c *AccountController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(model.ResetPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Body parse failed: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	sessionIDs, err := c.AccountUseCase.ResetPassword(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ResetPassword failed: %s", err)
+		return err
+	}
+
+	// tutup koneksi websocket semua session user di semua node
+	if c.WSHub != nil {
+		c.WSHub.DisconnectSessions(sessionIDs)
+	}
+	clearAuthCookie(ctx)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "Password has been reset, please log in again",
+		},
+	})
+}
+
+// SendVerificationEmail handler untuk kirim ulang link verifikasi ke email user
+func (c *AccountController) SendVerificationEmail(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// hanya user terdaftar dengan login biasa, bukan anonymous atau API key
+	if !canManageSessions(auth) {
+		c.Log.Warn("SendVerificationEmail - Caller is not a registered user")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.SendVerificationEmailRequest{
+		UserID: *auth.UserID,
+		IP:     ctx.IP(),
+	}
+	if err := c.AccountUseCase.SendVerificationEmail(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("SendVerificationEmail failed: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "Verification email sent",
+		},
+	})
+}
+
+// VerifyEmail handler untuk verifikasi email dari token di link email
+func (c *AccountController) VerifyEmail(ctx *fiber.Ctx) error {
+	request := new(model.VerifyEmailRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Body parse failed: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.AccountUseCase.VerifyEmail(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("VerifyEmail failed: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Profile handler untuk ambil profil user yang sedang login
+func (c *AccountController) Profile(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// anonymous tidak punya akun
+	if auth.UserID == nil || auth.IsAnonymous {
+		c.Log.Warn("Profile - Caller is not a registered user")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.GetProfileRequest{UserID: *auth.UserID}
+	response, err := c.AccountUseCase.Profile(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Profile failed: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// UpdateProfile handler untuk ubah profil, access token session saat ini diterbitkan ulang dengan claims baru
+func (c *AccountController) UpdateProfile(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// hanya user terdaftar dengan login biasa, bukan anonymous atau API key
+	if !canManageSessions(auth) {
+		c.Log.Warn("UpdateProfile - Caller is not a registered user")
+		return fiber.ErrForbidden
+	}
+
+	request := new(model.UpdateProfileRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Body parse failed: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.UserID = *auth.UserID
+	request.IP = ctx.IP()
+	request.Auth = auth
+
+	response, err := c.AccountUseCase.UpdateProfile(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("UpdateProfile failed: %s", err)
+		return err
+	}
+
+	// session lain dicabut karena password berubah
+	if c.WSHub != nil && len(response.RevokedSessionIDs) > 0 {
+		c.WSHub.DisconnectSessions(response.RevokedSessionIDs)
+	}
+	if response.Token != "" {
+		setAuthCookie(ctx, response.Token)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response.User,
+	})
+}
+
+// DeleteAccount handler untuk hapus akun, room aktif milik user ditutup dan semua koneksinya diputus
+func (c *AccountController) DeleteAccount(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// hanya user terdaftar dengan login biasa, bukan anonymous atau API key
+	if !canManageSessions(auth) {
+		c.Log.Warn("DeleteAccount - Caller is not a registered user")
+		return fiber.ErrForbidden
+	}
+
+	request := new(model.DeleteAccountRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Body parse failed: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.UserID = *auth.UserID
+
+	response, err := c.AccountUseCase.DeleteAccount(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("DeleteAccount failed: %s", err)
+		return err
+	}
+
+	if c.WSHub != nil {
+		for _, room := range response.ClosedRooms {
+			c.WSHub.BroadcastToRoom(room.Room.ID, marshalJSONBytes(websocket.WSMessage{
+				Event: websocket.EventRoomClosed,
+				Data:  marshalJSONBytes(room.Room),
+			}))
+			c.WSHub.DisconnectParticipants(room.Room.ID, room.ParticipantIDs)
+		}
+		c.WSHub.DisconnectSessions(response.SessionIDs)
+	}
+	clearAuthCookie(ctx)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "Account deleted",
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AnalyticsController controller untuk analytics room
+type AnalyticsController struct {
+	Log              *logrus.Logger
+	AnalyticsUseCase *usecase.AnalyticsUseCase
+	WSHub            *websocket.Hub
+}
+
+// NewAnalyticsController create new instance of AnalyticsController
+func NewAnalyticsController(log *logrus.Logger, analyticsUseCase *usecase.AnalyticsUseCase, wsHub *websocket.Hub) *AnalyticsController {
+	return &AnalyticsController{
+		Log:              log,
+		AnalyticsUseCase: analyticsUseCase,
+		WSHub:            wsHub,
+	}
+}
+
+// Get handler untuk analytics room (owner only)
+// Query params: bucket_minutes (1-1440, default otomatis dari durasi room)
+func (c *AnalyticsController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("GetAnalytics - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// only the room owner can see analytics
+	if !auth.IsRoomOwner || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		return fiber.ErrForbidden
+	}
+
+	request := &model.GetRoomAnalyticsRequest{
+		PresenterID:   *auth.UserID,
+		RoomID:        uint(roomIDUint64),
+		BucketMinutes: ctx.QueryInt("bucket_minutes", 0),
+	}
+
+	response, err := c.AnalyticsUseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("GetAnalytics - AnalyticsUseCase.Get error: %v", err)
+		return err
+	}
+
+	// jumlah online saat ini dari hub (live)
+	response.Participants.OnlineNow = len(c.WSHub.OnlineParticipants(response.RoomID))
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
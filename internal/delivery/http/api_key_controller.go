@@ -0,0 +1,108 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// APIKeyController controller untuk personal API key
+type APIKeyController struct {
+	Log           *logrus.Logger
+	APIKeyUseCase *usecase.APIKeyUseCase
+}
+
+// NewAPIKeyController create new instance of APIKeyController
+func NewAPIKeyController(log *logrus.Logger, apiKeyUseCase *usecase.APIKeyUseCase) *APIKeyController {
+	return &APIKeyController{
+		Log:           log,
+		APIKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// Create handler untuk membuat API key, key hanya ditampilkan sekali di response ini
+func (c *APIKeyController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if !canManageAPIKeys(auth) {
+		c.Log.Warn("Create - Caller cannot manage API keys")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.CreateAPIKeyRequest{}
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Create - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.UserID = *auth.UserID
+
+	response, err := c.APIKeyUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Create - APIKeyUseCase.Create error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// List handler untuk melihat API key milik user beserta waktu pemakaian terakhir
+func (c *APIKeyController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if !canManageAPIKeys(auth) {
+		c.Log.Warn("List - Caller cannot manage API keys")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListAPIKeysRequest{
+		UserID: *auth.UserID,
+	}
+
+	response, err := c.APIKeyUseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("List - APIKeyUseCase.List error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Revoke handler untuk mencabut API key
+func (c *APIKeyController) Revoke(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if !canManageAPIKeys(auth) {
+		c.Log.Warn("Revoke - Caller cannot manage API keys")
+		return fiber.ErrForbidden
+	}
+
+	apiKeyIDUint64, err := strconv.ParseUint(ctx.Params("api_key_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Revoke - Invalid api_key_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.RevokeAPIKeyRequest{
+		UserID:   *auth.UserID,
+		APIKeyID: uint(apiKeyIDUint64),
+	}
+
+	if err := c.APIKeyUseCase.Revoke(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Revoke - APIKeyUseCase.Revoke error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: true,
+	})
+}
+
+// canManageAPIKeys hanya user terdaftar yang login biasa, API key tidak bisa membuat key baru
+func canManageAPIKeys(auth *model.Auth) bool {
+	return auth.UserID != nil && !auth.IsAnonymous && auth.APIKeyID == nil
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ContentFilterController controller untuk konfigurasi filter konten room
+type ContentFilterController struct {
+	Log                  *logrus.Logger
+	ContentFilterUseCase *usecase.ContentFilterUseCase
+}
+
+// NewContentFilterController create new instance of ContentFilterController
+func NewContentFilterController(log *logrus.Logger, contentFilterUseCase *usecase.ContentFilterUseCase) *ContentFilterController {
+	return &ContentFilterController{
+		Log:                  log,
+		ContentFilterUseCase: contentFilterUseCase,
+	}
+}
+
+// Get handler untuk melihat konfigurasi filter konten room (presenter only)
+func (c *ContentFilterController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Get - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.IsRoomOwner || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("Get - Caller is not owner of room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.GetContentFilterRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+	}
+
+	response, err := c.ContentFilterUseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Get - ContentFilterUseCase.Get error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Update handler untuk mengganti konfigurasi filter konten room (presenter only)
+func (c *ContentFilterController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Update - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.IsRoomOwner || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("Update - Caller is not owner of room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.UpdateContentFilterRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Update - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.ContentFilterUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Update - ContentFilterUseCase.Update error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
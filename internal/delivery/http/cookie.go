@@ -1,23 +1,41 @@
 package http
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 const (
-	authCookieName = "token"
-	// 30 days in seconds, matching the JWT expiry duration
-	authCookieMaxAge = 86400 * 30
+	authCookieName    = "token"
+	refreshCookieName = "refresh_token"
+	// refresh token hanya dikirim browser ke endpoint user (refresh / logout)
+	refreshCookiePath = "/api/v1/users"
 )
 
 // cookieSecure controls the Secure flag on auth cookies.
 // Set to true in production (HTTPS). Defaults to false to allow HTTP in development.
 var cookieSecure bool
 
+// authCookieMaxAge and refreshCookieMaxAge in seconds, matching the access and refresh token expiry.
+var (
+	authCookieMaxAge    = int((15 * time.Minute).Seconds())
+	refreshCookieMaxAge = int((30 * 24 * time.Hour).Seconds())
+)
+
 // SetCookieSecure configures the Secure flag for all auth cookies.
 // Call this once during application bootstrap with the value of COOKIE_SECURE env var.
 func SetCookieSecure(secure bool) {
 	cookieSecure = secure
 }
 
+// SetAuthCookieMaxAge configures the lifetime of the access and refresh token cookies.
+// Call this once during application bootstrap with the TTLs used by TokenUtil.
+func SetAuthCookieMaxAge(accessTTL, refreshTTL time.Duration) {
+	authCookieMaxAge = int(accessTTL.Seconds())
+	refreshCookieMaxAge = int(refreshTTL.Seconds())
+}
+
 // setAuthCookie sets the JWT token as an HTTP-only, SameSite=Lax cookie.
 func setAuthCookie(ctx *fiber.Ctx, token string) {
 	ctx.Cookie(&fiber.Cookie{
@@ -31,7 +49,24 @@ func setAuthCookie(ctx *fiber.Ctx, token string) {
 	})
 }
 
-// clearAuthCookie removes the auth cookie by setting MaxAge to -1.
+// setRefreshCookie sets the refresh token as an HTTP-only, SameSite=Strict cookie.
+// Empty refresh token (session reused) leaves the existing cookie untouched.
+func setRefreshCookie(ctx *fiber.Ctx, refreshToken string) {
+	if refreshToken == "" {
+		return
+	}
+	ctx.Cookie(&fiber.Cookie{
+		Name:     refreshCookieName,
+		Value:    refreshToken,
+		HTTPOnly: true,
+		Secure:   cookieSecure,
+		SameSite: "Strict",
+		Path:     refreshCookiePath,
+		MaxAge:   refreshCookieMaxAge,
+	})
+}
+
+// clearAuthCookie removes the auth and refresh cookies by setting MaxAge to -1.
 func clearAuthCookie(ctx *fiber.Ctx) {
 	ctx.Cookie(&fiber.Cookie{
 		Name:     authCookieName,
@@ -42,4 +77,13 @@ func clearAuthCookie(ctx *fiber.Ctx) {
 		Path:     "/",
 		MaxAge:   -1,
 	})
+	ctx.Cookie(&fiber.Cookie{
+		Name:     refreshCookieName,
+		Value:    "",
+		HTTPOnly: true,
+		Secure:   cookieSecure,
+		SameSite: "Strict",
+		Path:     refreshCookiePath,
+		MaxAge:   -1,
+	})
 }
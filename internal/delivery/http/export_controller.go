@@ -0,0 +1,60 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportController controller untuk export data sesi room
+type ExportController struct {
+	Log           *logrus.Logger
+	ExportUseCase *usecase.ExportUseCase
+}
+
+// NewExportController create new instance of ExportController
+func NewExportController(log *logrus.Logger, exportUseCase *usecase.ExportUseCase) *ExportController {
+	return &ExportController{
+		Log:           log,
+		ExportUseCase: exportUseCase,
+	}
+}
+
+// Export handler untuk download export room (owner only)
+// Query params: format (json | csv | html, default json)
+func (c *ExportController) Export(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Export - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// only the room owner can export
+	if !auth.IsRoomOwner || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ExportRoomRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+		Format:      ctx.Query("format", model.ExportFormatJSON),
+	}
+
+	file, err := c.ExportUseCase.Export(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Export - ExportUseCase.Export error: %v", err)
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, file.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="`+file.Filename+`"`)
+	return ctx.Status(fiber.StatusOK).Send(file.Body)
+}
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
 	"reisify/internal/model"
 	"reisify/internal/usecase"
 	"strconv"
@@ -11,14 +14,18 @@ import (
 )
 
 type MessageController struct {
-	Log            *logrus.Logger
-	MessageUseCase *usecase.MessageUseCase
+	Log                *logrus.Logger
+	MessageUseCase     *usecase.MessageUseCase
+	ParticipantUseCase *usecase.ParticipantUseCase
+	WSHub              *websocket.Hub
 }
 
-func NewMessageController(log *logrus.Logger, messageUseCase *usecase.MessageUseCase) *MessageController {
+func NewMessageController(log *logrus.Logger, messageUseCase *usecase.MessageUseCase, participantUseCase *usecase.ParticipantUseCase, wsHub *websocket.Hub) *MessageController {
 	return &MessageController{
-		Log:            log,
-		MessageUseCase: messageUseCase,
+		Log:                log,
+		MessageUseCase:     messageUseCase,
+		ParticipantUseCase: participantUseCase,
+		WSHub:              wsHub,
 	}
 }
 
@@ -114,3 +121,128 @@ func (c *MessageController) List(ctx *fiber.Ctx) error {
 		Data: response,
 	})
 }
+
+// Update handler untuk edit message oleh author
+func (c *MessageController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse message_id from params
+	messageIDUint64, err := strconv.ParseUint(ctx.Params("message_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Update - Invalid message_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if auth.ParticipantID == nil || auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	request := &model.UpdateMessageRequest{
+		MessageID:     uint(messageIDUint64),
+		RoomID:        *auth.RoomID,
+		ParticipantID: *auth.ParticipantID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Update - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.MessageUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Update - MessageUseCase.Update error: %v", err)
+		return err
+	}
+
+	c.broadcast(request.RoomID, websocket.EventMessageUpdated, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Delete handler untuk hapus message (author atau owner room)
+func (c *MessageController) Delete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse message_id from params
+	messageIDUint64, err := strconv.ParseUint(ctx.Params("message_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Delete - Invalid message_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if auth.ParticipantID == nil || auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	request := &model.DeleteMessageRequest{
+		MessageID:     uint(messageIDUint64),
+		RoomID:        *auth.RoomID,
+		ParticipantID: *auth.ParticipantID,
+	}
+
+	response, err := c.MessageUseCase.Delete(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Delete - MessageUseCase.Delete error: %v", err)
+		return err
+	}
+
+	c.broadcast(request.RoomID, websocket.EventMessageDeleted, response)
+	if response.XPRevoked > 0 {
+		c.broadcastLeaderboardUpdate(request.RoomID, request.ParticipantID)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// broadcast kirim event message ke semua clients di room
+func (c *MessageController) broadcast(roomID uint, event string, payload interface{}) {
+	if c.WSHub == nil {
+		return
+	}
+	data := websocket.WSMessage{
+		Event: event,
+		Data:  messageMustMarshalJSON(payload),
+	}
+	c.WSHub.BroadcastToRoom(roomID, messageMustMarshalJSON(data))
+}
+
+// broadcastLeaderboardUpdate broadcast leaderboard setelah XP author dibatalkan
+func (c *MessageController) broadcastLeaderboardUpdate(roomID uint, participantID uint) {
+	if c.WSHub == nil || c.ParticipantUseCase == nil {
+		return
+	}
+
+	request := &model.GetLeaderboardRequest{
+		RoomID:        roomID,
+		ParticipantID: participantID,
+	}
+
+	leaderboard, err := c.ParticipantUseCase.Leaderboard(context.Background(), request)
+	if err != nil {
+		c.Log.Warnf("broadcastLeaderboardUpdate - error: %v", err)
+		return
+	}
+
+	data := websocket.WSMessage{
+		Event: websocket.EventLeaderboardUpdate,
+		Data: messageMustMarshalJSON(map[string]interface{}{
+			"leaderboard":        leaderboard,
+			"total_participants": leaderboard.TotalParticipants,
+		}),
+	}
+	c.WSHub.BroadcastToRoom(roomID, messageMustMarshalJSON(data))
+}
+
+// messageMustMarshalJSON helper untuk marshal JSON
+func messageMustMarshalJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
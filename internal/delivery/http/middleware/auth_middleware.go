@@ -4,14 +4,34 @@ import (
 	"reisify/internal/model"
 	"reisify/internal/usecase"
 	"reisify/internal/util"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewAuth(userUseCase *usecase.UserUseCase, tokenUtil *util.TokenUtil) fiber.Handler {
+func NewAuth(userUseCase *usecase.UserUseCase, tokenUtil *util.TokenUtil, apiKeyUseCase *usecase.APIKeyUseCase) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
-		// extract token from HTTP-only cookie
-		tokenString := ctx.Cookies("token")
+		// Authorization: Bearer <api key | jwt> untuk script dan integrasi server-to-server,
+		// selain itu token dari HTTP-only cookie
+		tokenString := bearerToken(ctx)
+
+		if strings.HasPrefix(tokenString, model.APIKeyPrefix) {
+			auth, err := apiKeyUseCase.Authenticate(ctx.UserContext(), &model.AuthenticateAPIKeyRequest{
+				Key: tokenString,
+				IP:  ctx.IP(),
+			})
+			if err != nil {
+				userUseCase.Log.Warnf("Failed to authenticate API key: %v", err)
+				return err
+			}
+
+			ctx.Locals("auth", auth)
+			return ctx.Next()
+		}
+
+		if tokenString == "" {
+			tokenString = ctx.Cookies("token")
+		}
 		if tokenString == "" {
 			userUseCase.Log.Warnf("Missing auth token cookie")
 			return fiber.ErrUnauthorized
@@ -24,6 +44,17 @@ func NewAuth(userUseCase *usecase.UserUseCase, tokenUtil *util.TokenUtil) fiber.
 			return fiber.ErrUnauthorized
 		}
 
+		// token room hasil join / create lewat API key hanya berlaku selama key masih aktif
+		if auth.APIKeyID != nil {
+			if err := apiKeyUseCase.Verify(ctx.UserContext(), &model.VerifyAPIKeyRequest{
+				APIKeyID: *auth.APIKeyID,
+				IP:       ctx.IP(),
+			}); err != nil {
+				userUseCase.Log.Warnf("API key of token is no longer valid: %v", err)
+				return err
+			}
+		}
+
 		userUseCase.Log.Debugf("User : %+v", auth.Username)
 
 		// set user to context
@@ -32,7 +63,26 @@ func NewAuth(userUseCase *usecase.UserUseCase, tokenUtil *util.TokenUtil) fiber.
 	}
 }
 
+// RequireScope tolak request API key yang tidak punya scope ini, login biasa selalu lolos
+func RequireScope(scope string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !GetUser(ctx).HasScope(scope) {
+			return fiber.NewError(fiber.StatusForbidden, "API key is missing scope "+scope)
+		}
+		return ctx.Next()
+	}
+}
+
 // GetUser mengambil data user dari context
 func GetUser(ctx *fiber.Ctx) *model.Auth {
 	return ctx.Locals("auth").(*model.Auth)
 }
+
+// bearerToken ambil token dari header "Authorization: Bearer <token>", kosong jika tidak ada
+func bearerToken(ctx *fiber.Ctx) string {
+	header := ctx.Get(fiber.HeaderAuthorization)
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"strings"
+
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	oidcStateCookieName = "oidc_state"
+	oidcStateCookiePath = "/api/v1/users/oidc"
+)
+
+// OIDCController handler login SSO OpenID Connect (authorization code + PKCE)
+type OIDCController struct {
+	Log         *logrus.Logger
+	OIDCUseCase *usecase.OIDCUseCase
+	FrontendURL string // base url frontend tujuan redirect setelah login
+}
+
+// NewOIDCController create new instance of OIDCController
+func NewOIDCController(log *logrus.Logger, oidcUseCase *usecase.OIDCUseCase, frontendURL string) *OIDCController {
+	return &OIDCController{
+		Log:         log,
+		OIDCUseCase: oidcUseCase,
+		FrontendURL: strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+// Login handler untuk redirect browser ke halaman login identity provider
+func (c *OIDCController) Login(ctx *fiber.Ctx) error {
+	request := &model.OIDCLoginRequest{
+		Redirect: ctx.Query("redirect"),
+	}
+
+	response, err := c.OIDCUseCase.Login(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("OIDC login failed: %s", err)
+		return err
+	}
+
+	// state diikat ke browser ini, dicek saat callback
+	ctx.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    response.State,
+		HTTPOnly: true,
+		Secure:   cookieSecure,
+		SameSite: "Lax", // callback adalah navigasi top-level dari domain provider
+		Path:     oidcStateCookiePath,
+		MaxAge:   int(usecase.OIDCStateTTL.Seconds()),
+	})
+
+	return ctx.Redirect(response.AuthURL, fiber.StatusFound)
+}
+
+// Callback handler untuk redirect balik dari identity provider, set cookie auth lalu redirect ke frontend
+func (c *OIDCController) Callback(ctx *fiber.Ctx) error {
+	request := &model.OIDCCallbackRequest{
+		Code:        ctx.Query("code"),
+		State:       ctx.Query("state"),
+		CookieState: ctx.Cookies(oidcStateCookieName),
+		Error:       ctx.Query("error"),
+		Client:      sessionClient(ctx),
+	}
+
+	// state cookie sekali pakai
+	ctx.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    "",
+		HTTPOnly: true,
+		Secure:   cookieSecure,
+		SameSite: "Lax",
+		Path:     oidcStateCookiePath,
+		MaxAge:   -1,
+	})
+
+	response, err := c.OIDCUseCase.Callback(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("OIDC callback failed: %s", err)
+		return err
+	}
+
+	// cookie sama dengan login username/password
+	setAuthCookie(ctx, response.Auth.Token)
+	setRefreshCookie(ctx, response.Auth.RefreshToken)
+
+	return ctx.Redirect(c.FrontendURL+response.Redirect, fiber.StatusFound)
+}
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"encoding/json"
 	"math"
 	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
 	"reisify/internal/model"
 	"reisify/internal/usecase"
 	"strconv"
@@ -14,12 +16,14 @@ import (
 type ParticipantController struct {
 	Log                *logrus.Logger
 	ParticipantUseCase *usecase.ParticipantUseCase
+	WSHub              *websocket.Hub
 }
 
-func NewParticipantController(log *logrus.Logger, participantUseCase *usecase.ParticipantUseCase) *ParticipantController {
+func NewParticipantController(log *logrus.Logger, participantUseCase *usecase.ParticipantUseCase, wsHub *websocket.Hub) *ParticipantController {
 	return &ParticipantController{
 		Log:                log,
 		ParticipantUseCase: participantUseCase,
+		WSHub:              wsHub,
 	}
 }
 
@@ -32,6 +36,11 @@ func (c *ParticipantController) Join(ctx *fiber.Ctx) error {
 	request := &model.JoinRoomRequest{
 		Username: auth.Username,
 		RoomCode: ctx.Params("room_code"),
+		APIKeyID: auth.APIKeyID,
+		Scopes:   auth.Scopes,
+		// token room memakai session login yang sama, refresh token tetap berlaku
+		SessionID: auth.SessionID,
+		Client:    sessionClient(ctx),
 	}
 
 	// parse body request
@@ -49,6 +58,7 @@ func (c *ParticipantController) Join(ctx *fiber.Ctx) error {
 
 	// set room-scoped token as HTTP-only cookie
 	setAuthCookie(ctx, response.Token)
+	setRefreshCookie(ctx, response.RefreshToken)
 
 	// return response
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
@@ -94,6 +104,9 @@ func (c *ParticipantController) List(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	// status online dari hub websocket
+	c.WSHub.AnnotatePresence(request.RoomID, responses.Participants)
+
 	// calculate pagination
 	paging := &model.PaginationResponse{
 		Page:      request.Page,
@@ -109,6 +122,53 @@ func (c *ParticipantController) List(ctx *fiber.Ctx) error {
 	})
 }
 
+// Presence handler untuk daftar participant yang sedang terkoneksi ke room beserta status presence
+func (c *ParticipantController) Presence(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	if auth.ParticipantID == nil || auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	roomID, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Invalid room id: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	// caller must belong to the requested room
+	if *auth.RoomID != uint(roomID) {
+		c.Log.Warnf("Presence - Caller does not belong to room %d", roomID)
+		return fiber.ErrForbidden
+	}
+
+	statuses := c.WSHub.Presence(uint(roomID))
+	request := &model.ListOnlineParticipantsRequest{
+		RoomID:         uint(roomID),
+		ParticipantIDs: make([]uint, 0, len(statuses)),
+	}
+	for participantID := range statuses {
+		request.ParticipantIDs = append(request.ParticipantIDs, participantID)
+	}
+
+	response, err := c.ParticipantUseCase.ListOnline(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("request failed: %v", err)
+		return err
+	}
+
+	for _, participant := range response.Participants {
+		participant.Status = statuses[participant.ID]
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: &model.RoomPresenceResponse{
+			Participants: response.Participants,
+			OnlineCount:  len(response.Participants),
+		},
+	})
+}
+
 // Leaderboard handler untuk mendapatkan leaderboard dalam room
 func (c *ParticipantController) Leaderboard(ctx *fiber.Ctx) error {
 	// get auth from locals
@@ -150,3 +210,246 @@ func (c *ParticipantController) Leaderboard(ctx *fiber.Ctx) error {
 		Data: responses,
 	})
 }
+
+// Kick handler untuk mengeluarkan participant dari room (owner, co-host atau moderator)
+func (c *ParticipantController) Kick(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomID, participantID, err := c.parseModerationParams(ctx, auth, "Kick")
+	if err != nil {
+		return err
+	}
+
+	request := &model.ModerateParticipantRequest{
+		ActorID:       *auth.UserID,
+		RoomID:        roomID,
+		ParticipantID: participantID,
+	}
+	if len(ctx.Body()) > 0 {
+		if err = ctx.BodyParser(request); err != nil {
+			c.Log.Warnf("Kick - Failed to parse body: %s", err)
+			return fiber.ErrBadRequest
+		}
+	}
+
+	response, err := c.ParticipantUseCase.Kick(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Kick - ParticipantUseCase.Kick error: %s", err)
+		return err
+	}
+
+	// broadcast dulu supaya participant yang di-kick ikut menerima event sebelum koneksinya ditutup
+	c.broadcast(roomID, websocket.EventParticipantKicked, response)
+	if c.WSHub != nil {
+		c.WSHub.DisconnectParticipants(roomID, []uint{participantID})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Ban handler untuk mengeluarkan participant dan memblokir join ulang (owner, co-host atau moderator)
+func (c *ParticipantController) Ban(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomID, participantID, err := c.parseModerationParams(ctx, auth, "Ban")
+	if err != nil {
+		return err
+	}
+
+	request := &model.ModerateParticipantRequest{
+		ActorID:       *auth.UserID,
+		RoomID:        roomID,
+		ParticipantID: participantID,
+	}
+	if len(ctx.Body()) > 0 {
+		if err = ctx.BodyParser(request); err != nil {
+			c.Log.Warnf("Ban - Failed to parse body: %s", err)
+			return fiber.ErrBadRequest
+		}
+	}
+
+	response, err := c.ParticipantUseCase.Ban(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Ban - ParticipantUseCase.Ban error: %s", err)
+		return err
+	}
+
+	c.broadcast(roomID, websocket.EventParticipantBanned, response)
+	if c.WSHub != nil {
+		c.WSHub.DisconnectParticipants(roomID, []uint{participantID})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Mute handler untuk mute participant selama durasi tertentu (owner, co-host atau moderator)
+func (c *ParticipantController) Mute(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomID, participantID, err := c.parseModerationParams(ctx, auth, "Mute")
+	if err != nil {
+		return err
+	}
+
+	request := &model.MuteParticipantRequest{
+		ActorID:       *auth.UserID,
+		RoomID:        roomID,
+		ParticipantID: participantID,
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Mute - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.ParticipantUseCase.Mute(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Mute - ParticipantUseCase.Mute error: %s", err)
+		return err
+	}
+
+	c.broadcast(roomID, websocket.EventParticipantMuted, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Unmute handler untuk membatalkan mute participant (owner, co-host atau moderator)
+func (c *ParticipantController) Unmute(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomID, participantID, err := c.parseModerationParams(ctx, auth, "Unmute")
+	if err != nil {
+		return err
+	}
+
+	request := &model.UnmuteParticipantRequest{
+		ActorID:       *auth.UserID,
+		RoomID:        roomID,
+		ParticipantID: participantID,
+	}
+
+	response, err := c.ParticipantUseCase.Unmute(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Unmute - ParticipantUseCase.Unmute error: %s", err)
+		return err
+	}
+
+	c.broadcast(roomID, websocket.EventParticipantUnmuted, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// ListBans handler untuk daftar participant yang di-ban (owner, co-host atau moderator)
+func (c *ParticipantController) ListBans(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("ListBans - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanModerate() || auth.UserID == nil || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("ListBans - Caller cannot moderate room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListBansRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+	}
+
+	response, err := c.ParticipantUseCase.ListBans(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ListBans - ParticipantUseCase.ListBans error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Unban handler untuk menghapus ban (owner, co-host atau moderator)
+func (c *ParticipantController) Unban(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Unban - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+	banIDUint64, err := strconv.ParseUint(ctx.Params("ban_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Unban - Invalid ban_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanModerate() || auth.UserID == nil || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("Unban - Caller cannot moderate room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.UnbanRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+		BanID:   uint(banIDUint64),
+	}
+
+	if err = c.ParticipantUseCase.Unban(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Unban - ParticipantUseCase.Unban error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: true,
+	})
+}
+
+// parseModerationParams parse room_id dan participant_id lalu pastikan caller adalah owner, co-host atau moderator room
+func (c *ParticipantController) parseModerationParams(ctx *fiber.Ctx, auth *model.Auth, action string) (uint, uint, error) {
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("%s - Invalid room_id: %v", action, err)
+		return 0, 0, fiber.ErrBadRequest
+	}
+	participantIDUint64, err := strconv.ParseUint(ctx.Params("participant_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("%s - Invalid participant_id: %v", action, err)
+		return 0, 0, fiber.ErrBadRequest
+	}
+
+	if !auth.CanModerate() || auth.UserID == nil || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("%s - Caller cannot moderate room %d", action, roomIDUint64)
+		return 0, 0, fiber.ErrForbidden
+	}
+
+	return uint(roomIDUint64), uint(participantIDUint64), nil
+}
+
+// broadcast kirim event moderasi ke semua client di room
+func (c *ParticipantController) broadcast(roomID uint, event string, payload interface{}) {
+	if c.WSHub == nil {
+		return
+	}
+	data := websocket.WSMessage{
+		Event: event,
+		Data:  participantMustMarshalJSON(payload),
+	}
+	c.WSHub.BroadcastToRoom(roomID, participantMustMarshalJSON(data))
+}
+
+func participantMustMarshalJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
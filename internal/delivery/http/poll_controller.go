@@ -31,13 +31,13 @@ func NewPollController(log *logrus.Logger, pollUseCase *usecase.PollUseCase, par
 	}
 }
 
-// Create handler untuk membuat poll baru (presenter only)
+// Create handler untuk membuat poll baru (owner atau co-host)
 func (c *PollController) Create(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
-	// check if user is presenter
-	if auth.Role != "presenter" && auth.Role != "admin" {
-		c.Log.Warnf("Create - User is not presenter")
+	// only the room owner and co-hosts can create a poll
+	if !auth.CanHost() {
+		c.Log.Warnf("Create - User cannot host room")
 		return fiber.ErrForbidden
 	}
 
@@ -74,8 +74,10 @@ func (c *PollController) Create(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	// broadcast ke websocket clients di room
-	c.broadcastPollCreated(uint(roomIDUint64), response)
+	// draft baru di-broadcast saat diaktifkan
+	if response.Poll.Status == "active" {
+		c.broadcastPollCreated(uint(roomIDUint64), response)
+	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
 		Data: response,
@@ -142,9 +144,10 @@ func (c *PollController) GetHistory(ctx *fiber.Ctx) error {
 
 	// parse query params
 	request := &model.GetPollHistoryRequest{
-		RoomID: uint(roomIDUint64),
-		Status: ctx.Query("status", "all"),
-		Limit:  ctx.QueryInt("limit", 10),
+		RoomID:        uint(roomIDUint64),
+		Status:        ctx.Query("status", "all"),
+		Limit:         ctx.QueryInt("limit", 10),
+		IncludeDrafts: auth.CanHost(),
 	}
 
 	// call usecase
@@ -208,13 +211,13 @@ func (c *PollController) Vote(ctx *fiber.Ctx) error {
 	})
 }
 
-// Close handler untuk menutup poll (presenter only)
+// Close handler untuk menutup poll (owner atau co-host)
 func (c *PollController) Close(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
-	// only the room presenter can close a poll
-	if !auth.IsRoomOwner {
-		c.Log.Warnf("Close - User is not room owner")
+	// only the room owner and co-hosts can close a poll
+	if !auth.CanHost() {
+		c.Log.Warnf("Close - User cannot host room")
 		return fiber.ErrForbidden
 	}
 
@@ -249,6 +252,252 @@ func (c *PollController) Close(ctx *fiber.Ctx) error {
 	})
 }
 
+// Update handler untuk edit draft poll (owner atau co-host)
+func (c *PollController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can edit a poll
+	if !auth.CanHost() {
+		c.Log.Warnf("Update - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse poll_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Update - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.UpdatePollRequest{
+		PollID:      uint(pollIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Update - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Update - PollUseCase.Update error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// ReorderOptions handler untuk mengubah urutan option draft poll (owner atau co-host)
+func (c *PollController) ReorderOptions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can edit a poll
+	if !auth.CanHost() {
+		c.Log.Warnf("ReorderOptions - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse poll_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("ReorderOptions - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.ReorderPollOptionsRequest{
+		PollID:      uint(pollIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("ReorderOptions - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.ReorderOptions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ReorderOptions - PollUseCase.ReorderOptions error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Activate handler untuk mengaktifkan draft poll (owner atau co-host)
+func (c *PollController) Activate(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can activate a poll
+	if !auth.CanHost() {
+		c.Log.Warnf("Activate - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse poll_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Activate - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.ActivatePollRequest{
+		PollID:      uint(pollIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.Activate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Activate - PollUseCase.Activate error: %v", err)
+		return err
+	}
+
+	// poll baru terlihat oleh participant saat diaktifkan
+	c.broadcastPollCreated(response.Poll.RoomID, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// GetResults handler untuk mendapatkan hasil poll terkini (termasuk word cloud untuk open-text poll)
+func (c *PollController) GetResults(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	if auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	// parse poll_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("GetResults - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.GetPollResultsRequest{
+		PollID: uint(pollIDUint64),
+		RoomID: *auth.RoomID,
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.GetResults(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("GetResults - PollUseCase.GetResults error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// ListAnswers handler untuk list semua jawaban open-text poll (owner atau co-host)
+func (c *PollController) ListAnswers(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can see hidden answers
+	if !auth.CanHost() {
+		c.Log.Warnf("ListAnswers - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse poll_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("ListAnswers - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.ListPollAnswersRequest{
+		PollID:      uint(pollIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.ListAnswers(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ListAnswers - PollUseCase.ListAnswers error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// HideAnswer handler untuk hide / unhide jawaban open-text poll (owner atau co-host)
+func (c *PollController) HideAnswer(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can moderate answers
+	if !auth.CanHost() {
+		c.Log.Warnf("HideAnswer - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse poll_id and answer_id from params
+	pollIDStr := ctx.Params("poll_id")
+	pollIDUint64, err := strconv.ParseUint(pollIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("HideAnswer - Invalid poll_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	answerIDStr := ctx.Params("answer_id")
+	answerIDUint64, err := strconv.ParseUint(answerIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("HideAnswer - Invalid answer_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.HidePollAnswerRequest{
+		PollID:      uint(pollIDUint64),
+		AnswerID:    uint(answerIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("HideAnswer - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.PollUseCase.HideAnswer(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("HideAnswer - PollUseCase.HideAnswer error: %v", err)
+		return err
+	}
+
+	// word cloud berubah, broadcast hasil terbaru ke room poll (bukan room di token caller)
+	c.broadcastPollResults(response.UpdatedResults.RoomID, response.UpdatedResults)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
 // ========================================
 // WebSocket Broadcast Functions
 // ========================================
@@ -271,10 +520,18 @@ func (c *PollController) broadcastPollVoted(roomID uint, response *model.SubmitP
 		return
 	}
 	// hanya kirim updated results, tidak kirim data personal voter
+	c.broadcastPollResults(roomID, response.UpdatedResults)
+}
+
+// broadcastPollResults broadcast event poll results updated ke room
+func (c *PollController) broadcastPollResults(roomID uint, results model.UpdatedPollResultsResponse) {
+	if c.WSHub == nil {
+		return
+	}
 	broadcastData := struct {
 		UpdatedResults model.UpdatedPollResultsResponse `json:"updated_results"`
 	}{
-		UpdatedResults: response.UpdatedResults,
+		UpdatedResults: results,
 	}
 	data := websocket.WSMessage{
 		Event: websocket.EventPollResultsUpdate,
@@ -65,8 +65,12 @@ func (c *QuestionController) Submit(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	// broadcast ke websocket clients di room
-	c.broadcastQuestionCreated(request.RoomID, response)
+	// question di antrian moderasi hanya dikirim ke owner dan moderator
+	if response.Question.ModerationStatus == "queued" {
+		c.notifyModerators(ctx, request.RoomID, websocket.EventQuestionQueued, response)
+	} else {
+		c.broadcastQuestionCreated(request.RoomID, response)
+	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
 		Data: response,
@@ -151,6 +155,49 @@ func (c *QuestionController) Upvote(ctx *fiber.Ctx) error {
 	})
 }
 
+// Reply handler untuk membalas question
+func (c *QuestionController) Reply(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse question_id from params
+	questionIDStr := ctx.Params("question_id")
+	questionIDUint64, err := strconv.ParseUint(questionIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Reply - Invalid question_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if auth.ParticipantID == nil || auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	request := &model.ReplyQuestionRequest{
+		QuestionID:    uint(questionIDUint64),
+		ParticipantID: *auth.ParticipantID,
+		RoomID:        *auth.RoomID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Reply - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.QuestionUseCase.Reply(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Reply - QuestionUseCase.Reply error: %v", err)
+		return err
+	}
+
+	// broadcast ke websocket clients di room
+	c.broadcastQuestionReplied(request.RoomID, response)
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
 // RemoveUpvote handler untuk remove upvote
 func (c *QuestionController) RemoveUpvote(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
@@ -189,13 +236,13 @@ func (c *QuestionController) RemoveUpvote(ctx *fiber.Ctx) error {
 	})
 }
 
-// Validate handler untuk validate question (presenter only)
+// Validate handler untuk validate question (owner, co-host atau moderator)
 func (c *QuestionController) Validate(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
-	// only the room presenter can validate questions
-	if !auth.IsRoomOwner {
-		c.Log.Warnf("Validate - User is not room owner")
+	// only the room owner, co-hosts and moderators can validate questions
+	if !auth.CanModerate() || auth.UserID == nil {
+		c.Log.Warnf("Validate - User cannot moderate room")
 		return fiber.ErrForbidden
 	}
 
@@ -247,6 +294,141 @@ func (c *QuestionController) Validate(ctx *fiber.Ctx) error {
 	})
 }
 
+// Queue handler untuk melihat antrian moderasi (owner dan moderator)
+func (c *QuestionController) Queue(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Queue - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// moderator harus user terdaftar dengan token room ini
+	if auth.UserID == nil || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("Queue - Caller cannot moderate room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.GetQuestionQueueRequest{
+		RoomID: uint(roomIDUint64),
+		UserID: *auth.UserID,
+		Limit:  ctx.QueryInt("limit", 20),
+		Offset: ctx.QueryInt("offset", 0),
+	}
+
+	// call usecase
+	response, err := c.QuestionUseCase.Queue(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Queue - QuestionUseCase.Queue error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Moderate handler untuk approve / reject / edit question di antrian (owner, co-host dan moderator)
+func (c *QuestionController) Moderate(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse question_id from params
+	questionIDStr := ctx.Params("question_id")
+	questionIDUint64, err := strconv.ParseUint(questionIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Moderate - Invalid question_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if auth.UserID == nil || auth.RoomID == nil {
+		return fiber.ErrForbidden
+	}
+
+	roomID, err := c.QuestionUseCase.GetRoomIDByQuestionID(ctx.UserContext(), uint(questionIDUint64))
+	if err != nil {
+		c.Log.Warnf("Moderate - GetRoomIDByQuestionID error: %v", err)
+		return err
+	}
+
+	// caller's token must be scoped to this question's room
+	if *auth.RoomID != roomID {
+		c.Log.Warnf("Moderate - Token room_id does not match question's room %d", roomID)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ModerateQuestionRequest{
+		QuestionID:  uint(questionIDUint64),
+		ModeratorID: *auth.UserID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Moderate - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.QuestionUseCase.Moderate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Moderate - QuestionUseCase.Moderate error: %v", err)
+		return err
+	}
+
+	c.broadcastQuestionModerated(ctx, roomID, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// broadcastQuestionModerated kirim hasil moderasi: approve -> question:created ke room,
+// reject -> question:rejected ke author saja, lalu question:moderated ke owner dan moderator
+func (c *QuestionController) broadcastQuestionModerated(ctx *fiber.Ctx, roomID uint, response *model.ModerateQuestionResponse) {
+	if c.WSHub == nil {
+		return
+	}
+
+	switch response.Action {
+	case "approve":
+		c.broadcastQuestionCreated(roomID, &model.SubmitQuestionResponse{
+			Question: response.Question,
+			XPEarned: response.XPEarned,
+		})
+	case "reject":
+		data := websocket.WSMessage{
+			Event: websocket.EventQuestionRejected,
+			Data: mustMarshalJSON(map[string]interface{}{
+				"question_id": response.Question.ID,
+				"content":     response.Question.Content,
+				"reason":      response.Reason,
+			}),
+		}
+		c.WSHub.SendToParticipants(roomID, []uint{response.Question.ParticipantID}, mustMarshalJSON(data))
+	}
+
+	c.notifyModerators(ctx, roomID, websocket.EventQuestionModerated, response)
+}
+
+// notifyModerators kirim event hanya ke owner, co-host dan moderator room
+func (c *QuestionController) notifyModerators(ctx *fiber.Ctx, roomID uint, event string, payload interface{}) {
+	if c.WSHub == nil {
+		return
+	}
+	userIDs, err := c.QuestionUseCase.ModeratorUserIDs(ctx.UserContext(), roomID)
+	if err != nil {
+		c.Log.Warnf("notifyModerators - ModeratorUserIDs error: %v", err)
+		return
+	}
+	data := websocket.WSMessage{
+		Event: event,
+		Data:  mustMarshalJSON(payload),
+	}
+	c.WSHub.SendToUsers(roomID, userIDs, mustMarshalJSON(data))
+}
+
 // broadcastQuestionCreated broadcast question created event ke semua clients di room
 func (c *QuestionController) broadcastQuestionCreated(roomID uint, response *model.SubmitQuestionResponse) {
 	if c.WSHub == nil {
@@ -271,6 +453,18 @@ func (c *QuestionController) broadcastQuestionUpvoted(roomID uint, response *mod
 	c.WSHub.BroadcastToRoom(roomID, mustMarshalJSON(data))
 }
 
+// broadcastQuestionReplied broadcast reply baru ke semua clients di room
+func (c *QuestionController) broadcastQuestionReplied(roomID uint, response *model.ReplyResponse) {
+	if c.WSHub == nil {
+		return
+	}
+	data := websocket.WSMessage{
+		Event: websocket.EventQuestionReplied,
+		Data:  mustMarshalJSON(response),
+	}
+	c.WSHub.BroadcastToRoom(roomID, mustMarshalJSON(data))
+}
+
 // broadcastQuestionUpvoteRemoved broadcast upvote removed event ke semua clients di room
 func (c *QuestionController) broadcastQuestionUpvoteRemoved(roomID uint, response *model.RemoveUpvoteResponse) {
 	if c.WSHub == nil {
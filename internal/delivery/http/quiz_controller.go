@@ -0,0 +1,192 @@
+package http
+
+import (
+	"encoding/json"
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// QuizController controller untuk Quiz operations
+type QuizController struct {
+	Log         *logrus.Logger
+	QuizUseCase *usecase.QuizUseCase
+	WSHub       *websocket.Hub
+}
+
+// NewQuizController create new instance of QuizController
+func NewQuizController(log *logrus.Logger, quizUseCase *usecase.QuizUseCase, wsHub *websocket.Hub) *QuizController {
+	return &QuizController{
+		Log:         log,
+		QuizUseCase: quizUseCase,
+		WSHub:       wsHub,
+	}
+}
+
+// Create handler untuk membuat quiz baru (owner atau co-host)
+func (c *QuizController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can create a quiz
+	if !auth.CanHost() {
+		c.Log.Warnf("Create - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Create - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// caller must belong to the requested room
+	if auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("Create - Caller does not belong to room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	// create request
+	request := &model.CreateQuizRequest{
+		RoomID:      uint(roomIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// parse body
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Create - BodyParser error: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// call usecase
+	response, err := c.QuizUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Create - QuizUseCase.Create error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Finish handler untuk mengakhiri quiz dan broadcast ringkasan (owner atau co-host)
+func (c *QuizController) Finish(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// only the room owner and co-hosts can finish a quiz
+	if !auth.CanHost() {
+		c.Log.Warnf("Finish - User cannot host room")
+		return fiber.ErrForbidden
+	}
+
+	// parse quiz_id from params
+	quizIDStr := ctx.Params("quiz_id")
+	quizIDUint64, err := strconv.ParseUint(quizIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("Finish - Invalid quiz_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.FinishQuizRequest{
+		QuizID:      uint(quizIDUint64),
+		PresenterID: *auth.UserID,
+	}
+
+	// call usecase
+	response, closedPolls, err := c.QuizUseCase.Finish(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Finish - QuizUseCase.Finish error: %v", err)
+		return err
+	}
+
+	// pertanyaan yang masih aktif ikut ditutup, client perlu poll:closed seperti close poll biasa
+	for _, closed := range closedPolls {
+		c.broadcastPollClosed(response.Quiz.RoomID, closed)
+	}
+
+	// broadcast ringkasan quiz ke room
+	c.broadcastQuizSummary(response.Quiz.RoomID, response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// GetSummary handler untuk mendapatkan ringkasan quiz
+func (c *QuizController) GetSummary(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	if auth.RoomID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "You must join a room first")
+	}
+
+	// parse quiz_id from params
+	quizIDStr := ctx.Params("quiz_id")
+	quizIDUint64, err := strconv.ParseUint(quizIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("GetSummary - Invalid quiz_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	// create request
+	request := &model.GetQuizSummaryRequest{
+		QuizID: uint(quizIDUint64),
+		RoomID: *auth.RoomID,
+	}
+
+	// call usecase
+	response, err := c.QuizUseCase.GetSummary(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("GetSummary - QuizUseCase.GetSummary error: %v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// ========================================
+// WebSocket Broadcast Functions
+// ========================================
+
+// broadcastQuizSummary broadcast event quiz summary ke room
+func (c *QuizController) broadcastQuizSummary(roomID uint, response *model.QuizSummaryResponse) {
+	if c.WSHub == nil {
+		return
+	}
+	data := websocket.WSMessage{
+		Event: websocket.EventQuizSummary,
+		Data:  quizMustMarshalJSON(response),
+	}
+	c.WSHub.BroadcastToRoom(roomID, quizMustMarshalJSON(data))
+}
+
+// broadcastPollClosed broadcast event poll closed ke room
+func (c *QuizController) broadcastPollClosed(roomID uint, response *model.ClosePollResponse) {
+	if c.WSHub == nil {
+		return
+	}
+	data := websocket.WSMessage{
+		Event: websocket.EventPollClosed,
+		Data:  quizMustMarshalJSON(response),
+	}
+	c.WSHub.BroadcastToRoom(roomID, quizMustMarshalJSON(data))
+}
+
+// quizMustMarshalJSON helper untuk marshal JSON
+func quizMustMarshalJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
@@ -62,14 +62,19 @@ func (c *RoomController) Create(ctx *fiber.Ctx) error {
 		Role:          "presenter",
 		IsAnonymous:   false,
 		IsRoomOwner:   true,
-	})
+		RoomRole:      model.RoomRoleOwner,
+		APIKeyID:      auth.APIKeyID,
+		Scopes:        auth.Scopes,
+		SessionID:     auth.SessionID,
+	}, sessionClient(ctx))
 	if err != nil {
 		c.Log.Warnf("Failed to create token: %s", err)
 		return fiber.ErrInternalServerError
 	}
 
 	// set room-scoped token as HTTP-only cookie
-	setAuthCookie(ctx, newToken)
+	setAuthCookie(ctx, newToken.AccessToken)
+	setRefreshCookie(ctx, newToken.RefreshToken)
 
 	// return response
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
@@ -138,6 +143,12 @@ func (c *RoomController) UpdateToClosed(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	// beri tahu client (dan webhook) bahwa room sudah ditutup
+	c.Hub.BroadcastToRoom(request.RoomID, marshalJSONBytes(websocket.WSMessage{
+		Event: websocket.EventRoomClosed,
+		Data:  marshalJSONBytes(response),
+	}))
+
 	// return response
 	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
 		Data: response,
@@ -206,7 +217,7 @@ func (c *RoomController) Delete(ctx *fiber.Ctx) error {
 	})
 }
 
-// SendAnnouncement handler untuk mengirim announcement ke room (presenter only)
+// SendAnnouncement handler untuk mengirim announcement ke room (owner atau co-host)
 func (c *RoomController) SendAnnouncement(ctx *fiber.Ctx) error {
 	// get user from locals
 	auth := middleware.GetUser(ctx)
@@ -226,9 +237,9 @@ func (c *RoomController) SendAnnouncement(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
-	// only the room presenter may send announcements, and only to their own room
-	if !auth.IsRoomOwner {
-		c.Log.Warnf("SendAnnouncement - User is not room owner")
+	// only the room owner and co-hosts may send announcements, and only to their own room
+	if !auth.CanHost() {
+		c.Log.Warnf("SendAnnouncement - User cannot host room")
 		return fiber.ErrForbidden
 	}
 	if auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
@@ -260,6 +271,343 @@ func (c *RoomController) SendAnnouncement(ctx *fiber.Ctx) error {
 	})
 }
 
+// UpdateSettings handler untuk mengubah pengaturan room (presenter only)
+func (c *RoomController) UpdateSettings(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("UpdateSettings - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.IsRoomOwner || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("UpdateSettings - Caller is not owner of room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.UpdateRoomSettingsRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("UpdateSettings - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.RoomUseCase.UpdateSettings(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("UpdateSettings - RoomUseCase.UpdateSettings error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// ListModerators handler untuk daftar moderator room (owner atau co-host)
+func (c *RoomController) ListModerators(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("ListModerators - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("ListModerators - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListModeratorsRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+	}
+
+	response, err := c.RoomUseCase.ListModerators(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ListModerators - RoomUseCase.ListModerators error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// AddModerator handler untuk menunjuk moderator (owner atau co-host)
+func (c *RoomController) AddModerator(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDStr := ctx.Params("room_id")
+	roomIDUint64, err := strconv.ParseUint(roomIDStr, 10, 64)
+	if err != nil {
+		c.Log.Warnf("AddModerator - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("AddModerator - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.AddModeratorRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("AddModerator - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.RoomUseCase.AddModerator(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("AddModerator - RoomUseCase.AddModerator error: %s", err)
+		return err
+	}
+
+	c.notifyRoleUpdated(&model.RoomRoleChangeResponse{
+		RoomID:        request.RoomID,
+		UserID:        response.UserID,
+		Role:          model.RoomRoleModerator,
+		ParticipantID: &request.ParticipantID,
+	})
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// RemoveModerator handler untuk mencabut moderator (owner atau co-host)
+func (c *RoomController) RemoveModerator(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id and user_id from params
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("RemoveModerator - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+	userIDUint64, err := strconv.ParseUint(ctx.Params("user_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("RemoveModerator - Invalid user_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("RemoveModerator - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.RemoveModeratorRequest{
+		PresenterID: *auth.UserID,
+		RoomID:      uint(roomIDUint64),
+		UserID:      uint(userIDUint64),
+	}
+
+	response, err := c.RoomUseCase.RemoveModerator(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("RemoveModerator - RoomUseCase.RemoveModerator error: %s", err)
+		return err
+	}
+
+	c.notifyRoleRevoked(response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "Moderator removed successfully",
+		},
+	})
+}
+
+// ListRoles handler untuk daftar co-host dan moderator room (owner atau co-host)
+func (c *RoomController) ListRoles(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("ListRoles - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("ListRoles - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListRoomRolesRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+		Role:    ctx.Query("role"),
+	}
+
+	response, err := c.RoomUseCase.ListRoles(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ListRoles - RoomUseCase.ListRoles error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// GrantRole handler untuk memberi role co-host / moderator ke participant (owner atau co-host)
+func (c *RoomController) GrantRole(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("GrantRole - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("GrantRole - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.GrantRoomRoleRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("GrantRole - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.RoomUseCase.GrantRole(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("GrantRole - RoomUseCase.GrantRole error: %s", err)
+		return err
+	}
+
+	c.notifyRoleUpdated(&model.RoomRoleChangeResponse{
+		RoomID:        request.RoomID,
+		UserID:        response.UserID,
+		Role:          response.Role,
+		ParticipantID: response.ParticipantID,
+	})
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// InviteRole handler untuk memberi role ke user terdaftar berdasarkan username (owner atau co-host)
+func (c *RoomController) InviteRole(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id from params
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("InviteRole - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("InviteRole - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.InviteRoomRoleRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+	}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("InviteRole - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+
+	response, err := c.RoomUseCase.InviteRole(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("InviteRole - RoomUseCase.InviteRole error: %s", err)
+		return err
+	}
+
+	// user yang sudah join diberi tahu supaya join ulang untuk token baru
+	if response.ParticipantID != nil {
+		c.notifyRoleUpdated(&model.RoomRoleChangeResponse{
+			RoomID:        request.RoomID,
+			UserID:        response.UserID,
+			Role:          response.Role,
+			ParticipantID: response.ParticipantID,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// RevokeRole handler untuk mencabut role user di room (owner atau co-host)
+func (c *RoomController) RevokeRole(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// parse room_id and user_id from params
+	roomIDUint64, err := strconv.ParseUint(ctx.Params("room_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("RevokeRole - Invalid room_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+	userIDUint64, err := strconv.ParseUint(ctx.Params("user_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("RevokeRole - Invalid user_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if !auth.CanHost() || auth.RoomID == nil || *auth.RoomID != uint(roomIDUint64) {
+		c.Log.Warnf("RevokeRole - Caller cannot host room %d", roomIDUint64)
+		return fiber.ErrForbidden
+	}
+
+	request := &model.RevokeRoomRoleRequest{
+		ActorID: *auth.UserID,
+		RoomID:  uint(roomIDUint64),
+		UserID:  uint(userIDUint64),
+	}
+
+	response, err := c.RoomUseCase.RevokeRole(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("RevokeRole - RoomUseCase.RevokeRole error: %s", err)
+		return err
+	}
+
+	c.notifyRoleRevoked(response)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// notifyRoleUpdated kirim room:role_updated ke user yang role-nya berubah,
+// client perlu join ulang untuk mendapatkan token dengan role baru
+func (c *RoomController) notifyRoleUpdated(change *model.RoomRoleChangeResponse) {
+	wsMessage := websocket.WSMessage{
+		Event: websocket.EventRoomRoleUpdated,
+		Data:  marshalJSONBytes(change),
+	}
+	c.Hub.SendToUsers(change.RoomID, []uint{change.UserID}, marshalJSONBytes(wsMessage))
+}
+
+// notifyRoleRevoked kirim room:role_updated lalu putuskan koneksi websocket,
+// token lama sudah di-invalidate sehingga user harus join ulang
+func (c *RoomController) notifyRoleRevoked(change *model.RoomRoleChangeResponse) {
+	c.notifyRoleUpdated(change)
+	if change.ParticipantID != nil {
+		c.Hub.DisconnectParticipants(change.RoomID, []uint{*change.ParticipantID})
+	}
+}
+
 // marshalJSONBytes helper untuk marshal JSON (room controller specific)
 func marshalJSONBytes(v interface{}) []byte {
 	data, err := json.Marshal(v)
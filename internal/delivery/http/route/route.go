@@ -2,7 +2,10 @@ package route
 
 import (
 	"reisify/internal/delivery/http"
+	"reisify/internal/delivery/http/middleware"
 	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -19,8 +22,17 @@ type RouteConfig struct {
 	MessageController       *http.MessageController
 	QuestionController      *http.QuestionController
 	PollController          *http.PollController
+	QuizController          *http.QuizController
 	XPTransactionController *http.XPTransactionController
 	ActivityController      *http.ActivityController
+	ContentFilterController *http.ContentFilterController
+	ExportController        *http.ExportController
+	AnalyticsController     *http.AnalyticsController
+	WebhookController       *http.WebhookController
+	APIKeyController        *http.APIKeyController
+	SessionController       *http.SessionController
+	AccountController       *http.AccountController
+	OIDCController          *http.OIDCController
 	AuthMiddleware          fiber.Handler
 	WSHandler               *websocket.WebSocketHandler
 	Redis                   *redis.Client
@@ -62,47 +74,147 @@ func (c *RouteConfig) SetupGuestRoute() {
 	c.App.Post("/api/v1/users/login", authLimiter, c.UserController.Login)
 	c.App.Post("/api/v1/users/anonymous", authLimiter, c.UserController.Anon)
 
+	// Account recovery & verifikasi email (token dari link email)
+	c.App.Post("/api/v1/users/password/forgot", authLimiter, c.AccountController.ForgotPassword)
+	c.App.Post("/api/v1/users/password/reset", authLimiter, c.AccountController.ResetPassword)
+	c.App.Post("/api/v1/users/email/verify", authLimiter, c.AccountController.VerifyEmail)
+
+	// SSO OpenID Connect, callback menerbitkan cookie yang sama dengan login
+	c.App.Get("/api/v1/users/oidc/login", authLimiter, c.OIDCController.Login)
+	c.App.Get("/api/v1/users/oidc/callback", authLimiter, c.OIDCController.Callback)
+
+	// refresh dipanggil otomatis oleh client setiap access token habis, limit terpisah dari login
+	refreshLimiter := limiter.New(limiter.Config{
+		Max:        30,
+		Expiration: 1 * time.Minute,
+		Storage:    storage,
+	})
+	c.App.Post("/api/v1/users/refresh", refreshLimiter, c.UserController.Refresh)
+
 	c.App.Get("/api/v1/rooms/:room_code", c.RoomController.Get)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
 	c.App.Use(c.AuthMiddleware)
 
+	// scope API key per route, tidak berpengaruh untuk login biasa
+	scope := middleware.RequireScope
+
 	// User routes
 	c.App.Post("/api/v1/users/logout", c.UserController.Logout)
+	c.App.Post("/api/v1/users/logout-all", c.UserController.LogoutAll)
+	c.App.Post("/api/v1/users/me/email/verification", c.AccountController.SendVerificationEmail)
 
-	// Room routes
-	c.App.Post("/api/v1/rooms", c.RoomController.Create)
-	c.App.Patch("/api/v1/rooms/:room_id/close", c.RoomController.UpdateToClosed)
-	c.App.Delete("/api/v1/rooms/:room_id", c.RoomController.Delete)
-	c.App.Post("/api/v1/rooms/:room_id/announcement", c.RoomController.SendAnnouncement)
-	c.App.Post("/api/v1/rooms/:room_code/join", c.ParticipantController.Join)
-	c.App.Get("/api/v1/rooms/:room_id/participants", c.ParticipantController.List)
+	// Profile routes, perubahan sensitif dan hapus akun butuh password saat ini sehingga dibatasi seperti login
+	var storage fiber.Storage
+	if c.Redis != nil {
+		storage = NewFallbackStorage(c.Redis, c.Log)
+	}
+	reauthLimiter := limiter.New(limiter.Config{
+		Max:        10,
+		Expiration: 1 * time.Minute,
+		Storage:    storage,
+		// per akun, tebakan password dari banyak IP tetap terbatas
+		KeyGenerator: func(ctx *fiber.Ctx) string {
+			if auth := middleware.GetUser(ctx); auth.UserID != nil {
+				return "reauth:user:" + strconv.FormatUint(uint64(*auth.UserID), 10)
+			}
+			return "reauth:ip:" + ctx.IP()
+		},
+	})
+	c.App.Get("/api/v1/users/me", c.AccountController.Profile)
+	c.App.Patch("/api/v1/users/me", reauthLimiter, c.AccountController.UpdateProfile)
+	c.App.Delete("/api/v1/users/me", reauthLimiter, c.AccountController.DeleteAccount)
 
-	c.App.Get("/api/v1/users/me/rooms", c.RoomController.Search)
+	// Session routes (tidak bisa diakses dengan API key)
+	c.App.Get("/api/v1/users/me/sessions", c.SessionController.List)
+	c.App.Delete("/api/v1/users/me/sessions/:session_id", c.SessionController.Revoke)
 
-	c.App.Post("/api/v1/rooms/:room_id/messages", c.MessageController.Send)
-	c.App.Get("/api/v1/rooms/:room_id/messages", c.MessageController.List)
+	// API key routes (tidak bisa diakses dengan API key)
+	c.App.Post("/api/v1/users/me/api-keys", c.APIKeyController.Create)
+	c.App.Get("/api/v1/users/me/api-keys", c.APIKeyController.List)
+	c.App.Delete("/api/v1/users/me/api-keys/:api_key_id", c.APIKeyController.Revoke)
 
-	c.App.Get("/api/v1/rooms/:room_id/leaderboard", c.ParticipantController.Leaderboard)
+	// Room routes
+	c.App.Post("/api/v1/rooms", scope(model.ScopeRoomsWrite), c.RoomController.Create)
+	c.App.Patch("/api/v1/rooms/:room_id/close", scope(model.ScopeRoomsWrite), c.RoomController.UpdateToClosed)
+	c.App.Delete("/api/v1/rooms/:room_id", scope(model.ScopeRoomsWrite), c.RoomController.Delete)
+	c.App.Post("/api/v1/rooms/:room_id/announcement", scope(model.ScopeRoomsWrite), c.RoomController.SendAnnouncement)
+	c.App.Patch("/api/v1/rooms/:room_id/settings", scope(model.ScopeRoomsWrite), c.RoomController.UpdateSettings)
+	c.App.Get("/api/v1/rooms/:room_id/moderators", scope(model.ScopeRoomsRead), c.RoomController.ListModerators)
+	c.App.Post("/api/v1/rooms/:room_id/moderators", scope(model.ScopeRoomsWrite), c.RoomController.AddModerator)
+	c.App.Delete("/api/v1/rooms/:room_id/moderators/:user_id", scope(model.ScopeRoomsWrite), c.RoomController.RemoveModerator)
+	c.App.Get("/api/v1/rooms/:room_id/roles", scope(model.ScopeRoomsRead), c.RoomController.ListRoles)
+	c.App.Post("/api/v1/rooms/:room_id/roles", scope(model.ScopeRoomsWrite), c.RoomController.GrantRole)
+	c.App.Post("/api/v1/rooms/:room_id/roles/invite", scope(model.ScopeRoomsWrite), c.RoomController.InviteRole)
+	c.App.Delete("/api/v1/rooms/:room_id/roles/:user_id", scope(model.ScopeRoomsWrite), c.RoomController.RevokeRole)
+	c.App.Post("/api/v1/rooms/:room_code/join", scope(model.ScopeRoomsRead), c.ParticipantController.Join)
+	c.App.Get("/api/v1/rooms/:room_id/participants", scope(model.ScopeParticipantsRead), c.ParticipantController.List)
+	c.App.Get("/api/v1/rooms/:room_id/presence", scope(model.ScopeParticipantsRead), c.ParticipantController.Presence)
+	c.App.Post("/api/v1/rooms/:room_id/participants/:participant_id/kick", scope(model.ScopeParticipantsWrite), c.ParticipantController.Kick)
+	c.App.Post("/api/v1/rooms/:room_id/participants/:participant_id/ban", scope(model.ScopeParticipantsWrite), c.ParticipantController.Ban)
+	c.App.Post("/api/v1/rooms/:room_id/participants/:participant_id/mute", scope(model.ScopeParticipantsWrite), c.ParticipantController.Mute)
+	c.App.Delete("/api/v1/rooms/:room_id/participants/:participant_id/mute", scope(model.ScopeParticipantsWrite), c.ParticipantController.Unmute)
+	c.App.Get("/api/v1/rooms/:room_id/content-filter", scope(model.ScopeRoomsRead), c.ContentFilterController.Get)
+	c.App.Put("/api/v1/rooms/:room_id/content-filter", scope(model.ScopeRoomsWrite), c.ContentFilterController.Update)
+	c.App.Get("/api/v1/rooms/:room_id/bans", scope(model.ScopeParticipantsRead), c.ParticipantController.ListBans)
+	c.App.Delete("/api/v1/rooms/:room_id/bans/:ban_id", scope(model.ScopeParticipantsWrite), c.ParticipantController.Unban)
+
+	c.App.Get("/api/v1/users/me/rooms", scope(model.ScopeRoomsRead), c.RoomController.Search)
+
+	c.App.Post("/api/v1/rooms/:room_id/messages", scope(model.ScopeMessagesWrite), c.MessageController.Send)
+	c.App.Get("/api/v1/rooms/:room_id/messages", scope(model.ScopeMessagesRead), c.MessageController.List)
+	c.App.Patch("/api/v1/messages/:message_id", scope(model.ScopeMessagesWrite), c.MessageController.Update)
+	c.App.Delete("/api/v1/messages/:message_id", scope(model.ScopeMessagesWrite), c.MessageController.Delete)
+
+	c.App.Get("/api/v1/rooms/:room_id/leaderboard", scope(model.ScopeParticipantsRead), c.ParticipantController.Leaderboard)
 
 	// XP Transactions route
-	c.App.Get("/api/v1/rooms/:room_id/xp-transactions", c.XPTransactionController.GetTransactions)
+	c.App.Get("/api/v1/rooms/:room_id/xp-transactions", scope(model.ScopeParticipantsRead), c.XPTransactionController.GetTransactions)
 
 	// Timeline route (unified activity feed)
-	c.App.Get("/api/v1/rooms/:room_id/timeline", c.ActivityController.GetTimeline)
+	c.App.Get("/api/v1/rooms/:room_id/timeline", scope(model.ScopeRoomsRead), c.ActivityController.GetTimeline)
+
+	// Export route (owner only)
+	c.App.Get("/api/v1/rooms/:room_id/export", scope(model.ScopeRoomsRead), c.ExportController.Export)
+
+	// Analytics route (owner only)
+	c.App.Get("/api/v1/rooms/:room_id/analytics", scope(model.ScopeRoomsRead), c.AnalyticsController.Get)
+
+	// Webhook routes (registered users only)
+	c.App.Post("/api/v1/webhooks", scope(model.ScopeWebhooksWrite), c.WebhookController.Create)
+	c.App.Get("/api/v1/webhooks", scope(model.ScopeWebhooksRead), c.WebhookController.List)
+	c.App.Get("/api/v1/webhooks/:webhook_id", scope(model.ScopeWebhooksRead), c.WebhookController.Get)
+	c.App.Patch("/api/v1/webhooks/:webhook_id", scope(model.ScopeWebhooksWrite), c.WebhookController.Update)
+	c.App.Delete("/api/v1/webhooks/:webhook_id", scope(model.ScopeWebhooksWrite), c.WebhookController.Delete)
+	c.App.Get("/api/v1/webhooks/:webhook_id/deliveries", scope(model.ScopeWebhooksRead), c.WebhookController.ListDeliveries)
+	c.App.Post("/api/v1/webhooks/:webhook_id/deliveries/:delivery_id/replay", scope(model.ScopeWebhooksWrite), c.WebhookController.Replay)
 
 	// Q&A routes
-	c.App.Post("/api/v1/rooms/:room_id/questions", c.QuestionController.Submit)
-	c.App.Get("/api/v1/rooms/:room_id/questions", c.QuestionController.List)
-	c.App.Post("/api/v1/questions/:question_id/upvote", c.QuestionController.Upvote)
-	c.App.Delete("/api/v1/questions/:question_id/upvote", c.QuestionController.RemoveUpvote)
-	c.App.Patch("/api/v1/questions/:question_id/validate", c.QuestionController.Validate)
+	c.App.Post("/api/v1/rooms/:room_id/questions", scope(model.ScopeQuestionsWrite), c.QuestionController.Submit)
+	c.App.Get("/api/v1/rooms/:room_id/questions", scope(model.ScopeQuestionsRead), c.QuestionController.List)
+	c.App.Get("/api/v1/rooms/:room_id/questions/queue", scope(model.ScopeQuestionsRead), c.QuestionController.Queue)
+	c.App.Post("/api/v1/questions/:question_id/upvote", scope(model.ScopeQuestionsWrite), c.QuestionController.Upvote)
+	c.App.Delete("/api/v1/questions/:question_id/upvote", scope(model.ScopeQuestionsWrite), c.QuestionController.RemoveUpvote)
+	c.App.Post("/api/v1/questions/:question_id/replies", scope(model.ScopeQuestionsWrite), c.QuestionController.Reply)
+	c.App.Patch("/api/v1/questions/:question_id/validate", scope(model.ScopeQuestionsWrite), c.QuestionController.Validate)
+	c.App.Patch("/api/v1/questions/:question_id/moderate", scope(model.ScopeQuestionsWrite), c.QuestionController.Moderate)
 
 	// Poll routes
-	c.App.Post("/api/v1/rooms/:room_id/polls", c.PollController.Create)
-	c.App.Get("/api/v1/rooms/:room_id/polls/active", c.PollController.GetActive)
-	c.App.Get("/api/v1/rooms/:room_id/polls", c.PollController.GetHistory)
-	c.App.Post("/api/v1/polls/:poll_id/vote", c.PollController.Vote)
-	c.App.Patch("/api/v1/polls/:poll_id/close", c.PollController.Close)
+	c.App.Post("/api/v1/rooms/:room_id/polls", scope(model.ScopePollsWrite), c.PollController.Create)
+	c.App.Get("/api/v1/rooms/:room_id/polls/active", scope(model.ScopePollsRead), c.PollController.GetActive)
+	c.App.Get("/api/v1/rooms/:room_id/polls", scope(model.ScopePollsRead), c.PollController.GetHistory)
+	c.App.Post("/api/v1/polls/:poll_id/vote", scope(model.ScopePollsWrite), c.PollController.Vote)
+	c.App.Patch("/api/v1/polls/:poll_id", scope(model.ScopePollsWrite), c.PollController.Update)
+	c.App.Patch("/api/v1/polls/:poll_id/options/order", scope(model.ScopePollsWrite), c.PollController.ReorderOptions)
+	c.App.Patch("/api/v1/polls/:poll_id/activate", scope(model.ScopePollsWrite), c.PollController.Activate)
+	c.App.Patch("/api/v1/polls/:poll_id/close", scope(model.ScopePollsWrite), c.PollController.Close)
+	c.App.Get("/api/v1/polls/:poll_id/results", scope(model.ScopePollsRead), c.PollController.GetResults)
+	c.App.Get("/api/v1/polls/:poll_id/answers", scope(model.ScopePollsRead), c.PollController.ListAnswers)
+	c.App.Patch("/api/v1/polls/:poll_id/answers/:answer_id", scope(model.ScopePollsWrite), c.PollController.HideAnswer)
+
+	// Quiz routes (pertanyaan quiz dibuat lewat POST /rooms/:room_id/polls dengan quiz_id)
+	c.App.Post("/api/v1/rooms/:room_id/quizzes", scope(model.ScopePollsWrite), c.QuizController.Create)
+	c.App.Get("/api/v1/quizzes/:quiz_id/summary", scope(model.ScopePollsRead), c.QuizController.GetSummary)
+	c.App.Patch("/api/v1/quizzes/:quiz_id/finish", scope(model.ScopePollsWrite), c.QuizController.Finish)
 }
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionController controller untuk session login user di setiap device
+type SessionController struct {
+	Log            *logrus.Logger
+	SessionUseCase *usecase.SessionUseCase
+	WSHub          *websocket.Hub
+}
+
+// NewSessionController create new instance of SessionController
+func NewSessionController(log *logrus.Logger, sessionUseCase *usecase.SessionUseCase, wsHub *websocket.Hub) *SessionController {
+	return &SessionController{
+		Log:            log,
+		SessionUseCase: sessionUseCase,
+		WSHub:          wsHub,
+	}
+}
+
+// List handler untuk melihat device yang sedang login
+func (c *SessionController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if !canManageSessions(auth) {
+		c.Log.Warn("List - Caller cannot manage sessions")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListSessionsRequest{
+		UserID:    *auth.UserID,
+		SessionID: auth.SessionID,
+	}
+
+	response, err := c.SessionUseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("List - SessionUseCase.List error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Revoke handler untuk logout satu device, koneksi websocket session itu ikut ditutup
+func (c *SessionController) Revoke(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if !canManageSessions(auth) {
+		c.Log.Warn("Revoke - Caller cannot manage sessions")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.RevokeSessionRequest{
+		UserID:    *auth.UserID,
+		SessionID: ctx.Params("session_id"),
+	}
+
+	if err := c.SessionUseCase.Revoke(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Revoke - SessionUseCase.Revoke error: %s", err)
+		return err
+	}
+
+	if c.WSHub != nil {
+		c.WSHub.DisconnectSessions([]string{request.SessionID})
+	}
+
+	// session sendiri dicabut, hapus cookie seperti logout
+	if request.SessionID == auth.SessionID {
+		clearAuthCookie(ctx)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: true,
+	})
+}
+
+// canManageSessions hanya user terdaftar dengan login biasa, bukan anonymous atau API key
+func canManageSessions(auth *model.Auth) bool {
+	return auth.UserID != nil && !auth.IsAnonymous && auth.APIKeyID == nil
+}
@@ -1,6 +1,10 @@
 package http
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/delivery/websocket"
 	"reisify/internal/model"
 	"reisify/internal/usecase"
 
@@ -9,15 +13,19 @@ import (
 )
 
 type UserController struct {
-	Log         *logrus.Logger
-	UserUseCase *usecase.UserUseCase
+	Log            *logrus.Logger
+	UserUseCase    *usecase.UserUseCase
+	AccountUseCase *usecase.AccountUseCase
+	WSHub          *websocket.Hub
 }
 
 // NewUserController create new instance of UserController
-func NewUserController(log *logrus.Logger, userUseCase *usecase.UserUseCase) *UserController {
+func NewUserController(log *logrus.Logger, userUseCase *usecase.UserUseCase, accountUseCase *usecase.AccountUseCase, wsHub *websocket.Hub) *UserController {
 	return &UserController{
-		Log:         log,
-		UserUseCase: userUseCase,
+		Log:            log,
+		UserUseCase:    userUseCase,
+		AccountUseCase: accountUseCase,
+		WSHub:          wsHub,
 	}
 }
 
@@ -32,6 +40,7 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 		c.Log.Warnf("Body parse failed: %s", err)
 		return fiber.ErrBadRequest
 	}
+	request.Client = sessionClient(ctx)
 
 	// call usecase to create user
 	response, err := c.UserUseCase.Create(ctx.UserContext(), request)
@@ -40,8 +49,19 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	// kirim email verifikasi, gagal kirim tidak membatalkan registrasi
+	if c.AccountUseCase != nil {
+		if err := c.AccountUseCase.SendVerificationEmail(ctx.UserContext(), &model.SendVerificationEmailRequest{
+			UserID: response.User.ID,
+			IP:     ctx.IP(),
+		}); err != nil {
+			c.Log.Warnf("SendVerificationEmail after register failed: %s", err)
+		}
+	}
+
 	// set token as HTTP-only cookie
 	setAuthCookie(ctx, response.Token)
+	setRefreshCookie(ctx, response.RefreshToken)
 
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
 		Data: response,
@@ -59,6 +79,7 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		c.Log.Warnf("Body parse failed: %s", err)
 		return fiber.ErrBadRequest
 	}
+	request.Client = sessionClient(ctx)
 
 	// call usecase to login user
 	response, err := c.UserUseCase.Login(ctx.UserContext(), request)
@@ -69,6 +90,7 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 
 	// set token as HTTP-only cookie
 	setAuthCookie(ctx, response.Token)
+	setRefreshCookie(ctx, response.RefreshToken)
 
 	// return response
 	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
@@ -87,6 +109,10 @@ func (c *UserController) Anon(ctx *fiber.Ctx) error {
 		return fiber.ErrBadRequest
 	}
 
+	// fingerprint device untuk ban anonymous participant
+	request.Fingerprint = deviceFingerprint(ctx, request.DeviceID)
+	request.Client = sessionClient(ctx)
+
 	// call usecase to anon user
 	response, err := c.UserUseCase.Anon(ctx.UserContext(), request)
 	if err != nil {
@@ -96,6 +122,7 @@ func (c *UserController) Anon(ctx *fiber.Ctx) error {
 
 	// set token as HTTP-only cookie
 	setAuthCookie(ctx, response.Token)
+	setRefreshCookie(ctx, response.RefreshToken)
 
 	// return response
 	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
@@ -103,21 +130,55 @@ func (c *UserController) Anon(ctx *fiber.Ctx) error {
 	})
 }
 
-// Logout handler untuk logout user dan invalidate token
-func (c *UserController) Logout(ctx *fiber.Ctx) error {
-	// extract token from HTTP-only cookie
-	tokenString := ctx.Cookies("token")
-	if tokenString == "" {
-		return fiber.ErrUnauthorized
+// Refresh handler untuk tukar refresh token dengan access token dan refresh token baru
+func (c *UserController) Refresh(ctx *fiber.Ctx) error {
+	request := new(model.RefreshTokenRequest)
+
+	// refresh token dari body (client non-browser), fallback ke HTTP-only cookie
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			c.Log.Warnf("Body parse failed: %s", err)
+			return fiber.ErrBadRequest
+		}
+	}
+	if request.RefreshToken == "" {
+		request.RefreshToken = ctx.Cookies(refreshCookieName)
 	}
+	request.Client = sessionClient(ctx)
+
+	tokens, err := c.UserUseCase.Refresh(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Refresh failed: %s", err)
+		clearAuthCookie(ctx)
+		return err
+	}
+
+	setAuthCookie(ctx, tokens.AccessToken)
+	setRefreshCookie(ctx, tokens.RefreshToken)
 
-	// call usecase to invalidate token in Redis
-	if err := c.UserUseCase.Logout(ctx.UserContext(), tokenString); err != nil {
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]interface{}{
+			"access_expires_at": tokens.AccessExpiresAt,
+		},
+	})
+}
+
+// Logout handler untuk logout user dan mencabut session saat ini
+func (c *UserController) Logout(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// call usecase to revoke session in Redis
+	if err := c.UserUseCase.Logout(ctx.UserContext(), &model.LogoutRequest{SessionID: auth.SessionID}); err != nil {
 		c.Log.Warnf("Logout failed: %s", err)
 		return err
 	}
 
-	// clear the auth cookie
+	// tutup koneksi websocket session ini
+	if c.WSHub != nil {
+		c.WSHub.DisconnectSessions([]string{auth.SessionID})
+	}
+
+	// clear the auth cookies
 	clearAuthCookie(ctx)
 
 	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
@@ -126,3 +187,51 @@ func (c *UserController) Logout(ctx *fiber.Ctx) error {
 		},
 	})
 }
+
+// LogoutAll handler untuk logout dari semua session user di semua device
+func (c *UserController) LogoutAll(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	// hanya user terdaftar dengan login biasa, bukan anonymous atau API key
+	if !canManageSessions(auth) {
+		return fiber.ErrForbidden
+	}
+
+	sessionIDs, err := c.UserUseCase.LogoutAll(ctx.UserContext(), &model.LogoutAllRequest{UserID: *auth.UserID})
+	if err != nil {
+		c.Log.Warnf("LogoutAll failed: %s", err)
+		return err
+	}
+
+	// tutup koneksi websocket semua session user di semua node
+	if c.WSHub != nil {
+		c.WSHub.DisconnectSessions(sessionIDs)
+	}
+
+	clearAuthCookie(ctx)
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: map[string]string{
+			"message": "Successfully logged out of all sessions",
+		},
+	})
+}
+
+// deviceFingerprint hash device_id dari client, fallback ke IP + User-Agent.
+// device_id dipilih client, jadi ban anonymous hanya best-effort: device_id baru tidak dikenali sebagai banned
+func deviceFingerprint(ctx *fiber.Ctx, deviceID string) string {
+	source := deviceID
+	if source == "" {
+		source = ctx.IP() + "|" + ctx.Get(fiber.HeaderUserAgent)
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+// sessionClient device request saat ini, dicatat di session login
+func sessionClient(ctx *fiber.Ctx) *model.SessionClient {
+	return &model.SessionClient{
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		IP:        ctx.IP(),
+	}
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"reisify/internal/delivery/http/middleware"
+	"reisify/internal/model"
+	"reisify/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookController controller untuk outgoing webhook milik user
+type WebhookController struct {
+	Log            *logrus.Logger
+	WebhookUseCase *usecase.WebhookUseCase
+}
+
+// NewWebhookController create new instance of WebhookController
+func NewWebhookController(log *logrus.Logger, webhookUseCase *usecase.WebhookUseCase) *WebhookController {
+	return &WebhookController{
+		Log:            log,
+		WebhookUseCase: webhookUseCase,
+	}
+}
+
+// Create handler untuk mendaftarkan webhook (akun atau satu room milik user)
+func (c *WebhookController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("Create - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.CreateWebhookRequest{}
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Create - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.UserID = *auth.UserID
+
+	response, err := c.WebhookUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Create - WebhookUseCase.Create error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// List handler untuk melihat semua webhook milik user
+func (c *WebhookController) List(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("List - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	request := &model.ListWebhooksRequest{
+		UserID: *auth.UserID,
+	}
+
+	response, err := c.WebhookUseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("List - WebhookUseCase.List error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Get handler untuk melihat satu webhook
+func (c *WebhookController) Get(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("Get - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	webhookIDUint64, err := strconv.ParseUint(ctx.Params("webhook_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Get - Invalid webhook_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.GetWebhookRequest{
+		UserID:    *auth.UserID,
+		WebhookID: uint(webhookIDUint64),
+	}
+
+	response, err := c.WebhookUseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Get - WebhookUseCase.Get error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Update handler untuk mengubah url, event atau status aktif webhook
+func (c *WebhookController) Update(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("Update - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	webhookIDUint64, err := strconv.ParseUint(ctx.Params("webhook_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Update - Invalid webhook_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.UpdateWebhookRequest{}
+	if err = ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Update - Failed to parse body: %s", err)
+		return fiber.ErrBadRequest
+	}
+	request.UserID = *auth.UserID
+	request.WebhookID = uint(webhookIDUint64)
+
+	response, err := c.WebhookUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Update - WebhookUseCase.Update error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Delete handler untuk menghapus webhook beserta delivery log-nya
+func (c *WebhookController) Delete(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("Delete - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	webhookIDUint64, err := strconv.ParseUint(ctx.Params("webhook_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Delete - Invalid webhook_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.GetWebhookRequest{
+		UserID:    *auth.UserID,
+		WebhookID: uint(webhookIDUint64),
+	}
+
+	if err := c.WebhookUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Delete - WebhookUseCase.Delete error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: true,
+	})
+}
+
+// ListDeliveries handler untuk delivery log webhook (?status=&limit=)
+func (c *WebhookController) ListDeliveries(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("ListDeliveries - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	webhookIDUint64, err := strconv.ParseUint(ctx.Params("webhook_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("ListDeliveries - Invalid webhook_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.ListWebhookDeliveriesRequest{
+		UserID:    *auth.UserID,
+		WebhookID: uint(webhookIDUint64),
+		Status:    ctx.Query("status"),
+		Limit:     ctx.QueryInt("limit", 0),
+	}
+
+	response, err := c.WebhookUseCase.ListDeliveries(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("ListDeliveries - WebhookUseCase.ListDeliveries error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse{
+		Data: response,
+	})
+}
+
+// Replay handler untuk mengirim ulang payload delivery sebagai delivery baru
+func (c *WebhookController) Replay(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+	if auth.UserID == nil {
+		c.Log.Warn("Replay - Anonymous caller cannot manage webhooks")
+		return fiber.ErrForbidden
+	}
+
+	webhookIDUint64, err := strconv.ParseUint(ctx.Params("webhook_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Replay - Invalid webhook_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+	deliveryIDUint64, err := strconv.ParseUint(ctx.Params("delivery_id"), 10, 64)
+	if err != nil {
+		c.Log.Warnf("Replay - Invalid delivery_id: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.ReplayWebhookDeliveryRequest{
+		UserID:     *auth.UserID,
+		WebhookID:  uint(webhookIDUint64),
+		DeliveryID: uint(deliveryIDUint64),
+	}
+
+	response, err := c.WebhookUseCase.Replay(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Replay - WebhookUseCase.Replay error: %s", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse{
+		Data: response,
+	})
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPollSchedulerInterval interval default pengecekan draft poll yang sudah jatuh tempo
+const DefaultPollSchedulerInterval = 5 * time.Second
+
+// PollScheduler background worker yang mengaktifkan draft poll sesuai ScheduledAt.
+// Aman dijalankan di beberapa replica karena poll di-lock dengan SKIP LOCKED.
+type PollScheduler struct {
+	Log         *logrus.Logger
+	PollUseCase *usecase.PollUseCase
+	WSHub       *websocket.Hub
+	Interval    time.Duration
+}
+
+func NewPollScheduler(log *logrus.Logger, pollUseCase *usecase.PollUseCase, hub *websocket.Hub, interval time.Duration) *PollScheduler {
+	if interval <= 0 {
+		interval = DefaultPollSchedulerInterval
+	}
+	return &PollScheduler{
+		Log:         log,
+		PollUseCase: pollUseCase,
+		WSHub:       hub,
+		Interval:    interval,
+	}
+}
+
+// Run cek poll terjadwal setiap Interval sampai ctx selesai
+func (s *PollScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+// tick aktifkan poll yang jatuh tempo lalu broadcast poll:created ke room masing-masing
+func (s *PollScheduler) tick(ctx context.Context, now time.Time) {
+	activated, err := s.PollUseCase.ActivateScheduled(ctx, now)
+	if err != nil {
+		s.Log.Warnf("PollScheduler - ActivateScheduled error: %v", err)
+		return
+	}
+
+	for i := range activated {
+		response := &activated[i]
+		s.Log.Infof("PollScheduler - Poll %d activated in room %d", response.Poll.ID, response.Poll.RoomID)
+
+		if s.WSHub == nil {
+			continue
+		}
+		data, err := json.Marshal(response)
+		if err != nil {
+			continue
+		}
+		message, err := json.Marshal(websocket.WSMessage{
+			Event: websocket.EventPollCreated,
+			Data:  data,
+		})
+		if err != nil {
+			continue
+		}
+		s.WSHub.BroadcastToRoom(response.Poll.RoomID, message)
+	}
+}
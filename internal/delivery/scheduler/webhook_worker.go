@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"reisify/internal/usecase"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultWebhookWorkerInterval interval default pengecekan webhook delivery yang perlu dikirim ulang
+const DefaultWebhookWorkerInterval = 5 * time.Second
+
+// WebhookWorker background worker yang mengirim ulang webhook delivery yang gagal sesuai backoff.
+// Aman dijalankan di beberapa replica karena delivery di-claim dengan SKIP LOCKED.
+type WebhookWorker struct {
+	Log            *logrus.Logger
+	WebhookUseCase *usecase.WebhookUseCase
+	Interval       time.Duration
+}
+
+func NewWebhookWorker(log *logrus.Logger, webhookUseCase *usecase.WebhookUseCase, interval time.Duration) *WebhookWorker {
+	if interval <= 0 {
+		interval = DefaultWebhookWorkerInterval
+	}
+	return &WebhookWorker{
+		Log:            log,
+		WebhookUseCase: webhookUseCase,
+		Interval:       interval,
+	}
+}
+
+// Run kirim delivery yang jatuh tempo setiap Interval sampai ctx selesai
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.tick(ctx, now)
+		}
+	}
+}
+
+// tick proses satu batch delivery yang jatuh tempo
+func (w *WebhookWorker) tick(ctx context.Context, now time.Time) {
+	count, err := w.WebhookUseCase.DeliverDue(ctx, now)
+	if err != nil {
+		w.Log.Warnf("WebhookWorker - DeliverDue error: %v", err)
+		return
+	}
+	if count > 0 {
+		w.Log.Infof("WebhookWorker - %d webhook deliveries attempted", count)
+	}
+}
@@ -0,0 +1,256 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reisify/internal/model"
+	"sort"
+	"sync"
+)
+
+// Envelope pesan yang dikirim antar node lewat backplane
+type Envelope struct {
+	NodeID  string `json:"node_id"` // node asal pesan, dipakai untuk skip echo
+	RoomID  uint   `json:"room_id"`
+	Payload []byte `json:"payload"` // WSMessage yang sudah di-marshal
+
+	// target opsional, jika keduanya kosong pesan dikirim ke semua client di room
+	UserIDs        []uint `json:"user_ids,omitempty"`
+	ParticipantIDs []uint `json:"participant_ids,omitempty"`
+
+	// target session login, RoomID 0 berarti semua room
+	SessionIDs []string `json:"session_ids,omitempty"`
+
+	// jika true, client target diputus koneksinya (kick / ban) dan Payload diabaikan
+	Disconnect bool `json:"disconnect,omitempty"`
+}
+
+// matches cek apakah client termasuk target envelope
+func (e Envelope) matches(client *Client) bool {
+	if len(e.UserIDs) == 0 && len(e.ParticipantIDs) == 0 && len(e.SessionIDs) == 0 {
+		return true
+	}
+	for _, id := range e.UserIDs {
+		if client.userID != 0 && client.userID == id {
+			return true
+		}
+	}
+	for _, id := range e.ParticipantIDs {
+		if client.participantID == id {
+			return true
+		}
+	}
+	for _, id := range e.SessionIDs {
+		if client.sessionID != "" && client.sessionID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Backplane menghubungkan beberapa Hub (satu per node) supaya broadcast dan presence
+// konsisten walaupun client terkoneksi ke replica yang berbeda
+type Backplane interface {
+	// NodeID identitas unik node ini
+	NodeID() string
+
+	// Publish mengirim envelope ke semua node (termasuk node sendiri)
+	Publish(ctx context.Context, env Envelope) error
+
+	// Subscribe mulai menerima envelope di background sampai ctx selesai
+	Subscribe(ctx context.Context, handler func(Envelope)) error
+
+	// SetPresence menyimpan jumlah koneksi participant di node ini, 0 berarti hapus
+	SetPresence(ctx context.Context, roomID, participantID uint, connections int) error
+
+	// OnlineParticipants daftar participant yang online di room dari semua node
+	OnlineParticipants(ctx context.Context, roomID uint) ([]uint, error)
+
+	// SetStatus menyimpan status presence participant di node ini (online | idle | away), offline berarti hapus
+	SetStatus(ctx context.Context, roomID, participantID uint, status string) error
+
+	// Statuses status presence participant di room, jika beda antar node diambil yang paling aktif
+	Statuses(ctx context.Context, roomID uint) (map[uint]string, error)
+}
+
+// presenceRank urutan status presence, koneksi paling aktif menentukan status participant
+var presenceRank = map[string]int{
+	model.PresenceAway:   1,
+	model.PresenceIdle:   2,
+	model.PresenceOnline: 3,
+}
+
+// mergeStatus simpan status ke statuses jika lebih aktif dari yang sudah ada
+func mergeStatus(statuses map[uint]string, participantID uint, status string) {
+	if presenceRank[status] > presenceRank[statuses[participantID]] {
+		statuses[participantID] = status
+	}
+}
+
+// newNodeID generate random node id
+func newNodeID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate node id: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// ========================================
+// IN-MEMORY BACKPLANE (single process / testing)
+// ========================================
+
+// MemoryBus bus in-memory yang di-share oleh beberapa MemoryBackplane,
+// dipakai untuk single node atau untuk mensimulasikan multi node di test
+type MemoryBus struct {
+	mu          sync.RWMutex
+	subscribers map[string]func(Envelope)
+	presence    map[uint]map[string]map[uint]int    // roomID -> nodeID -> participantID -> connections
+	statuses    map[uint]map[string]map[uint]string // roomID -> nodeID -> participantID -> status
+}
+
+// NewMemoryBus membuat bus in-memory baru
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		subscribers: make(map[string]func(Envelope)),
+		presence:    make(map[uint]map[string]map[uint]int),
+		statuses:    make(map[uint]map[string]map[uint]string),
+	}
+}
+
+// NewBackplane membuat backplane untuk satu node yang terhubung ke bus ini
+func (b *MemoryBus) NewBackplane() Backplane {
+	return &memoryBackplane{bus: b, nodeID: newNodeID()}
+}
+
+// NewMemoryBackplane backplane in-memory untuk satu node
+func NewMemoryBackplane() Backplane {
+	return NewMemoryBus().NewBackplane()
+}
+
+type memoryBackplane struct {
+	bus    *MemoryBus
+	nodeID string
+}
+
+func (m *memoryBackplane) NodeID() string {
+	return m.nodeID
+}
+
+func (m *memoryBackplane) Publish(ctx context.Context, env Envelope) error {
+	m.bus.mu.RLock()
+	handlers := make([]func(Envelope), 0, len(m.bus.subscribers))
+	for _, handler := range m.bus.subscribers {
+		handlers = append(handlers, handler)
+	}
+	m.bus.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(env)
+	}
+	return nil
+}
+
+func (m *memoryBackplane) Subscribe(ctx context.Context, handler func(Envelope)) error {
+	m.bus.mu.Lock()
+	m.bus.subscribers[m.nodeID] = handler
+	m.bus.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.bus.mu.Lock()
+		delete(m.bus.subscribers, m.nodeID)
+		m.bus.mu.Unlock()
+	}()
+	return nil
+}
+
+func (m *memoryBackplane) SetPresence(ctx context.Context, roomID, participantID uint, connections int) error {
+	m.bus.mu.Lock()
+	defer m.bus.mu.Unlock()
+
+	if m.bus.presence[roomID] == nil {
+		m.bus.presence[roomID] = make(map[string]map[uint]int)
+	}
+	if m.bus.presence[roomID][m.nodeID] == nil {
+		m.bus.presence[roomID][m.nodeID] = make(map[uint]int)
+	}
+
+	if connections <= 0 {
+		delete(m.bus.presence[roomID][m.nodeID], participantID)
+		if len(m.bus.presence[roomID][m.nodeID]) == 0 {
+			delete(m.bus.presence[roomID], m.nodeID)
+		}
+		if len(m.bus.presence[roomID]) == 0 {
+			delete(m.bus.presence, roomID)
+		}
+		return nil
+	}
+
+	m.bus.presence[roomID][m.nodeID][participantID] = connections
+	return nil
+}
+
+func (m *memoryBackplane) OnlineParticipants(ctx context.Context, roomID uint) ([]uint, error) {
+	m.bus.mu.RLock()
+	defer m.bus.mu.RUnlock()
+
+	seen := make(map[uint]bool)
+	for _, participants := range m.bus.presence[roomID] {
+		for participantID := range participants {
+			seen[participantID] = true
+		}
+	}
+	return sortedIDs(seen), nil
+}
+
+func (m *memoryBackplane) SetStatus(ctx context.Context, roomID, participantID uint, status string) error {
+	m.bus.mu.Lock()
+	defer m.bus.mu.Unlock()
+
+	if m.bus.statuses[roomID] == nil {
+		m.bus.statuses[roomID] = make(map[string]map[uint]string)
+	}
+	if m.bus.statuses[roomID][m.nodeID] == nil {
+		m.bus.statuses[roomID][m.nodeID] = make(map[uint]string)
+	}
+
+	if status == model.PresenceOffline {
+		delete(m.bus.statuses[roomID][m.nodeID], participantID)
+		if len(m.bus.statuses[roomID][m.nodeID]) == 0 {
+			delete(m.bus.statuses[roomID], m.nodeID)
+		}
+		if len(m.bus.statuses[roomID]) == 0 {
+			delete(m.bus.statuses, roomID)
+		}
+		return nil
+	}
+
+	m.bus.statuses[roomID][m.nodeID][participantID] = status
+	return nil
+}
+
+func (m *memoryBackplane) Statuses(ctx context.Context, roomID uint) (map[uint]string, error) {
+	m.bus.mu.RLock()
+	defer m.bus.mu.RUnlock()
+
+	statuses := make(map[uint]string)
+	for _, participants := range m.bus.statuses[roomID] {
+		for participantID, status := range participants {
+			mergeStatus(statuses, participantID, status)
+		}
+	}
+	return statuses, nil
+}
+
+// sortedIDs mengubah set id menjadi slice terurut
+func sortedIDs(set map[uint]bool) []uint {
+	ids := make([]uint, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
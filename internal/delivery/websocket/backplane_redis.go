@@ -0,0 +1,238 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reisify/internal/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// channel pub/sub untuk broadcast antar node
+	redisBroadcastChannel = "ws:broadcast"
+
+	// TTL key liveness node; node yang mati otomatis tidak dihitung di presence
+	redisNodeTTL = 30 * time.Second
+
+	// interval refresh key liveness node, harus < redisNodeTTL
+	redisNodeHeartbeat = 10 * time.Second
+)
+
+// RedisBackplane backplane berbasis Redis pub/sub, presence disimpan di hash per room
+// dengan field "<node_id>:<participant_id>" supaya koneksi dari node yang mati bisa dibersihkan
+type RedisBackplane struct {
+	client *redis.Client
+	nodeID string
+	log    *logrus.Logger
+}
+
+// NewRedisBackplane membuat backplane Redis baru
+func NewRedisBackplane(client *redis.Client, log *logrus.Logger) *RedisBackplane {
+	return &RedisBackplane{
+		client: client,
+		nodeID: newNodeID(),
+		log:    log,
+	}
+}
+
+func (r *RedisBackplane) NodeID() string {
+	return r.nodeID
+}
+
+func (r *RedisBackplane) Publish(ctx context.Context, env Envelope) error {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, redisBroadcastChannel, data).Err()
+}
+
+func (r *RedisBackplane) Subscribe(ctx context.Context, handler func(Envelope)) error {
+	sub := r.client.Subscribe(ctx, redisBroadcastChannel)
+
+	// tunggu konfirmasi subscribe supaya error koneksi langsung ketahuan
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return err
+	}
+
+	if err := r.touchNode(ctx); err != nil {
+		r.log.Warnf("failed to register websocket node: %v", err)
+	}
+
+	go func() {
+		defer sub.Close()
+
+		ticker := time.NewTicker(redisNodeHeartbeat)
+		defer ticker.Stop()
+
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.touchNode(ctx); err != nil {
+					r.log.Warnf("failed to refresh websocket node heartbeat: %v", err)
+				}
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				var env Envelope
+				if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+					r.log.Warnf("invalid backplane envelope: %v", err)
+					continue
+				}
+				handler(env)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *RedisBackplane) SetPresence(ctx context.Context, roomID, participantID uint, connections int) error {
+	key := presenceKey(roomID)
+	field := fmt.Sprintf("%s:%d", r.nodeID, participantID)
+
+	if connections <= 0 {
+		return r.client.HDel(ctx, key, field).Err()
+	}
+	return r.client.HSet(ctx, key, field, connections).Err()
+}
+
+func (r *RedisBackplane) OnlineParticipants(ctx context.Context, roomID uint) ([]uint, error) {
+	key := presenceKey(roomID)
+
+	entries, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// kumpulkan node yang muncul lalu cek liveness sekaligus
+	participantsByNode := make(map[string][]uint)
+	for field := range entries {
+		nodeID, idStr, found := strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+		participantID, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		participantsByNode[nodeID] = append(participantsByNode[nodeID], uint(participantID))
+	}
+
+	online := make(map[uint]bool)
+	for nodeID, participants := range participantsByNode {
+		alive, err := r.nodeAlive(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !alive {
+			// node sudah mati, bersihkan field miliknya
+			stale := make([]string, 0, len(participants))
+			for _, participantID := range participants {
+				stale = append(stale, fmt.Sprintf("%s:%d", nodeID, participantID))
+			}
+			_ = r.client.HDel(ctx, key, stale...).Err()
+			continue
+		}
+
+		for _, participantID := range participants {
+			online[participantID] = true
+		}
+	}
+
+	return sortedIDs(online), nil
+}
+
+func (r *RedisBackplane) SetStatus(ctx context.Context, roomID, participantID uint, status string) error {
+	key := statusKey(roomID)
+	field := fmt.Sprintf("%s:%d", r.nodeID, participantID)
+
+	if status == model.PresenceOffline {
+		return r.client.HDel(ctx, key, field).Err()
+	}
+	return r.client.HSet(ctx, key, field, status).Err()
+}
+
+func (r *RedisBackplane) Statuses(ctx context.Context, roomID uint) (map[uint]string, error) {
+	key := statusKey(roomID)
+
+	entries, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[uint]string)
+	aliveNodes := make(map[string]bool)
+	var stale []string
+	for field, status := range entries {
+		nodeID, idStr, found := strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+		participantID, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		alive, checked := aliveNodes[nodeID]
+		if !checked {
+			if alive, err = r.nodeAlive(ctx, nodeID); err != nil {
+				return nil, err
+			}
+			aliveNodes[nodeID] = alive
+		}
+		if !alive {
+			stale = append(stale, field)
+			continue
+		}
+		mergeStatus(statuses, uint(participantID), status)
+	}
+
+	// bersihkan status milik node yang sudah mati
+	if len(stale) > 0 {
+		_ = r.client.HDel(ctx, key, stale...).Err()
+	}
+	return statuses, nil
+}
+
+// nodeAlive cek key liveness node, node sendiri selalu dianggap hidup
+func (r *RedisBackplane) nodeAlive(ctx context.Context, nodeID string) (bool, error) {
+	if nodeID == r.nodeID {
+		return true, nil
+	}
+	n, err := r.client.Exists(ctx, nodeKey(nodeID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// touchNode refresh key liveness node ini
+func (r *RedisBackplane) touchNode(ctx context.Context) error {
+	return r.client.Set(ctx, nodeKey(r.nodeID), 1, redisNodeTTL).Err()
+}
+
+func presenceKey(roomID uint) string {
+	return fmt.Sprintf("ws:presence:%d", roomID)
+}
+
+func statusKey(roomID uint) string {
+	return fmt.Sprintf("ws:presence_status:%d", roomID)
+}
+
+func nodeKey(nodeID string) string {
+	return "ws:node:" + nodeID
+}
@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"reisify/internal/model"
+	"sync"
 	"time"
 
 	"github.com/gofiber/contrib/websocket"
@@ -23,6 +25,9 @@ type Client struct {
 	conn *websocket.Conn // koneksi websocket
 	send chan []byte     // channel untuk mengirim pesan ke client
 
+	kick     chan struct{} // ditutup saat client dikeluarkan dari room
+	kickOnce sync.Once
+
 	// identitas client
 	userID        uint   // dari jwt token
 	roomID        uint   // room yang dijoin
@@ -30,11 +35,83 @@ type Client struct {
 	displayName   string // nama yang ditampilkan di UI
 	isAnonymous   bool   // anonymous
 	isRoomOwner   bool   // true jika user adalah pembuat room (host)
+	roomRole      string // owner | co_host | moderator | participant
+	sessionID     string // session login dari token, untuk disconnect saat session dicabut
+
+	// presence dari heartbeat client, dibaca juga oleh sweep hub
+	presenceMu    sync.Mutex
+	lastHeartbeat time.Time // heartbeat terakhir, awal koneksi jika belum ada
+	idle          bool      // client melaporkan idle di heartbeat terakhir
 
 	// handler reference (untuk process events)
 	messageHandler func(*Client, []byte) error
 }
 
+// canHost true untuk owner dan co-host room
+func (c *Client) canHost() bool {
+	return c.isRoomOwner || model.RoomRoleCanHost(c.roomRole)
+}
+
+// heartbeat catat heartbeat presence dari client
+func (c *Client) heartbeat(idle bool) {
+	c.presenceMu.Lock()
+	defer c.presenceMu.Unlock()
+
+	c.lastHeartbeat = time.Now()
+	c.idle = idle
+}
+
+// presenceStatus status presence koneksi ini: away jika heartbeat berhenti, idle jika dilaporkan client
+func (c *Client) presenceStatus(now time.Time) string {
+	c.presenceMu.Lock()
+	defer c.presenceMu.Unlock()
+
+	if now.Sub(c.lastHeartbeat) >= presenceAwayAfter {
+		return model.PresenceAway
+	}
+	if c.idle {
+		return model.PresenceIdle
+	}
+	return model.PresenceOnline
+}
+
+// disconnect minta WritePump menutup koneksi client, aman dipanggil berkali-kali
+func (c *Client) disconnect() {
+	c.kickOnce.Do(func() {
+		close(c.kick)
+	})
+}
+
+// reply kirim pesan hanya ke client ini, pesan dibuang jika antrian penuh supaya ReadPump tidak tertahan
+func (c *Client) reply(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+		c.hub.log.WithField("participant_id", c.participantID).Debug("Failed to send reply to client")
+	}
+}
+
+// replyAck kirim ack untuk event client, hanya jika client mengirim request_id
+func (c *Client) replyAck(request *WSMessage, result interface{}) {
+	if request.RequestID == "" {
+		return
+	}
+	c.reply(mustMarshal(WSMessage{
+		Event:     EventAck,
+		RequestID: request.RequestID,
+		Data:      mustMarshal(AckPayload{Event: request.Event, Result: result}),
+	}))
+}
+
+// replyError kirim event error untuk event client yang gagal diproses
+func (c *Client) replyError(request *WSMessage, err error) {
+	c.reply(mustMarshal(WSMessage{
+		Event:     EventError,
+		RequestID: request.RequestID,
+		Data:      mustMarshal(NewErrorPayload(request.Event, err)),
+	}))
+}
+
 // ReadPump goroutine untuk membaca pesan dari client
 func (c *Client) ReadPump() {
 	defer func() {
@@ -110,6 +187,18 @@ func (c *Client) WritePump() {
 			if err = w.Close(); err != nil {
 				return
 			}
+		case <-c.kick:
+			// kirim dulu pesan yang masih antri (misal participant:kicked) lalu tutup koneksi
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			for range len(c.send) {
+				msg, ok := <-c.send
+				if !ok {
+					break
+				}
+				_ = c.conn.WriteMessage(websocket.TextMessage, msg)
+			}
+			_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "removed from room"))
+			return
 		case <-ticker.C:
 			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
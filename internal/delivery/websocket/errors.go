@@ -0,0 +1,63 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxRequestIDLength panjang maksimal request_id dari client
+const maxRequestIDLength = 64
+
+// Kode error event websocket
+const (
+	ErrorCodeInvalidPayload = "invalid_payload" // JSON tidak valid
+	ErrorCodeUnknownEvent   = "unknown_event"   // event tidak dikenal
+	ErrorCodeInvalidRequest = "invalid_request" // 400: validasi gagal atau state tidak mengizinkan
+	ErrorCodeUnauthorized   = "unauthorized"    // 401
+	ErrorCodeForbidden      = "forbidden"       // 403: bukan host, muted, dll
+	ErrorCodeNotFound       = "not_found"       // 404
+	ErrorCodeConflict       = "conflict"        // 409: sudah vote / upvote, dll
+	ErrorCodeRateLimited    = "rate_limited"    // 429
+	ErrorCodeInternal       = "internal_error"  // 5xx dan error lain
+)
+
+// errUnknownEvent dikembalikan dispatcher untuk event yang tidak dikenal
+var errUnknownEvent = errors.New("unknown event")
+
+// errorCodes kode error per status fiber.Err* yang dikembalikan usecase
+var errorCodes = map[int]string{
+	fiber.StatusBadRequest:      ErrorCodeInvalidRequest,
+	fiber.StatusUnauthorized:    ErrorCodeUnauthorized,
+	fiber.StatusForbidden:       ErrorCodeForbidden,
+	fiber.StatusNotFound:        ErrorCodeNotFound,
+	fiber.StatusConflict:        ErrorCodeConflict,
+	fiber.StatusTooManyRequests: ErrorCodeRateLimited,
+}
+
+// NewErrorPayload ubah error handler menjadi payload event error.
+// Pesan *fiber.Error diteruskan ke client, error lain (database, SFU) hanya "Internal Server Error"
+func NewErrorPayload(event string, err error) ErrorPayload {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var fiberErr *fiber.Error
+
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return ErrorPayload{Event: event, Code: ErrorCodeInvalidPayload, Status: fiber.StatusBadRequest, Message: "Invalid payload"}
+	case errors.Is(err, errUnknownEvent):
+		return ErrorPayload{Event: event, Code: ErrorCodeUnknownEvent, Status: fiber.StatusBadRequest, Message: "Unknown event"}
+	case errors.As(err, &fiberErr):
+		code, ok := errorCodes[fiberErr.Code]
+		if !ok {
+			code = ErrorCodeInvalidRequest
+			if fiberErr.Code >= fiber.StatusInternalServerError {
+				code = ErrorCodeInternal
+			}
+		}
+		return ErrorPayload{Event: event, Code: code, Status: fiberErr.Code, Message: fiberErr.Message}
+	default:
+		return ErrorPayload{Event: event, Code: ErrorCodeInternal, Status: fiber.StatusInternalServerError, Message: "Internal Server Error"}
+	}
+}
@@ -7,11 +7,19 @@ import (
 	"reisify/internal/model"
 	"reisify/internal/sfu"
 	"reisify/internal/usecase"
+	"slices"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/pion/webrtc/v4"
 )
 
+const (
+	// jumlah chat terakhir dan question teratas di snapshot room:state
+	roomStateMessageLimit  = 50
+	roomStateQuestionLimit = 20
+)
+
 type EventHandler struct {
 	messageUseCase     *usecase.MessageUseCase
 	participantUseCase *usecase.ParticipantUseCase
@@ -30,36 +38,73 @@ func NewEventHandler(messageUseCase *usecase.MessageUseCase, participantUseCase
 	}
 }
 
-// HandleMessage process incoming websocket messages
+// HandleMessage process incoming websocket messages.
+// Error dikirim balik ke pengirim sebagai event error, jika request_id diisi hasil sukses dikirim sebagai ack
 func (h *EventHandler) HandleMessage(client *Client, data []byte) error {
 	var wsMsg WSMessage
 	if err := json.Unmarshal(data, &wsMsg); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse ws message")
+		client.replyError(&wsMsg, err)
+		return err
+	}
+
+	// request_id terlalu panjang tidak dikembalikan ke client
+	if len(wsMsg.RequestID) > maxRequestIDLength {
+		wsMsg.RequestID = ""
+		err := fiber.NewError(fiber.StatusBadRequest, "request_id is too long")
+		client.replyError(&wsMsg, err)
+		return err
+	}
+
+	result, err := h.dispatch(client, &wsMsg)
+	if err != nil {
+		client.replyError(&wsMsg, err)
 		return err
 	}
+	client.replyAck(&wsMsg, result)
+	return nil
+}
 
-	// route ke handler berdasarkan event type
-	switch wsMsg.Event {
+// dispatch route ke handler berdasarkan event type, return hasil untuk ack
+func (h *EventHandler) dispatch(client *Client, msg *WSMessage) (interface{}, error) {
+	switch msg.Event {
+	case EventRoomResume:
+		return h.handleRoomResume(client, msg.Data)
+	case EventPresenceHeartbeat:
+		return h.handlePresenceHeartbeat(client, msg.Data)
 	case EventMessageSend:
-		return h.handleMessageSend(client, wsMsg.Data)
+		return h.handleMessageSend(client, msg.Data)
+	case EventMessageEdit:
+		return h.handleMessageEdit(client, msg.Data)
+	case EventMessageDelete:
+		return h.handleMessageDelete(client, msg.Data)
 	case EventChatTyping:
-		return h.handleChatTyping(client, wsMsg.Data)
+		return h.handleChatTyping(client, msg.Data)
 	case EventLeaderboardRequest:
-		return h.handleLeaderboardRequest(client, wsMsg.Data)
+		return h.handleLeaderboardRequest(client, msg.Data)
 	// Q&A events
 	case EventQuestionSubmit:
-		return h.handleQuestionSubmit(client, wsMsg.Data)
+		return h.handleQuestionSubmit(client, msg.Data)
 	case EventQuestionUpvote:
-		return h.handleQuestionUpvote(client, wsMsg.Data)
+		return h.handleQuestionUpvote(client, msg.Data)
 	case EventQuestionRemoveUpvote:
-		return h.handleQuestionRemoveUpvote(client, wsMsg.Data)
+		return h.handleQuestionRemoveUpvote(client, msg.Data)
+	// Poll events
+	case EventPollVote:
+		return h.handlePollVote(client, msg.Data)
+	case EventPollCreate:
+		return h.handlePollCreate(client, msg.Data)
+	case EventPollActivate:
+		return h.handlePollActivate(client, msg.Data)
+	case EventPollClose:
+		return h.handlePollClose(client, msg.Data)
 	// WebRTC
 	case EventWebrtcOffer:
-		return h.handleWebrtcOffer(client, wsMsg.Data)
+		return h.handleWebrtcOffer(client, msg.Data)
 	case EventWebrtcAnswer:
-		return h.handleWebrtcAnswer(client, wsMsg.Data)
+		return h.handleWebrtcAnswer(client, msg.Data)
 	case EventWebrtcCandidate:
-		return h.handleWebrtcCandidate(client, wsMsg.Data)
+		return h.handleWebrtcCandidate(client, msg.Data)
 	// Conference events
 	case EventConferenceStart:
 		return h.handleConferenceStart(client)
@@ -74,23 +119,196 @@ func (h *EventHandler) HandleMessage(client *Client, data []byte) error {
 	case EventLowerHand:
 		return h.handleLowerHand(client)
 	case EventPromoteSpeaker:
-		return h.handlePromoteSpeaker(client, wsMsg.Data)
+		return h.handlePromoteSpeaker(client, msg.Data)
 	case EventDemoteSpeaker:
-		return h.handleDemoteSpeaker(client, wsMsg.Data)
+		return h.handleDemoteSpeaker(client, msg.Data)
 	default:
-		client.hub.log.WithField("event", wsMsg.Event).Warn("unknown event")
-		return nil
+		client.hub.log.WithField("event", msg.Event).Warn("unknown event")
+		return nil, errUnknownEvent
+	}
+}
+
+// SendRoomState kirim snapshot room:state ke client yang baru terkoneksi supaya UI bisa dibangun tanpa
+// beberapa request HTTP. Jika snapshot gagal client menerima event error dan harus fetch lewat HTTP
+func (h *EventHandler) SendRoomState(client *Client) {
+	state, err := h.buildRoomState(client)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to build room state")
+		client.replyError(&WSMessage{Event: EventRoomState}, err)
+		return
+	}
+
+	client.reply(mustMarshal(WSMessage{
+		Event: EventRoomState,
+		Data:  mustMarshal(state),
+	}))
+}
+
+// buildRoomState kumpulkan snapshot room dari usecase yang sama dengan endpoint HTTP
+func (h *EventHandler) buildRoomState(client *Client) (*RoomStatePayload, error) {
+	ctx := context.Background()
+
+	// seq diambil sebelum data dibaca, broadcast setelahnya diterima live atau lewat room:resume
+	seq, err := client.hub.Seq(client.roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	// register diproses async oleh hub, presence client ini bisa belum tercatat
+	onlineIDs := client.hub.OnlineParticipants(client.roomID)
+	if !slices.Contains(onlineIDs, client.participantID) {
+		onlineIDs = append(onlineIDs, client.participantID)
+	}
+	participants, err := h.participantUseCase.ListOnline(ctx, &model.ListOnlineParticipantsRequest{
+		RoomID:         client.roomID,
+		ParticipantIDs: onlineIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.hub.AnnotatePresence(client.roomID, participants.Participants)
+	for _, participant := range participants.Participants {
+		if participant.ID == client.participantID {
+			participant.Status = model.PresenceOnline
+		}
+	}
+
+	polls, err := h.pollUseCase.GetActivePolls(ctx, &model.GetActivePollsRequest{
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := h.questionUseCase.List(ctx, &model.GetQuestionsRequest{
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+		SortBy:        "upvotes",
+		Limit:         roomStateQuestionLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := h.messageUseCase.List(ctx, &model.GetMessagesRequest{
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+		Limit:         roomStateMessageLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := h.participantUseCase.Leaderboard(ctx, &model.GetLeaderboardRequest{
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	me := RoomStateMe{
+		ParticipantID: client.participantID,
+		DisplayName:   client.displayName,
+		IsRoomOwner:   client.isRoomOwner,
+		RoomRole:      client.roomRole,
+	}
+	if leaderboard.MyRank != nil {
+		me.XPScore = leaderboard.MyRank.XPScore
+		me.Rank = leaderboard.MyRank.Rank
+	}
+
+	return &RoomStatePayload{
+		Seq:          seq,
+		Participants: participants.Participants,
+		OnlineCount:  len(participants.Participants),
+		Polls:        polls.Polls,
+		Questions:    questions.Questions,
+		Messages:     messages.Messages,
+		Conference:   conferenceStateData(h.sfuManager.GetRoom(client.roomID).GetConferenceState(), client),
+		Me:           me,
+	}, nil
+}
+
+// conferenceStateData payload conference:state untuk client, termasuk role client tersebut
+func conferenceStateData(state sfu.ConferenceState, client *Client) map[string]interface{} {
+	return map[string]interface{}{
+		"host_id":       state.HostID,
+		"hosts":         state.Hosts,
+		"is_active":     state.IsActive,
+		"speakers":      state.Speakers,
+		"raised_hands":  state.RaisedHands,
+		"is_room_owner": client.isRoomOwner, // inform client their role
+		"room_role":     client.roomRole,
+	}
+}
+
+// handleRoomResume kirim ulang broadcast room yang terlewat sejak last_seq, urut berdasarkan seq.
+// Jika gap sudah tidak ada di replay buffer client diminta full resync lewat HTTP
+func (h *EventHandler) handleRoomResume(client *Client, data json.RawMessage) (interface{}, error) {
+	var payload struct {
+		LastSeq uint64 `json:"last_seq"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse resume payload")
+		return nil, err
+	}
+
+	events, current, ok, err := client.hub.Replay(client.roomID, payload.LastSeq)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to load replay buffer")
+		return nil, err
+	}
+
+	result := ResumePayload{LastSeq: current}
+	event := EventRoomResyncRequired
+	if ok {
+		event = EventRoomResumed
+		result.Replayed = len(events)
+	}
+
+	// tunggu antrian kirim supaya replay tidak terpotong, berhenti jika client dikeluarkan
+	events = append(events, mustMarshal(WSMessage{Event: event, Data: mustMarshal(result)}))
+	for _, message := range events {
+		select {
+		case client.send <- message:
+		case <-client.kick:
+			return nil, nil
+		}
+	}
+	return result, nil
+}
+
+// handlePresenceHeartbeat catat heartbeat client, state "idle" jika tab tidak aktif / tidak ada input.
+// presence:update di-broadcast jika status participant berubah
+func (h *EventHandler) handlePresenceHeartbeat(client *Client, data json.RawMessage) (interface{}, error) {
+	var payload struct {
+		State string `json:"state"`
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			client.hub.log.WithField("error", err).Warn("failed to parse presence heartbeat payload")
+			return nil, err
+		}
 	}
+	if payload.State != "" && payload.State != "active" && payload.State != "idle" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "state must be active or idle")
+	}
+
+	client.heartbeat(payload.State == "idle")
+	client.hub.updatePresence(client.roomID, client.participantID, client.displayName)
+	return nil, nil
 }
 
-func (h *EventHandler) handleMessageSend(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleMessageSend(client *Client, data json.RawMessage) (interface{}, error) {
 	// parse payload
 	var payload struct {
 		Content string `json:"content"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse message payload")
-		return err
+		return nil, err
 	}
 
 	// create request untuk usecase
@@ -104,7 +322,7 @@ func (h *EventHandler) handleMessageSend(client *Client, data json.RawMessage) e
 	response, err := h.messageUseCase.Send(context.Background(), request)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to send message")
-		return err
+		return nil, err
 	}
 
 	// broadcast message ke semua client di room
@@ -114,18 +332,83 @@ func (h *EventHandler) handleMessageSend(client *Client, data json.RawMessage) e
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
 	h.broadcastLeaderboardUpdate(client)
-	return nil
+	return response, nil
+}
+
+// handleMessageEdit edit message milik client lalu broadcast message:updated
+func (h *EventHandler) handleMessageEdit(client *Client, data json.RawMessage) (interface{}, error) {
+	var payload struct {
+		MessageID uint   `json:"message_id"`
+		Content   string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse message edit payload")
+		return nil, err
+	}
+
+	request := &model.UpdateMessageRequest{
+		MessageID:     payload.MessageID,
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+		Content:       payload.Content,
+	}
+
+	response, err := h.messageUseCase.Update(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to edit message")
+		return nil, err
+	}
+
+	broadcastData := WSMessage{
+		Event: EventMessageUpdated,
+		Data:  mustMarshal(response),
+	}
+	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
+	return response, nil
+}
+
+// handleMessageDelete hapus message (author atau owner room) lalu broadcast message:deleted
+func (h *EventHandler) handleMessageDelete(client *Client, data json.RawMessage) (interface{}, error) {
+	var payload struct {
+		MessageID uint `json:"message_id"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse message delete payload")
+		return nil, err
+	}
+
+	request := &model.DeleteMessageRequest{
+		MessageID:     payload.MessageID,
+		RoomID:        client.roomID,
+		ParticipantID: client.participantID,
+	}
+
+	response, err := h.messageUseCase.Delete(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to delete message")
+		return nil, err
+	}
+
+	broadcastData := WSMessage{
+		Event: EventMessageDeleted,
+		Data:  mustMarshal(response),
+	}
+	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
+	if response.XPRevoked > 0 {
+		h.broadcastLeaderboardUpdate(client)
+	}
+	return response, nil
 }
 
 // handleChatTyping handle typing indicatior
-func (h *EventHandler) handleChatTyping(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleChatTyping(client *Client, data json.RawMessage) (interface{}, error) {
 	// parse payload
 	var payload struct {
 		IsTyping bool `json:"is_typing"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse message payload")
-		return err
+		return nil, err
 	}
 
 	// broadcast typing status ke semua client di room (kecuali sender)
@@ -139,11 +422,11 @@ func (h *EventHandler) handleChatTyping(client *Client, data json.RawMessage) er
 
 	// broadcast ke room (implmenetasi nanti jika perlu exclude sender)
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(typingData))
-	return nil
+	return nil, nil
 }
 
 // handleLeaderboardRequest handle request leaderboard
-func (h *EventHandler) handleLeaderboardRequest(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleLeaderboardRequest(client *Client, data json.RawMessage) (interface{}, error) {
 	request := &model.GetLeaderboardRequest{
 		RoomID:        client.roomID,
 		ParticipantID: client.participantID,
@@ -152,7 +435,7 @@ func (h *EventHandler) handleLeaderboardRequest(client *Client, data json.RawMes
 	leaderboard, err := h.participantUseCase.Leaderboard(context.Background(), request)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to get leaderboard")
-		return err
+		return nil, err
 	}
 
 	responseData := WSMessage{
@@ -161,18 +444,18 @@ func (h *EventHandler) handleLeaderboardRequest(client *Client, data json.RawMes
 	}
 
 	client.send <- mustMarshal(responseData)
-	return nil
+	return leaderboard, nil
 }
 
 // handleQuestionSubmit handle submit question via websocket
-func (h *EventHandler) handleQuestionSubmit(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleQuestionSubmit(client *Client, data json.RawMessage) (interface{}, error) {
 	// parse payload
 	var payload struct {
 		Content string `json:"content"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse question payload")
-		return err
+		return nil, err
 	}
 
 	// create request untuk usecase
@@ -186,7 +469,22 @@ func (h *EventHandler) handleQuestionSubmit(client *Client, data json.RawMessage
 	response, err := h.questionUseCase.Submit(context.Background(), request)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to submit question")
-		return err
+		return nil, err
+	}
+
+	// question di antrian moderasi hanya dikirim ke owner dan moderator, belum ada XP
+	if response.Question.ModerationStatus == "queued" {
+		userIDs, err := h.questionUseCase.ModeratorUserIDs(context.Background(), client.roomID)
+		if err != nil {
+			client.hub.log.WithField("error", err).Warn("failed to get room moderators")
+			return nil, err
+		}
+		queuedData := WSMessage{
+			Event: EventQuestionQueued,
+			Data:  mustMarshal(response),
+		}
+		client.hub.SendToUsers(client.roomID, userIDs, mustMarshal(queuedData))
+		return response, nil
 	}
 
 	// broadcast question:created ke semua client di room
@@ -196,18 +494,18 @@ func (h *EventHandler) handleQuestionSubmit(client *Client, data json.RawMessage
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
 	h.broadcastLeaderboardUpdate(client)
-	return nil
+	return response, nil
 }
 
 // handleQuestionUpvote handle upvote question via websocket
-func (h *EventHandler) handleQuestionUpvote(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleQuestionUpvote(client *Client, data json.RawMessage) (interface{}, error) {
 	// parse payload
 	var payload struct {
 		QuestionID uint `json:"question_id"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse upvote payload")
-		return err
+		return nil, err
 	}
 
 	// create request untuk usecase
@@ -221,7 +519,7 @@ func (h *EventHandler) handleQuestionUpvote(client *Client, data json.RawMessage
 	response, err := h.questionUseCase.Upvote(context.Background(), request)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to upvote question")
-		return err
+		return nil, err
 	}
 
 	// broadcast question:upvoted ke semua client di room dengan action dan participant_id
@@ -236,18 +534,18 @@ func (h *EventHandler) handleQuestionUpvote(client *Client, data json.RawMessage
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
 	h.broadcastLeaderboardUpdate(client)
-	return nil
+	return response.Question, nil
 }
 
 // handleQuestionRemoveUpvote handle remove upvote via websocket
-func (h *EventHandler) handleQuestionRemoveUpvote(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleQuestionRemoveUpvote(client *Client, data json.RawMessage) (interface{}, error) {
 	// parse payload
 	var payload struct {
 		QuestionID uint `json:"question_id"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse remove upvote payload")
-		return err
+		return nil, err
 	}
 
 	// create request untuk usecase
@@ -261,7 +559,7 @@ func (h *EventHandler) handleQuestionRemoveUpvote(client *Client, data json.RawM
 	response, err := h.questionUseCase.RemoveUpvote(context.Background(), request)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to remove upvote")
-		return err
+		return nil, err
 	}
 
 	// broadcast question:upvoted (dengan updated count) ke semua client di room dengan action dan participant_id
@@ -276,7 +574,158 @@ func (h *EventHandler) handleQuestionRemoveUpvote(client *Client, data json.RawM
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
 	h.broadcastLeaderboardUpdate(client)
-	return nil
+	return response.Question, nil
+}
+
+// handlePollVote handle vote poll via websocket, hasil personal (XP, quiz) hanya ke voter
+func (h *EventHandler) handlePollVote(client *Client, data json.RawMessage) (interface{}, error) {
+	// parse payload, field selain poll_id sama dengan body POST /polls/:poll_id/vote
+	var payload struct {
+		PollID uint `json:"poll_id"`
+		model.SubmitPollVoteRequest
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse poll vote payload")
+		return nil, err
+	}
+
+	// create request untuk usecase
+	request := &payload.SubmitPollVoteRequest
+	request.PollID = payload.PollID
+	request.ParticipantID = client.participantID
+	request.RoomID = client.roomID
+
+	// call usecase
+	response, err := h.pollUseCase.Vote(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to vote poll")
+		return nil, err
+	}
+
+	// hasil vote untuk voter, client yang mengirim request_id juga menerima hasil yang sama di ack
+	client.reply(mustMarshal(WSMessage{
+		Event: EventPollVoted,
+		Data:  mustMarshal(response),
+	}))
+
+	// broadcast hasil terbaru tanpa data personal voter
+	h.broadcastPollResults(client, response.UpdatedResults)
+	h.broadcastLeaderboardUpdate(client)
+	return response, nil
+}
+
+// handlePollCreate handle buat poll via websocket (owner atau co-host), draft tidak di-broadcast
+func (h *EventHandler) handlePollCreate(client *Client, data json.RawMessage) (interface{}, error) {
+	if !client.canHost() || client.userID == 0 {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can create polls")
+	}
+
+	// payload sama dengan body POST /rooms/:room_id/polls
+	request := new(model.CreatePollRequest)
+	if err := json.Unmarshal(data, request); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse poll create payload")
+		return nil, err
+	}
+	request.RoomID = client.roomID
+	request.PresenterID = client.userID
+
+	// call usecase
+	response, err := h.pollUseCase.Create(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to create poll")
+		return nil, err
+	}
+
+	if response.Poll.Status == "active" {
+		h.broadcastPollCreated(client, response)
+	}
+	return response, nil
+}
+
+// handlePollActivate handle aktifkan draft poll via websocket (owner atau co-host)
+func (h *EventHandler) handlePollActivate(client *Client, data json.RawMessage) (interface{}, error) {
+	if !client.canHost() || client.userID == 0 {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can activate polls")
+	}
+
+	var payload struct {
+		PollID uint `json:"poll_id"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse poll activate payload")
+		return nil, err
+	}
+
+	request := &model.ActivatePollRequest{
+		PollID:      payload.PollID,
+		PresenterID: client.userID,
+		RoomID:      client.roomID,
+	}
+
+	// call usecase
+	response, err := h.pollUseCase.Activate(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to activate poll")
+		return nil, err
+	}
+
+	h.broadcastPollCreated(client, response)
+	return response, nil
+}
+
+// handlePollClose handle tutup poll via websocket (owner atau co-host)
+func (h *EventHandler) handlePollClose(client *Client, data json.RawMessage) (interface{}, error) {
+	if !client.canHost() || client.userID == 0 {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can close polls")
+	}
+
+	var payload struct {
+		PollID uint `json:"poll_id"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to parse poll close payload")
+		return nil, err
+	}
+
+	request := &model.ClosePollRequest{
+		PollID:      payload.PollID,
+		PresenterID: client.userID,
+		RoomID:      client.roomID,
+	}
+
+	// call usecase
+	response, err := h.pollUseCase.Close(context.Background(), request)
+	if err != nil {
+		client.hub.log.WithField("error", err).Warn("failed to close poll")
+		return nil, err
+	}
+
+	closedData := WSMessage{
+		Event: EventPollClosed,
+		Data:  mustMarshal(response),
+	}
+	client.hub.BroadcastToRoom(client.roomID, mustMarshal(closedData))
+	return response, nil
+}
+
+// broadcastPollCreated broadcast poll:created ke semua client di room
+func (h *EventHandler) broadcastPollCreated(client *Client, response *model.CreatePollResponse) {
+	createdData := WSMessage{
+		Event: EventPollCreated,
+		Data:  mustMarshal(response),
+	}
+	client.hub.BroadcastToRoom(client.roomID, mustMarshal(createdData))
+}
+
+// broadcastPollResults broadcast poll:results_updated ke semua client di room
+func (h *EventHandler) broadcastPollResults(client *Client, results model.UpdatedPollResultsResponse) {
+	resultsData := WSMessage{
+		Event: EventPollResultsUpdate,
+		Data: mustMarshal(map[string]interface{}{
+			"updated_results": results,
+		}),
+	}
+	client.hub.BroadcastToRoom(client.roomID, mustMarshal(resultsData))
 }
 
 func (h *EventHandler) broadcastLeaderboardUpdate(client *Client) {
@@ -309,7 +758,7 @@ func (h *EventHandler) HandleDisconnect(client *Client) {
 	h.sfuManager.RemovePeer(client.roomID, peerID)
 }
 
-func (h *EventHandler) handleWebrtcOffer(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleWebrtcOffer(client *Client, data json.RawMessage) (interface{}, error) {
 	var offerPayload struct {
 		Type        string `json:"type"`
 		SDP         string `json:"sdp"`
@@ -318,7 +767,7 @@ func (h *EventHandler) handleWebrtcOffer(client *Client, data json.RawMessage) e
 	}
 	if err := json.Unmarshal(data, &offerPayload); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse webrtc offer")
-		return err
+		return nil, err
 	}
 
 	offer := webrtc.SessionDescription{
@@ -372,37 +821,37 @@ func (h *EventHandler) handleWebrtcOffer(client *Client, data json.RawMessage) e
 		}).Info("Handling renegotiation offer")
 
 		// Handle renegotiation - just process the new offer without recreating peer
-		return existingPeer.HandleOffer(offer)
+		return nil, existingPeer.HandleOffer(offer)
 	}
 
 	// Create new peer if doesn't exist
 	peer, err := h.sfuManager.CreatePeer(client.roomID, peerID, signalFunc)
 	if err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to create peer")
-		return err
+		return nil, err
 	}
 
-	return peer.HandleOffer(offer)
+	return nil, peer.HandleOffer(offer)
 }
 
-func (h *EventHandler) handleWebrtcAnswer(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleWebrtcAnswer(client *Client, data json.RawMessage) (interface{}, error) {
 	var answer webrtc.SessionDescription
 	if err := json.Unmarshal(data, &answer); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse webrtc answer")
-		return err
+		return nil, err
 	}
 	peerID := fmt.Sprintf("%d", client.participantID)
-	return h.sfuManager.HandleAnswer(client.roomID, peerID, answer)
+	return nil, h.sfuManager.HandleAnswer(client.roomID, peerID, answer)
 }
 
-func (h *EventHandler) handleWebrtcCandidate(client *Client, data json.RawMessage) error {
+func (h *EventHandler) handleWebrtcCandidate(client *Client, data json.RawMessage) (interface{}, error) {
 	var candidate webrtc.ICECandidateInit
 	if err := json.Unmarshal(data, &candidate); err != nil {
 		client.hub.log.WithField("error", err).Warn("failed to parse webrtc candidate")
-		return err
+		return nil, err
 	}
 	peerID := fmt.Sprintf("%d", client.participantID)
-	return h.sfuManager.HandleCandidate(client.roomID, peerID, candidate)
+	return nil, h.sfuManager.HandleCandidate(client.roomID, peerID, candidate)
 }
 
 // mustMarshal helper untuk marshal JSON, panic jika error
@@ -416,17 +865,18 @@ func mustMarshal(v interface{}) []byte {
 
 // Conference Handlers
 
-func (h *EventHandler) handleConferenceStart(client *Client) error {
-	// Authorization: Only room owner (host) can start conference
-	if !client.isRoomOwner {
-		return fmt.Errorf("unauthorized: only room owner can start conference")
+func (h *EventHandler) handleConferenceStart(client *Client) (interface{}, error) {
+	// Authorization: Only room owner and co-hosts can start conference
+	if !client.canHost() {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can start conference")
 	}
 
 	peerID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
+	room.SetHost(peerID, true)
 
 	if err := room.StartConference(peerID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Broadcast conference started to all clients in room
@@ -435,26 +885,28 @@ func (h *EventHandler) handleConferenceStart(client *Client) error {
 		Event: EventConferenceStarted,
 		Data: mustMarshal(map[string]interface{}{
 			"host_id":      state.HostID,
+			"hosts":        state.Hosts,
 			"is_active":    state.IsActive,
 			"speakers":     state.Speakers,
 			"raised_hands": state.RaisedHands,
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleConferenceStop(client *Client) error {
-	// Authorization: Only room owner (host) can stop conference
-	if !client.isRoomOwner {
-		return fmt.Errorf("unauthorized: only room owner can stop conference")
+func (h *EventHandler) handleConferenceStop(client *Client) (interface{}, error) {
+	// Authorization: Only room owner and co-hosts can stop conference
+	if !client.canHost() {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can stop conference")
 	}
 
 	peerID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
+	room.SetHost(peerID, true)
 
 	if err := room.StopConference(peerID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Broadcast conference ended to all clients in room
@@ -463,10 +915,10 @@ func (h *EventHandler) handleConferenceStop(client *Client) error {
 		Data:  mustMarshal(map[string]interface{}{}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleConferenceJoin(client *Client) error {
+func (h *EventHandler) handleConferenceJoin(client *Client) (interface{}, error) {
 	peerID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
 
@@ -474,13 +926,7 @@ func (h *EventHandler) handleConferenceJoin(client *Client) error {
 	state := room.GetConferenceState()
 	stateData := WSMessage{
 		Event: EventConferenceState,
-		Data: mustMarshal(map[string]interface{}{
-			"host_id":       state.HostID,
-			"is_active":     state.IsActive,
-			"speakers":      state.Speakers,
-			"raised_hands":  state.RaisedHands,
-			"is_room_owner": client.isRoomOwner, // inform client their role
-		}),
+		Data:  mustMarshal(conferenceStateData(state, client)),
 	}
 	client.send <- mustMarshal(stateData)
 
@@ -490,13 +936,14 @@ func (h *EventHandler) handleConferenceJoin(client *Client) error {
 		Data: mustMarshal(map[string]interface{}{
 			"participant_id": peerID,
 			"is_room_owner":  client.isRoomOwner,
+			"room_role":      client.roomRole,
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleConferenceLeave(client *Client) error {
+func (h *EventHandler) handleConferenceLeave(client *Client) (interface{}, error) {
 	peerID := fmt.Sprintf("%d", client.participantID)
 
 	// Broadcast that someone left
@@ -507,10 +954,10 @@ func (h *EventHandler) handleConferenceLeave(client *Client) error {
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleRaiseHand(client *Client) error {
+func (h *EventHandler) handleRaiseHand(client *Client) (interface{}, error) {
 	peerID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
 
@@ -525,10 +972,10 @@ func (h *EventHandler) handleRaiseHand(client *Client) error {
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleLowerHand(client *Client) error {
+func (h *EventHandler) handleLowerHand(client *Client) (interface{}, error) {
 	peerID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
 
@@ -542,27 +989,28 @@ func (h *EventHandler) handleLowerHand(client *Client) error {
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handlePromoteSpeaker(client *Client, data json.RawMessage) error {
-	// Authorization: Only room owner (host) can promote speakers
-	if !client.isRoomOwner {
-		return fmt.Errorf("unauthorized: only room owner can promote speakers")
+func (h *EventHandler) handlePromoteSpeaker(client *Client, data json.RawMessage) (interface{}, error) {
+	// Authorization: Only room owner and co-hosts can promote speakers
+	if !client.canHost() {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can promote speakers")
 	}
 
 	var payload struct {
 		ParticipantID string `json:"participant_id"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return err
+		return nil, err
 	}
 
 	hostID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
+	room.SetHost(hostID, true)
 
 	if !room.PromoteSpeaker(hostID, payload.ParticipantID) {
-		return fmt.Errorf("not authorized to promote")
+		return nil, fiber.NewError(fiber.StatusForbidden, "Not authorized to promote")
 	}
 
 	// Broadcast speaker promoted
@@ -573,27 +1021,28 @@ func (h *EventHandler) handlePromoteSpeaker(client *Client, data json.RawMessage
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
 
-func (h *EventHandler) handleDemoteSpeaker(client *Client, data json.RawMessage) error {
-	// Authorization: Only room owner (host) can demote speakers
-	if !client.isRoomOwner {
-		return fmt.Errorf("unauthorized: only room owner can demote speakers")
+func (h *EventHandler) handleDemoteSpeaker(client *Client, data json.RawMessage) (interface{}, error) {
+	// Authorization: Only room owner and co-hosts can demote speakers
+	if !client.canHost() {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner or a co-host can demote speakers")
 	}
 
 	var payload struct {
 		ParticipantID string `json:"participant_id"`
 	}
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return err
+		return nil, err
 	}
 
 	hostID := fmt.Sprintf("%d", client.participantID)
 	room := h.sfuManager.GetRoom(client.roomID)
+	room.SetHost(hostID, true)
 
 	if !room.DemoteSpeaker(hostID, payload.ParticipantID) {
-		return fmt.Errorf("not authorized to demote")
+		return nil, fiber.NewError(fiber.StatusForbidden, "Not authorized to demote")
 	}
 
 	// Broadcast speaker demoted
@@ -604,5 +1053,5 @@ func (h *EventHandler) handleDemoteSpeaker(client *Client, data json.RawMessage)
 		}),
 	}
 	client.hub.BroadcastToRoom(client.roomID, mustMarshal(broadcastData))
-	return nil
+	return nil, nil
 }
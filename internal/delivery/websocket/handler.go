@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"reisify/internal/util"
+	"time"
 
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
@@ -47,6 +48,12 @@ func (wsh *WebSocketHandler) HandleWebSocket(ctx *fiber.Ctx) error {
 		return fiber.ErrUnauthorized
 	}
 
+	// token room dari API key hanya untuk HTTP API, event websocket tidak dicek per scope
+	if claims.APIKeyID != nil {
+		wsh.log.Warn("websocket connection with API key token rejected")
+		return fiber.ErrForbidden
+	}
+
 	// validate required fields
 	if claims.RoomID == nil || claims.ParticipantID == nil {
 		wsh.log.Warn("missing required field in token")
@@ -70,12 +77,16 @@ func (wsh *WebSocketHandler) HandleWebSocket(ctx *fiber.Ctx) error {
 			hub:            wsh.hub,
 			conn:           c,
 			send:           make(chan []byte, 256),
+			kick:           make(chan struct{}),
 			userID:         getUintValue(claims.UserID),
 			roomID:         getUintValue(claims.RoomID),
 			participantID:  getUintValue(claims.ParticipantID),
 			displayName:    claims.DisplayName,
 			isAnonymous:    claims.IsAnonymous,
 			isRoomOwner:    claims.IsRoomOwner,
+			roomRole:       claims.RoomRole,
+			sessionID:      claims.SessionID,
+			lastHeartbeat:  time.Now(),
 			messageHandler: wsh.eventHandler.HandleMessage,
 		}
 
@@ -85,6 +96,9 @@ func (wsh *WebSocketHandler) HandleWebSocket(ctx *fiber.Ctx) error {
 		// run write pump sebagai goroutine
 		go client.WritePump()
 
+		// snapshot room hanya ke client ini, broadcast setelah seq snapshot tetap diterima live
+		wsh.eventHandler.SendRoomState(client)
+
 		// run read pump di goroutine utama agar koneksi tetap terbuka
 		client.ReadPump()
 
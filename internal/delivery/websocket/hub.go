@@ -1,38 +1,98 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"reisify/internal/model"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// timeout untuk operasi backplane (publish / presence) supaya hub tidak blocking lama
+const backplaneTimeout = 2 * time.Second
+
 type Hub struct {
-	clients    map[*Client]bool          // registered clients
-	broadcast  chan []byte               // kirim pesan ke semua client
-	register   chan *Client              // client yang mau register
-	unregister chan *Client              // client yang mau unregister
-	rooms      map[uint]map[*Client]bool // rooms dan clients di dalamnya
-	log        *logrus.Logger
+	clients     map[*Client]bool          // registered clients
+	broadcast   chan []byte               // kirim pesan ke semua client
+	register    chan *Client              // client yang mau register
+	unregister  chan *Client              // client yang mau unregister
+	rooms       map[uint]map[*Client]bool // rooms dan clients di dalamnya
+	connections map[uint]map[uint]int     // roomID -> participantID -> jumlah koneksi di node ini
+	statuses    map[uint]map[uint]string  // roomID -> participantID -> status presence di node ini
+	mu          sync.RWMutex              // guard clients, rooms, connections dan statuses
+	backplane   Backplane                 // fan-out antar node
+	recorder    PresenceRecorder          // opsional, riwayat presence untuk analytics
+	listener    BroadcastListener         // opsional, menerima setiap event room (webhook)
+	replay      ReplayBuffer              // seq dan buffer broadcast room untuk resume
+	log         *logrus.Logger
+
+	presenceLocks   map[presenceTarget]*presenceLock // serialisasi update presence per participant
+	presenceLocksMu sync.Mutex                       // guard presenceLocks
+	sweeping        atomic.Bool                      // sweep presence sedang berjalan
+}
+
+// PresenceRecorder dipanggil saat participant mulai / berhenti online di node ini (koneksi pertama / terakhir)
+type PresenceRecorder func(roomID, participantID uint, event string, onlineCount int, at time.Time)
+
+// SetPresenceRecorder pasang recorder presence history, panggil sebelum Run
+func (h *Hub) SetPresenceRecorder(recorder PresenceRecorder) {
+	h.recorder = recorder
+}
+
+// BroadcastListener dipanggil untuk setiap event yang di-broadcast ke room dari node ini.
+// Event dari node lain (lewat backplane) tidak diteruskan, jadi setiap event hanya diproses sekali.
+type BroadcastListener func(roomID uint, event string, data json.RawMessage)
+
+// SetBroadcastListener pasang listener broadcast room, panggil sebelum Run
+func (h *Hub) SetBroadcastListener(listener BroadcastListener) {
+	h.listener = listener
+}
+
+// SetReplayBuffer ganti replay buffer (default in-memory), panggil sebelum Run.
+// Untuk multi node pakai buffer bersama (Redis) supaya seq room sama di semua node
+func (h *Hub) SetReplayBuffer(replay ReplayBuffer) {
+	h.replay = replay
 }
 
-// NewHub membuat instance Hub baru
-func NewHub(log *logrus.Logger) *Hub {
+// NewHub membuat instance Hub baru, backplane nil berarti single node (in-memory)
+func NewHub(log *logrus.Logger, backplane Backplane) *Hub {
+	if backplane == nil {
+		backplane = NewMemoryBackplane()
+	}
+
 	return &Hub{
-		clients:    make(map[*Client]bool),
-		broadcast:  make(chan []byte, 256), // buffered channel -> ukuran channel yang reasonable agar tidak memakan memori berlebihan
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		rooms:      make(map[uint]map[*Client]bool),
-		log:        log,
+		clients:     make(map[*Client]bool),
+		broadcast:   make(chan []byte, 256), // buffered channel -> ukuran channel yang reasonable agar tidak memakan memori berlebihan
+		register:    make(chan *Client),
+		unregister:  make(chan *Client),
+		rooms:       make(map[uint]map[*Client]bool),
+		connections: make(map[uint]map[uint]int),
+		statuses:    make(map[uint]map[uint]string),
+		backplane:   backplane,
+		replay:      NewMemoryReplayBuffer(defaultReplaySize, defaultReplayTTL),
+		log:         log,
+
+		presenceLocks: make(map[presenceTarget]*presenceLock),
 	}
 }
 
 // Run goroutine untuk mengelola semua channel operation
 func (h *Hub) Run() {
+	// terima broadcast dari node lain
+	if err := h.backplane.Subscribe(context.Background(), h.handleEnvelope); err != nil {
+		h.log.Errorf("failed to subscribe websocket backplane: %v", err)
+	}
+
+	presenceTicker := time.NewTicker(presenceSweepInterval)
+	defer presenceTicker.Stop()
+
 	for {
 		select {
 		case client := <-h.register:
+			h.mu.Lock()
 			h.clients[client] = true
 
 			if h.rooms[client.roomID] == nil {
@@ -40,6 +100,8 @@ func (h *Hub) Run() {
 			}
 
 			h.rooms[client.roomID][client] = true
+			connections := h.addConnection(client.roomID, client.participantID, 1)
+			h.mu.Unlock()
 
 			h.log.WithFields(logrus.Fields{
 				"user_id":        client.userID,
@@ -47,14 +109,18 @@ func (h *Hub) Run() {
 				"participant_id": client.participantID,
 			}).Info("Client connected")
 
-			// broadcast participant joined ke room
-			h.broadcastParticipantJoined(client)
+			// presence di backplane dan broadcast participant joined di background
+			recordEvent := ""
+			if connections == 1 {
+				recordEvent = model.PresenceEventJoin
+			}
+			go h.syncPresence(client, recordEvent)
 
 		case client := <-h.unregister:
-			if _, ok := h.clients[client]; ok {
-				// broadcast participant left ke room sebelum unregister
-				h.broadcastParticipantLeft(client)
-
+			h.mu.Lock()
+			_, ok := h.clients[client]
+			connections := 0
+			if ok {
 				delete(h.clients, client)
 				close(client.send)
 
@@ -65,6 +131,17 @@ func (h *Hub) Run() {
 						delete(h.rooms, client.roomID)
 					}
 				}
+				connections = h.addConnection(client.roomID, client.participantID, -1)
+			}
+			h.mu.Unlock()
+
+			if ok {
+				// presence di backplane dan broadcast participant left di background
+				recordEvent := ""
+				if connections == 0 {
+					recordEvent = model.PresenceEventLeave
+				}
+				go h.syncPresence(client, recordEvent)
 
 				h.log.WithFields(logrus.Fields{
 					"user_id": client.userID,
@@ -72,6 +149,7 @@ func (h *Hub) Run() {
 				}).Debug("Client disconnected")
 			}
 		case message := <-h.broadcast:
+			h.mu.Lock()
 			for client := range h.clients {
 				select {
 				case client.send <- message:
@@ -80,21 +158,167 @@ func (h *Hub) Run() {
 					delete(h.clients, client)
 				}
 			}
+			h.mu.Unlock()
+		case <-presenceTicker.C:
+			go h.sweepPresence()
 		}
 	}
 }
 
-// BroadcastToRoom mengirim pesan ke semua client di room tertentu
+// unsequencedEvents event room yang hanya berarti saat dikirim: tidak diberi seq dan tidak disimpan untuk replay
+var unsequencedEvents = map[string]bool{
+	EventChatTyping:     true,
+	EventPresenceUpdate: true,
+	EventRoomUserJoin:   true,
+	EventRoomUserLeft:   true,
+}
+
+// listenerSkippedEvents event room yang tidak diteruskan ke broadcast listener (webhook).
+// Event yang bisa dipilih webhook (mis. room:user_joined) tidak boleh masuk sini
+var listenerSkippedEvents = map[string]bool{
+	EventChatTyping:     true,
+	EventPresenceUpdate: true,
+}
+
+// BroadcastToRoom mengirim pesan ke semua client di room tertentu, di semua node.
+// Pesan diberi seq room dan disimpan di replay buffer untuk client yang resume, kecuali unsequencedEvents
 func (h *Hub) BroadcastToRoom(roomID uint, msg []byte) {
-	if clients, ok := h.rooms[roomID]; ok {
+	var message WSMessage
+	if err := json.Unmarshal(msg, &message); err != nil || message.Event == "" {
+		h.publish(Envelope{RoomID: roomID, Payload: msg})
+		return
+	}
+
+	if unsequencedEvents[message.Event] {
+		h.publish(Envelope{RoomID: roomID, Payload: msg})
+	} else {
+		h.publish(Envelope{RoomID: roomID, Payload: h.sequence(roomID, message, msg)})
+	}
+	if !listenerSkippedEvents[message.Event] {
+		h.notifyListener(roomID, message)
+	}
+}
+
+// Seq seq broadcast terakhir room, dipakai sebagai titik awal resume untuk snapshot room
+func (h *Hub) Seq(roomID uint) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	return h.replay.Seq(ctx, roomID)
+}
+
+// Replay broadcast room dengan seq > lastSeq untuk client yang resume, ok false jika client harus full resync
+func (h *Hub) Replay(roomID uint, lastSeq uint64) (events [][]byte, current uint64, ok bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	return h.replay.Since(ctx, roomID, lastSeq)
+}
+
+// SendToUsers mengirim pesan hanya ke client milik user tertentu di room, di semua node
+func (h *Hub) SendToUsers(roomID uint, userIDs []uint, msg []byte) {
+	if len(userIDs) == 0 {
+		return
+	}
+	h.publish(Envelope{RoomID: roomID, Payload: msg, UserIDs: userIDs})
+}
+
+// SendToParticipants mengirim pesan hanya ke client milik participant tertentu di room, di semua node
+func (h *Hub) SendToParticipants(roomID uint, participantIDs []uint, msg []byte) {
+	if len(participantIDs) == 0 {
+		return
+	}
+	h.publish(Envelope{RoomID: roomID, Payload: msg, ParticipantIDs: participantIDs})
+}
+
+// DisconnectParticipants memutus koneksi websocket participant tertentu di room, di semua node.
+// Pesan yang sudah diantrikan ke client (misal event kick) tetap dikirim sebelum koneksi ditutup.
+func (h *Hub) DisconnectParticipants(roomID uint, participantIDs []uint) {
+	if len(participantIDs) == 0 {
+		return
+	}
+	h.publish(Envelope{RoomID: roomID, ParticipantIDs: participantIDs, Disconnect: true})
+}
+
+// DisconnectSessions memutus semua koneksi websocket yang dibuka dengan session login ini,
+// di semua room dan semua node (logout / session dicabut)
+func (h *Hub) DisconnectSessions(sessionIDs []string) {
+	if len(sessionIDs) == 0 {
+		return
+	}
+	h.publish(Envelope{SessionIDs: sessionIDs, Disconnect: true})
+}
+
+// publish kirim envelope ke client lokal lalu ke node lain lewat backplane
+func (h *Hub) publish(env Envelope) {
+	env.NodeID = h.backplane.NodeID()
+	h.deliver(env)
+
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	if err := h.backplane.Publish(ctx, env); err != nil {
 		h.log.WithFields(logrus.Fields{
-			"room_id":      roomID,
+			"room_id": env.RoomID,
+			"error":   err,
+		}).Warn("Failed to publish message to backplane")
+	}
+}
+
+// OnlineParticipants daftar participant yang sedang online di room (semua node)
+func (h *Hub) OnlineParticipants(roomID uint) []uint {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	participants, err := h.backplane.OnlineParticipants(ctx, roomID)
+	if err != nil {
+		h.log.Warnf("failed to load online participants from backplane: %v", err)
+
+		// fallback ke presence lokal node ini
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		local := make(map[uint]bool)
+		for participantID := range h.connections[roomID] {
+			local[participantID] = true
+		}
+		return sortedIDs(local)
+	}
+	return participants
+}
+
+// handleEnvelope menerima pesan dari backplane, pesan dari node sendiri sudah dikirim lokal
+func (h *Hub) handleEnvelope(env Envelope) {
+	if env.NodeID == h.backplane.NodeID() {
+		return
+	}
+	h.deliver(env)
+}
+
+// deliver mengirim pesan ke client target di room yang terkoneksi ke node ini
+func (h *Hub) deliver(env Envelope) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients, ok := h.rooms[env.RoomID]
+	if env.RoomID == 0 {
+		clients, ok = h.clients, len(env.SessionIDs) > 0
+	}
+	if ok {
+		h.log.WithFields(logrus.Fields{
+			"room_id":      env.RoomID,
 			"client_count": len(clients),
 		}).Debug("Broadcasting to room")
 
 		for client := range clients {
+			if !env.matches(client) {
+				continue
+			}
+			if env.Disconnect {
+				client.disconnect()
+				continue
+			}
 			select {
-			case client.send <- msg:
+			case client.send <- env.Payload:
 			default:
 				h.log.WithFields(logrus.Fields{
 					"user_id": client.userID,
@@ -104,14 +328,80 @@ func (h *Hub) BroadcastToRoom(roomID uint, msg []byte) {
 	}
 }
 
+// addConnection update jumlah koneksi participant di node ini, caller harus memegang h.mu
+func (h *Hub) addConnection(roomID, participantID uint, delta int) int {
+	if h.connections[roomID] == nil {
+		h.connections[roomID] = make(map[uint]int)
+	}
+
+	count := h.connections[roomID][participantID] + delta
+	if count <= 0 {
+		delete(h.connections[roomID], participantID)
+		if len(h.connections[roomID]) == 0 {
+			delete(h.connections, roomID)
+		}
+		return 0
+	}
+
+	h.connections[roomID][participantID] = count
+	return count
+}
+
+// setPresence sinkronisasi jumlah koneksi participant ke backplane
+func (h *Hub) setPresence(roomID, participantID uint, connections int) {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	if err := h.backplane.SetPresence(ctx, roomID, participantID, connections); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"room_id":        roomID,
+			"participant_id": participantID,
+			"error":          err,
+		}).Warn("Failed to update presence in backplane")
+	}
+}
+
+// recordPresence simpan presence event di background supaya hub tidak menunggu database
+func (h *Hub) recordPresence(client *Client, event string, onlineCount int) {
+	if h.recorder == nil {
+		return
+	}
+	go h.recorder(client.roomID, client.participantID, event, onlineCount, time.Now())
+}
+
+// sequence beri seq room ke broadcast, jika replay buffer gagal pesan tetap dikirim tanpa seq
+func (h *Hub) sequence(roomID uint, message WSMessage, msg []byte) []byte {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	payload, err := h.replay.Append(ctx, roomID, message)
+	if err != nil {
+		h.log.WithFields(logrus.Fields{
+			"room_id": roomID,
+			"error":   err,
+		}).Warn("Failed to append broadcast to replay buffer")
+		return msg
+	}
+	return payload
+}
+
+// notifyListener teruskan event broadcast ke listener di background
+func (h *Hub) notifyListener(roomID uint, message WSMessage) {
+	if h.listener == nil {
+		return
+	}
+	go h.listener(roomID, message.Event, message.Data)
+}
+
 // broadcastParticipantJoined broadcast ketika participant baru join room
-func (h *Hub) broadcastParticipantJoined(client *Client) {
+func (h *Hub) broadcastParticipantJoined(client *Client, onlineCount int) {
 	data := WSMessage{
 		Event: EventRoomUserJoin,
 		Data: h.mustMarshal(map[string]interface{}{
 			"participant_id": client.participantID,
 			"display_name":   client.displayName,
 			"is_anonymous":   client.isAnonymous,
+			"online_count":   onlineCount,
 			"joined_at":      time.Now().Format(time.RFC3339),
 		}),
 	}
@@ -119,12 +409,13 @@ func (h *Hub) broadcastParticipantJoined(client *Client) {
 }
 
 // broadcastParticipantLeft broadcast ketika participant disconnect dari room
-func (h *Hub) broadcastParticipantLeft(client *Client) {
+func (h *Hub) broadcastParticipantLeft(client *Client, onlineCount int) {
 	data := WSMessage{
 		Event: EventRoomUserLeft,
 		Data: h.mustMarshal(map[string]interface{}{
 			"participant_id": client.participantID,
 			"display_name":   client.displayName,
+			"online_count":   onlineCount,
 			"left_at":        time.Now().Format(time.RFC3339),
 		}),
 	}
@@ -1,25 +1,107 @@
 package websocket
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"reisify/internal/model"
+)
 
 type WSMessage struct {
-	Event string          `json:"event"`
-	Data  json.RawMessage `json:"data"`
+	Event     string          `json:"event"`
+	Data      json.RawMessage `json:"data"`
+	RequestID string          `json:"request_id,omitempty"` // opsional dari client, dikembalikan di ack / error
+	Seq       uint64          `json:"seq,omitempty"`        // nomor urut broadcast room, diisi server
+}
+
+// ResumePayload data event room:resumed / room:resync_required
+type ResumePayload struct {
+	LastSeq  uint64 `json:"last_seq"` // seq terakhir room saat resume diproses
+	Replayed int    `json:"replayed"` // jumlah event yang dikirim ulang
+}
+
+// AckPayload data event ack: event client yang berhasil diproses dan hasilnya
+type AckPayload struct {
+	Event  string      `json:"event"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// ErrorPayload data event error: event client yang gagal dan kode error yang bisa dibaca mesin
+type ErrorPayload struct {
+	Event   string `json:"event,omitempty"`
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// PresencePayload data event presence:update
+type PresencePayload struct {
+	ParticipantID uint   `json:"participant_id"`
+	DisplayName   string `json:"display_name"`
+	Status        string `json:"status"`       // online | idle | away | offline, gabungan semua koneksi participant
+	OnlineCount   int    `json:"online_count"` // participant yang terkoneksi (termasuk idle dan away)
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// RoomStatePayload data event room:state, snapshot room untuk client yang baru terkoneksi
+type RoomStatePayload struct {
+	Seq          uint64                       `json:"seq"` // seq broadcast terakhir saat snapshot dibuat, titik awal room:resume
+	Participants []*model.ParticipantListItem `json:"participants"`
+	OnlineCount  int                          `json:"online_count"`
+	Polls        []model.PollResponse         `json:"polls"`
+	Questions    []model.QuestionResponse     `json:"questions"`
+	Messages     []model.MessageResponse      `json:"messages"` // terbaru lebih dulu
+	Conference   map[string]interface{}       `json:"conference"`
+	Me           RoomStateMe                  `json:"me"`
+}
+
+// RoomStateMe data participant yang terkoneksi di room:state
+type RoomStateMe struct {
+	ParticipantID uint   `json:"participant_id"`
+	DisplayName   string `json:"display_name"`
+	IsRoomOwner   bool   `json:"is_room_owner"`
+	RoomRole      string `json:"room_role"`
+	XPScore       int    `json:"xp_score"`
+	Rank          int    `json:"rank"`
 }
 
 // Event types constants
 const (
+	// Protocol events
+	EventAck   = "ack"   // Server -> Client (hanya ke pengirim, jika request_id diisi)
+	EventError = "error" // Server -> Client (hanya ke pengirim)
+
+	// Resume events (replay broadcast yang terlewat saat reconnect)
+	EventRoomResume         = "room:resume"          // Client -> Server
+	EventRoomResumed        = "room:resumed"         // Server -> Client (setelah event yang terlewat dikirim ulang)
+	EventRoomResyncRequired = "room:resync_required" // Server -> Client (gap tidak tersedia, client harus fetch ulang lewat HTTP)
+
 	// Room events
-	EventRoomJoin     = "room:join"
-	EventRoomUserJoin = "room:user_joined"
-	EventRoomUserLeft = "room:user_left"
-	EventRoomClosed   = "room:closed"
-	EventRoomAnnounce = "room:announce" // Server -> Client (broadcast from presenter)
+	EventRoomJoin        = "room:join"
+	EventRoomState       = "room:state" // Server -> Client (hanya ke client yang baru terkoneksi)
+	EventRoomUserJoin    = "room:user_joined"
+	EventRoomUserLeft    = "room:user_left"
+	EventRoomClosed      = "room:closed"
+	EventRoomAnnounce    = "room:announce"     // Server -> Client (broadcast from presenter)
+	EventRoomRoleUpdated = "room:role_updated" // Server -> Client (user yang role-nya berubah)
+
+	// Presence events
+	EventPresenceHeartbeat = "presence:heartbeat" // Client -> Server
+	EventPresenceUpdate    = "presence:update"    // Server -> Client (broadcast, status participant berubah)
+
+	// Participant moderation events
+	EventParticipantKicked  = "participant:kicked"  // Server -> Client (broadcast)
+	EventParticipantBanned  = "participant:banned"  // Server -> Client (broadcast)
+	EventParticipantMuted   = "participant:muted"   // Server -> Client (broadcast)
+	EventParticipantUnmuted = "participant:unmuted" // Server -> Client (broadcast)
 
 	// Message events
-	EventMessageSend = "message:send" // Client -> Server
-	EventMessageNew  = "message:new"  // Server -> Client (broadcast)
-	EventChatTyping  = "chat:typing"  // Bidirectional
+	EventMessageSend    = "message:send"    // Client -> Server
+	EventMessageNew     = "message:new"     // Server -> Client (broadcast)
+	EventMessageEdit    = "message:edit"    // Client -> Server
+	EventMessageDelete  = "message:delete"  // Client -> Server
+	EventMessageUpdated = "message:updated" // Server -> Client (broadcast)
+	EventMessageDeleted = "message:deleted" // Server -> Client (broadcast)
+	EventChatTyping     = "chat:typing"     // Bidirectional
 
 	// Question events
 	EventQuestionSubmit       = "question:submit"        // Client -> Server
@@ -28,13 +110,24 @@ const (
 	EventQuestionCreated      = "question:created"       // Server -> Client (broadcast)
 	EventQuestionUpvoted      = "question:upvoted"       // Server -> Client (broadcast)
 	EventQuestionValidated    = "question:validated"     // Server -> Client (broadcast)
+	EventQuestionQueued       = "question:queued"        // Server -> Client (owner dan moderator)
+	EventQuestionModerated    = "question:moderated"     // Server -> Client (owner dan moderator)
+	EventQuestionRejected     = "question:rejected"      // Server -> Client (author saja)
+	EventQuestionReplied      = "question:replied"       // Server -> Client (broadcast)
 
 	// Poll events
 	EventPollVote          = "poll:vote"            // Client -> Server
+	EventPollCreate        = "poll:create"          // Client -> Server (owner atau co-host)
+	EventPollActivate      = "poll:activate"        // Client -> Server (owner atau co-host)
+	EventPollClose         = "poll:close"           // Client -> Server (owner atau co-host)
+	EventPollVoted         = "poll:voted"           // Server -> Client (voter saja, hasil vote dan XP)
 	EventPollCreated       = "poll:created"         // Server -> Client (broadcast)
 	EventPollResultsUpdate = "poll:results_updated" // Server -> Client (broadcast)
 	EventPollClosed        = "poll:closed"          // Server -> Client (broadcast)
 
+	// Quiz events
+	EventQuizSummary = "quiz:summary" // Server -> Client (broadcast)
+
 	// Leaderboard events
 	EventLeaderboardUpdate  = "leaderboard:updated" // Server -> Client
 	EventXPAwarded          = "xp:awarded"
@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"context"
+	"reisify/internal/model"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// koneksi tanpa heartbeat selama ini dianggap away, client disarankan heartbeat tiap 30 detik
+	presenceAwayAfter = 90 * time.Second
+
+	// interval hub mengecek heartbeat yang berhenti
+	presenceSweepInterval = 15 * time.Second
+)
+
+// Presence status presence participant yang terkoneksi ke room (semua node), participant offline tidak ada di map
+func (h *Hub) Presence(roomID uint) map[uint]string {
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	statuses, err := h.backplane.Statuses(ctx, roomID)
+	if err != nil {
+		h.log.Warnf("failed to load presence statuses from backplane: %v", err)
+
+		// fallback ke status lokal node ini
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		statuses = make(map[uint]string, len(h.statuses[roomID]))
+		for participantID, status := range h.statuses[roomID] {
+			statuses[participantID] = status
+		}
+	}
+	return statuses
+}
+
+// AnnotatePresence isi status presence di daftar participant room, participant tanpa koneksi offline
+func (h *Hub) AnnotatePresence(roomID uint, participants []*model.ParticipantListItem) {
+	statuses := h.Presence(roomID)
+	for _, participant := range participants {
+		status, ok := statuses[participant.ID]
+		if !ok {
+			status = model.PresenceOffline
+		}
+		participant.Status = status
+	}
+}
+
+// presenceTarget participant di room, unit serialisasi update presence
+type presenceTarget struct {
+	roomID        uint
+	participantID uint
+}
+
+// presenceLock mutex per participant, refs menghitung goroutine yang memakai supaya bisa dibuang dari map
+type presenceLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockPresence serialisasi update presence satu participant di room, return fungsi untuk unlock.
+// Participant lain tidak menunggu, jadi backplane yang lambat hanya menahan participant yang sama
+func (h *Hub) lockPresence(roomID, participantID uint) func() {
+	key := presenceTarget{roomID, participantID}
+
+	h.presenceLocksMu.Lock()
+	lock := h.presenceLocks[key]
+	if lock == nil {
+		lock = &presenceLock{}
+		h.presenceLocks[key] = lock
+	}
+	lock.refs++
+	h.presenceLocksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		h.presenceLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(h.presenceLocks, key)
+		}
+		h.presenceLocksMu.Unlock()
+	}
+}
+
+// syncPresence sinkronisasi jumlah koneksi participant di node ini ke backplane setelah register / unregister,
+// lalu broadcast join / left jika participant berubah online / offline di semua node.
+// Dipanggil di goroutine terpisah supaya I/O backplane tidak menahan Run
+func (h *Hub) syncPresence(client *Client, recordEvent string) {
+	unlock := h.lockPresence(client.roomID, client.participantID)
+	defer unlock()
+
+	// jumlah koneksi dibaca ulang, register / unregister lain mungkin sudah terjadi sejak goroutine ini dibuat
+	h.mu.RLock()
+	connections := h.connections[client.roomID][client.participantID]
+	h.mu.RUnlock()
+
+	wasOnline := slices.Contains(h.OnlineParticipants(client.roomID), client.participantID)
+	h.setPresence(client.roomID, client.participantID, connections)
+	online := h.OnlineParticipants(client.roomID)
+	isOnline := slices.Contains(online, client.participantID)
+
+	if recordEvent != "" {
+		h.recordPresence(client, recordEvent, len(online))
+	}
+
+	// koneksi tambahan (tab lain / node lain) atau reconnect cepat tidak di-broadcast join / left lagi
+	switch {
+	case !wasOnline && isOnline:
+		h.broadcastParticipantJoined(client, len(online))
+	case wasOnline && !isOnline:
+		h.broadcastParticipantLeft(client, len(online))
+	}
+
+	h.refreshPresence(client.roomID, client.participantID, client.displayName)
+}
+
+// updatePresence hitung ulang status participant dari koneksinya di node ini (yang paling aktif).
+// Jika berubah, status disimpan ke backplane dan presence:update di-broadcast dengan status gabungan semua node
+func (h *Hub) updatePresence(roomID, participantID uint, displayName string) {
+	unlock := h.lockPresence(roomID, participantID)
+	defer unlock()
+
+	h.refreshPresence(roomID, participantID, displayName)
+}
+
+// refreshPresence isi updatePresence, caller harus memegang lockPresence participant supaya urutan status
+// di backplane sama dengan di h.statuses
+func (h *Hub) refreshPresence(roomID, participantID uint, displayName string) {
+	now := time.Now()
+	status := model.PresenceOffline
+
+	h.mu.Lock()
+	for client := range h.rooms[roomID] {
+		if client.participantID != participantID {
+			continue
+		}
+		if clientStatus := client.presenceStatus(now); presenceRank[clientStatus] > presenceRank[status] {
+			status = clientStatus
+		}
+	}
+	changed := h.setLocalStatus(roomID, participantID, status)
+	h.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backplaneTimeout)
+	defer cancel()
+
+	if err := h.backplane.SetStatus(ctx, roomID, participantID, status); err != nil {
+		h.log.WithFields(logrus.Fields{
+			"room_id":        roomID,
+			"participant_id": participantID,
+			"error":          err,
+		}).Warn("Failed to update presence status in backplane")
+	}
+
+	h.broadcastPresenceUpdate(roomID, participantID, displayName)
+}
+
+// sweepPresence cek ulang status semua participant di node ini, heartbeat yang berhenti menjadi away.
+// Sweep berikutnya di-skip jika sweep sebelumnya belum selesai
+func (h *Hub) sweepPresence() {
+	if !h.sweeping.CompareAndSwap(false, true) {
+		return
+	}
+	defer h.sweeping.Store(false)
+
+	h.mu.RLock()
+	targets := make(map[presenceTarget]string)
+	for roomID, clients := range h.rooms {
+		for client := range clients {
+			targets[presenceTarget{roomID, client.participantID}] = client.displayName
+		}
+	}
+	h.mu.RUnlock()
+
+	for key, displayName := range targets {
+		h.updatePresence(key.roomID, key.participantID, displayName)
+	}
+}
+
+// setLocalStatus simpan status lokal participant, return true jika berubah. Caller harus memegang h.mu
+func (h *Hub) setLocalStatus(roomID, participantID uint, status string) bool {
+	current, ok := h.statuses[roomID][participantID]
+	if !ok {
+		current = model.PresenceOffline
+	}
+	if current == status {
+		return false
+	}
+
+	if status == model.PresenceOffline {
+		delete(h.statuses[roomID], participantID)
+		if len(h.statuses[roomID]) == 0 {
+			delete(h.statuses, roomID)
+		}
+		return true
+	}
+
+	if h.statuses[roomID] == nil {
+		h.statuses[roomID] = make(map[uint]string)
+	}
+	h.statuses[roomID][participantID] = status
+	return true
+}
+
+// broadcastPresenceUpdate broadcast presence:update dengan status participant dari semua node
+func (h *Hub) broadcastPresenceUpdate(roomID, participantID uint, displayName string) {
+	status, ok := h.Presence(roomID)[participantID]
+	if !ok {
+		status = model.PresenceOffline
+	}
+
+	data := WSMessage{
+		Event: EventPresenceUpdate,
+		Data: h.mustMarshal(PresencePayload{
+			ParticipantID: participantID,
+			DisplayName:   displayName,
+			Status:        status,
+			OnlineCount:   len(h.OnlineParticipants(roomID)),
+			UpdatedAt:     time.Now().Format(time.RFC3339),
+		}),
+	}
+	h.BroadcastToRoom(roomID, h.mustMarshal(data))
+}
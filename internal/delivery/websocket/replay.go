@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	// jumlah default broadcast terakhir per room yang disimpan untuk replay
+	defaultReplaySize = 256
+
+	// room tanpa broadcast selama ini dibuang dari buffer
+	defaultReplayTTL = 10 * time.Minute
+)
+
+// ReplayBuffer memberi nomor urut (seq) naik per room untuk setiap broadcast dan menyimpan
+// broadcast terakhir supaya client yang reconnect bisa mengambil event yang terlewat
+type ReplayBuffer interface {
+	// Append set seq berikutnya di msg, simpan, lalu return payload yang sudah di-marshal
+	Append(ctx context.Context, roomID uint, msg WSMessage) ([]byte, error)
+
+	// Seq seq broadcast terakhir room, 0 jika belum ada
+	Seq(ctx context.Context, roomID uint) (uint64, error)
+
+	// Since payload broadcast dengan seq > lastSeq dan seq terakhir room.
+	// ok false jika sebagian event sudah keluar dari buffer atau counter room sudah direset
+	Since(ctx context.Context, roomID uint, lastSeq uint64) (events [][]byte, current uint64, ok bool, err error)
+}
+
+// ========================================
+// IN-MEMORY REPLAY BUFFER (single node)
+// ========================================
+
+type replayEvent struct {
+	seq     uint64
+	payload []byte
+}
+
+type replayRoom struct {
+	seq       uint64
+	events    []replayEvent // urut berdasarkan seq, maksimal size
+	updatedAt time.Time
+}
+
+type memoryReplayBuffer struct {
+	mu        sync.Mutex
+	rooms     map[uint]*replayRoom
+	size      int
+	ttl       time.Duration
+	lastPrune time.Time
+}
+
+// NewMemoryReplayBuffer replay buffer in-memory, hanya konsisten untuk satu node.
+// size <= 0 dan ttl <= 0 memakai nilai default
+func NewMemoryReplayBuffer(size int, ttl time.Duration) ReplayBuffer {
+	if size <= 0 {
+		size = defaultReplaySize
+	}
+	if ttl <= 0 {
+		ttl = defaultReplayTTL
+	}
+	return &memoryReplayBuffer{
+		rooms:     make(map[uint]*replayRoom),
+		size:      size,
+		ttl:       ttl,
+		lastPrune: time.Now(),
+	}
+}
+
+func (m *memoryReplayBuffer) Append(ctx context.Context, roomID uint, msg WSMessage) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.prune(now)
+
+	room := m.rooms[roomID]
+	if room == nil {
+		room = &replayRoom{}
+		m.rooms[roomID] = room
+	}
+
+	msg.Seq = room.seq + 1
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	room.seq = msg.Seq
+	room.updatedAt = now
+	room.events = append(room.events, replayEvent{seq: msg.Seq, payload: payload})
+	if len(room.events) > m.size {
+		room.events = room.events[len(room.events)-m.size:]
+	}
+	return payload, nil
+}
+
+func (m *memoryReplayBuffer) Seq(ctx context.Context, roomID uint) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if room := m.rooms[roomID]; room != nil {
+		return room.seq, nil
+	}
+	return 0, nil
+}
+
+func (m *memoryReplayBuffer) Since(ctx context.Context, roomID uint, lastSeq uint64) ([][]byte, uint64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	room := m.rooms[roomID]
+	if room == nil {
+		return nil, 0, lastSeq == 0, nil
+	}
+	if lastSeq > room.seq {
+		return nil, room.seq, false, nil
+	}
+	if lastSeq == room.seq {
+		return nil, room.seq, true, nil
+	}
+	if len(room.events) == 0 || room.events[0].seq > lastSeq+1 {
+		return nil, room.seq, false, nil
+	}
+
+	events := make([][]byte, 0, room.seq-lastSeq)
+	for _, event := range room.events {
+		if event.seq > lastSeq {
+			events = append(events, event.payload)
+		}
+	}
+	return events, room.seq, true, nil
+}
+
+// prune buang room yang tidak ada broadcast selama ttl, caller harus memegang m.mu
+func (m *memoryReplayBuffer) prune(now time.Time) {
+	if now.Sub(m.lastPrune) < m.ttl {
+		return
+	}
+	m.lastPrune = now
+
+	for roomID, room := range m.rooms {
+		if now.Sub(room.updatedAt) >= m.ttl {
+			delete(m.rooms, roomID)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisReplayBuffer replay buffer di Redis, seq dibagi oleh semua node: counter di
+// "ws:seq:<room_id>" dan broadcast terakhir di sorted set "ws:replay:<room_id>" dengan score seq
+type RedisReplayBuffer struct {
+	client *redis.Client
+	size   int
+	ttl    time.Duration
+}
+
+// NewRedisReplayBuffer membuat replay buffer Redis baru, size <= 0 dan ttl <= 0 memakai nilai default
+func NewRedisReplayBuffer(client *redis.Client, size int, ttl time.Duration) *RedisReplayBuffer {
+	if size <= 0 {
+		size = defaultReplaySize
+	}
+	if ttl <= 0 {
+		ttl = defaultReplayTTL
+	}
+	return &RedisReplayBuffer{
+		client: client,
+		size:   size,
+		ttl:    ttl,
+	}
+}
+
+func (r *RedisReplayBuffer) Append(ctx context.Context, roomID uint, msg WSMessage) ([]byte, error) {
+	seq, err := r.client.Incr(ctx, replaySeqKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Seq = uint64(seq)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	key := replayKey(roomID)
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(seq), Member: payload})
+	pipe.ZRemRangeByRank(ctx, key, 0, int64(-r.size-1))
+	pipe.Expire(ctx, key, r.ttl)
+	pipe.Expire(ctx, replaySeqKey(roomID), r.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (r *RedisReplayBuffer) Seq(ctx context.Context, roomID uint) (uint64, error) {
+	seq, err := r.client.Get(ctx, replaySeqKey(roomID)).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return seq, err
+}
+
+func (r *RedisReplayBuffer) Since(ctx context.Context, roomID uint, lastSeq uint64) ([][]byte, uint64, bool, error) {
+	current, err := r.Seq(ctx, roomID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if lastSeq > current {
+		return nil, current, false, nil
+	}
+	if lastSeq == current {
+		return nil, current, true, nil
+	}
+
+	entries, err := r.client.ZRangeByScoreWithScores(ctx, replayKey(roomID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatUint(lastSeq, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, current, false, err
+	}
+	if len(entries) == 0 || uint64(entries[0].Score) != lastSeq+1 {
+		return nil, current, false, nil
+	}
+
+	events := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		member, ok := entry.Member.(string)
+		if !ok {
+			continue
+		}
+		events = append(events, []byte(member))
+	}
+	return events, current, true, nil
+}
+
+func replaySeqKey(roomID uint) string {
+	return fmt.Sprintf("ws:seq:%d", roomID)
+}
+
+func replayKey(roomID uint) string {
+	return fmt.Sprintf("ws:replay:%d", roomID)
+}
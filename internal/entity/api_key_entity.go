@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// APIKey personal API key milik user, hanya hash SHA-256 key yang disimpan
+type APIKey struct {
+	ID         uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID     uint       `gorm:"column:user_id;not null;index:idx_api_keys_user"`
+	Name       string     `gorm:"column:name;type:varchar(100);not null"`
+	Prefix     string     `gorm:"column:prefix;type:varchar(20);not null"` // awal key untuk ditampilkan di list
+	KeyHash    string     `gorm:"column:key_hash;type:char(64);uniqueIndex:idx_api_keys_key_hash;not null"`
+	Scopes     []string   `gorm:"column:scopes;type:text;serializer:json;not null"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	LastUsedIP string     `gorm:"column:last_used_ip;type:varchar(64);not null;default:''"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (k *APIKey) TableName() string {
+	return "api_keys"
+}
@@ -1,13 +1,20 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Message struct {
-	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
-	RoomID        uint      `gorm:"column:room_id;not null;index:idx_messages_room;index:idx_messages_room_created"`
-	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_messages_participant"`
-	Content       string    `gorm:"column:content;type:text;not null"`
-	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null;index:idx_messages_room_created"`
+	ID            uint           `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID        uint           `gorm:"column:room_id;not null;index:idx_messages_room;index:idx_messages_room_created"`
+	ParticipantID uint           `gorm:"column:participant_id;not null;index:idx_messages_participant"`
+	Content       string         `gorm:"column:content;type:text;not null"`
+	CreatedAt     time.Time      `gorm:"column:created_at;autoCreateTime;not null;index:idx_messages_room_created"`
+	EditedAt      *time.Time     `gorm:"column:edited_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;index:idx_messages_deleted_at"` // soft delete
+	DeletedBy     *uint          `gorm:"column:deleted_by"`                               // participant yang menghapus
 
 	// Relationships
 	Room        Room        `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
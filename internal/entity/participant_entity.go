@@ -3,13 +3,15 @@ package entity
 import "time"
 
 type Participant struct {
-	ID          uint      `gorm:"column:id;primaryKey;autoIncrement"`
-	RoomID      uint      `gorm:"column:room_id;not null;index:idx_participants_room"`
-	UserID      *uint     `gorm:"column:user_id;index"` // NULL for anonymous
-	DisplayName string    `gorm:"column:display_name;type:varchar(100);not null"`
-	XPScore     int       `gorm:"column:xp_score;type:int;default:0;not null;index:idx_participants_xp_score"`
-	IsAnonymous *bool     `gorm:"column:is_anonymous;default:true;not null"`
-	JoinedAt    time.Time `gorm:"column:joined_at;autoCreateTime;not null;index:idx_participants_joined_at"`
+	ID          uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID      uint       `gorm:"column:room_id;not null;index:idx_participants_room"`
+	UserID      *uint      `gorm:"column:user_id;index"` // NULL for anonymous
+	DisplayName string     `gorm:"column:display_name;type:varchar(100);not null"`
+	XPScore     int        `gorm:"column:xp_score;type:int;default:0;not null;index:idx_participants_xp_score"`
+	IsAnonymous *bool      `gorm:"column:is_anonymous;default:true;not null"`
+	JoinedAt    time.Time  `gorm:"column:joined_at;autoCreateTime;not null;index:idx_participants_joined_at"`
+	Fingerprint *string    `gorm:"column:fingerprint;type:varchar(64);index:idx_participants_fingerprint"` // hash device, hanya untuk anonymous
+	MutedUntil  *time.Time `gorm:"column:muted_until"`                                                     // tidak bisa chat / bertanya sampai waktu ini
 
 	// Relationships
 	Room           Room            `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
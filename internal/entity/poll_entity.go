@@ -3,16 +3,22 @@ package entity
 import "time"
 
 type Poll struct {
-	ID          uint       `gorm:"column:id;primaryKey;autoIncrement"`
-	RoomID      uint       `gorm:"column:room_id;not null;index:idx_polls_room;index:idx_polls_room_status"`
-	Question    string     `gorm:"column:question;type:text;not null"`
-	Status      string     `gorm:"column:status;type:varchar(10);default:'draft';not null;index:idx_polls_status;index:idx_polls_room_status"`
-	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime;not null;index:idx_polls_created_at"`
-	ActivatedAt *time.Time `gorm:"column:activated_at;index:idx_polls_activated_at"`
-	ClosedAt    *time.Time `gorm:"column:closed_at"`
+	ID               uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID           uint       `gorm:"column:room_id;not null;index:idx_polls_room;index:idx_polls_room_status"`
+	Question         string     `gorm:"column:question;type:text;not null"`
+	Type             string     `gorm:"column:type;type:varchar(20);default:'single_choice';not null"`
+	MaxSelections    int        `gorm:"column:max_selections;type:smallint;default:1;not null"`
+	QuizID           *uint      `gorm:"column:quiz_id;index:idx_polls_quiz"`                        // NULL jika bukan pertanyaan quiz
+	TimeLimitSeconds int        `gorm:"column:time_limit_seconds;type:smallint;default:0;not null"` // detik setelah activated_at, 0 = tanpa timer
+	Status           string     `gorm:"column:status;type:varchar(10);default:'draft';not null;index:idx_polls_status;index:idx_polls_room_status"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime;not null;index:idx_polls_created_at"`
+	ScheduledAt      *time.Time `gorm:"column:scheduled_at;index:idx_polls_scheduled_at"` // draft diaktifkan otomatis pada waktu ini
+	ActivatedAt      *time.Time `gorm:"column:activated_at;index:idx_polls_activated_at"`
+	ClosedAt         *time.Time `gorm:"column:closed_at"`
 
 	// Relationships
 	Room          Room           `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+	Quiz          *Quiz          `gorm:"foreignKey:QuizID;references:ID;constraint:OnDelete:SET NULL"`
 	Options       []PollOption   `gorm:"foreignKey:PollID;references:ID;constraint:OnDelete:CASCADE"`
 	PollResponses []PollResponse `gorm:"foreignKey:PollID;references:ID;constraint:OnDelete:CASCADE"`
 }
@@ -6,6 +6,7 @@ type PollOption struct {
 	OptionText string `gorm:"column:option_text;type:varchar(255);not null"`
 	VoteCount  int    `gorm:"column:vote_count;type:int;default:0;not null"` // Denormalized
 	Order      int    `gorm:"column:order;type:smallint;not null;index:idx_poll_options_order"`
+	IsCorrect  bool   `gorm:"column:is_correct;default:false;not null"` // jawaban benar untuk pertanyaan quiz
 
 	// Relationships
 	Poll          Poll           `gorm:"foreignKey:PollID;references:ID;constraint:OnDelete:CASCADE"`
@@ -4,9 +4,10 @@ import "time"
 
 type PollResponse struct {
 	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
-	PollID        uint      `gorm:"column:poll_id;not null;index:idx_poll_responses_poll;uniqueIndex:unique_poll_response"`
-	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_poll_responses_participant;uniqueIndex:unique_poll_response"`
-	PollOptionID  uint      `gorm:"column:poll_option_id;not null;index:idx_poll_responses_option"`
+	PollID        uint      `gorm:"column:poll_id;not null;index:idx_poll_responses_poll;uniqueIndex:unique_poll_response_option"`
+	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_poll_responses_participant;uniqueIndex:unique_poll_response_option"`
+	PollOptionID  uint      `gorm:"column:poll_option_id;not null;index:idx_poll_responses_option;uniqueIndex:unique_poll_response_option"`
+	Rank          int       `gorm:"column:rank;type:smallint;default:0;not null"` // urutan preferensi untuk ranked choice (1 = pilihan pertama)
 	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null;index:idx_poll_responses_created_at"`
 
 	// Relationships
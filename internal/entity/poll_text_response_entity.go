@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// PollTextResponse jawaban free-text untuk open-text (word cloud) poll
+type PollTextResponse struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	PollID        uint      `gorm:"column:poll_id;not null;index:idx_poll_text_responses_poll;index:idx_poll_text_responses_poll_participant"`
+	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_poll_text_responses_poll_participant"`
+	Content       string    `gorm:"column:content;type:varchar(100);not null"`
+	Normalized    string    `gorm:"column:normalized;type:varchar(100);not null"` // term untuk word cloud
+	IsHidden      bool      `gorm:"column:is_hidden;default:false;not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	Poll        Poll        `gorm:"foreignKey:PollID;references:ID;constraint:OnDelete:CASCADE"`
+	Participant Participant `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (pt *PollTextResponse) TableName() string {
+	return "poll_text_responses"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type QuestionReply struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	QuestionID    uint      `gorm:"column:question_id;not null;index:idx_question_replies_question"`
+	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_question_replies_participant"`
+	Content       string    `gorm:"column:content;type:text;not null"`
+	IsPresenter   bool      `gorm:"column:is_presenter;default:false;not null"` // dibalas oleh presenter room
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	Question    Question    `gorm:"foreignKey:QuestionID;references:ID;constraint:OnDelete:CASCADE"`
+	Participant Participant `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (r *QuestionReply) TableName() string {
+	return "question_replies"
+}
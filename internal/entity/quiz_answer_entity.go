@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// QuizAnswer hasil penilaian jawaban participant untuk satu pertanyaan quiz
+type QuizAnswer struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	QuizID        uint      `gorm:"column:quiz_id;not null;index:idx_quiz_answers_quiz"`
+	PollID        uint      `gorm:"column:poll_id;not null;uniqueIndex:unique_quiz_answer"`
+	ParticipantID uint      `gorm:"column:participant_id;not null;index:idx_quiz_answers_participant;uniqueIndex:unique_quiz_answer"`
+	IsCorrect     bool      `gorm:"column:is_correct;default:false;not null"`
+	ResponseMs    int       `gorm:"column:response_ms;type:int;default:0;not null"` // waktu menjawab sejak poll activated_at
+	Points        int       `gorm:"column:points;type:int;default:0;not null"`      // XP yang didapat
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	Quiz        Quiz        `gorm:"foreignKey:QuizID;references:ID;constraint:OnDelete:CASCADE"`
+	Poll        Poll        `gorm:"foreignKey:PollID;references:ID;constraint:OnDelete:CASCADE"`
+	Participant Participant `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (qa *QuizAnswer) TableName() string {
+	return "quiz_answers"
+}
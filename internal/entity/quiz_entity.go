@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Quiz kumpulan poll yang dinilai benar/salah dan dihitung ranking-nya
+type Quiz struct {
+	ID         uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID     uint       `gorm:"column:room_id;not null;index:idx_quizzes_room"`
+	Title      string     `gorm:"column:title;type:varchar(255);not null"`
+	Status     string     `gorm:"column:status;type:varchar(10);default:'active';not null"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime;not null"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+
+	// Relationships
+	Room  Room   `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+	Polls []Poll `gorm:"foreignKey:QuizID;references:ID;constraint:OnDelete:SET NULL"`
+}
+
+func (q *Quiz) TableName() string {
+	return "quizzes"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// RoomBan participant yang diblokir dari room, berdasarkan user id (terdaftar) atau fingerprint (anonymous)
+type RoomBan struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID        uint      `gorm:"column:room_id;not null"`
+	ParticipantID *uint     `gorm:"column:participant_id"`
+	UserID        *uint     `gorm:"column:user_id;index:idx_room_bans_user"`
+	Fingerprint   *string   `gorm:"column:fingerprint;type:varchar(64);index:idx_room_bans_fingerprint"`
+	DisplayName   string    `gorm:"column:display_name;type:varchar(100);not null"`
+	Reason        string    `gorm:"column:reason;type:varchar(255);not null;default:''"`
+	BannedBy      *uint     `gorm:"column:banned_by"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	Room Room `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (b *RoomBan) TableName() string {
+	return "room_bans"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// RoomContentFilter konfigurasi filter konten chat dan question per room
+type RoomContentFilter struct {
+	RoomID              uint      `gorm:"column:room_id;primaryKey"`
+	Enabled             bool      `gorm:"column:enabled;default:false;not null"`
+	BlockedWords        []string  `gorm:"column:blocked_words;type:text;serializer:json;not null"`
+	WordAction          string    `gorm:"column:word_action;type:varchar(10);default:'mask';not null"` // mask, reject, moderate
+	BlockLinks          bool      `gorm:"column:block_links;default:false;not null"`
+	LinkAction          string    `gorm:"column:link_action;type:varchar(10);default:'reject';not null"`   // mask, reject, moderate
+	RepeatWindowSeconds int       `gorm:"column:repeat_window_seconds;default:0;not null"`                 // 0 = nonaktif
+	RepeatAction        string    `gorm:"column:repeat_action;type:varchar(10);default:'reject';not null"` // reject, moderate
+	FloodMaxMessages    int       `gorm:"column:flood_max_messages;default:0;not null"`                    // 0 = nonaktif
+	FloodWindowSeconds  int       `gorm:"column:flood_window_seconds;default:10;not null"`
+	UpdatedAt           time.Time `gorm:"column:updated_at;autoUpdateTime;not null"`
+
+	Room Room `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (r *RoomContentFilter) TableName() string {
+	return "room_content_filters"
+}
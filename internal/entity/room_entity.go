@@ -3,13 +3,15 @@ package entity
 import "time"
 
 type Room struct {
-	ID          uint       `gorm:"column:id;primaryKey;autoIncrement"`
-	RoomCode    string     `gorm:"column:room_code;type:varchar(20);uniqueIndex;not null"`
-	Title       string     `gorm:"column:title;type:varchar(255);not null"`
-	PresenterID uint       `gorm:"column:presenter_id;not null;index"`
-	Status      string     `gorm:"column:status;type:varchar(10);default:'active';not null;index"`
-	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime;not null;index:idx_rooms_created_at"`
-	ClosedAt    *time.Time `gorm:"column:closed_at"`
+	ID                 uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomCode           string     `gorm:"column:room_code;type:varchar(20);uniqueIndex;not null"`
+	Title              string     `gorm:"column:title;type:varchar(255);not null"`
+	PresenterID        uint       `gorm:"column:presenter_id;not null;index"`
+	Status             string     `gorm:"column:status;type:varchar(10);default:'active';not null;index"`
+	QuestionModeration bool       `gorm:"column:question_moderation;default:false;not null"` // pertanyaan baru masuk antrian moderasi
+	ParticipantReplies bool       `gorm:"column:participant_replies;default:false;not null"` // participant boleh membalas pertanyaan
+	CreatedAt          time.Time  `gorm:"column:created_at;autoCreateTime;not null;index:idx_rooms_created_at"`
+	ClosedAt           *time.Time `gorm:"column:closed_at"`
 
 	// Relationships
 	Presenter      User            `gorm:"foreignKey:PresenterID;references:ID;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// RoomPresenceEvent riwayat participant online / offline di room, dipakai untuk analytics
+type RoomPresenceEvent struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	RoomID        uint      `gorm:"column:room_id;not null;index:idx_room_presence_events_room_created"`
+	ParticipantID uint      `gorm:"column:participant_id;not null"`
+	Event         string    `gorm:"column:event;type:varchar(10);not null"` // join | leave
+	OnlineCount   int       `gorm:"column:online_count;not null;default:0"` // jumlah participant online setelah event
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime;not null;index:idx_room_presence_events_room_created"`
+
+	// Relationships
+	Room        Room        `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+	Participant Participant `gorm:"foreignKey:ParticipantID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (e *RoomPresenceEvent) TableName() string {
+	return "room_presence_events"
+}
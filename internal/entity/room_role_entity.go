@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// RoomRole role tambahan user di room (co_host / moderator).
+// Owner diambil dari rooms.presenter_id, user tanpa row adalah participant biasa.
+type RoomRole struct {
+	RoomID    uint      `gorm:"column:room_id;primaryKey"`
+	UserID    uint      `gorm:"column:user_id;primaryKey;index:idx_room_roles_user"`
+	Role      string    `gorm:"column:role;type:varchar(20);not null"`
+	GrantedBy *uint     `gorm:"column:granted_by"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	Room Room `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (rr *RoomRole) TableName() string {
+	return "room_roles"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// UserIdentity akun di identity provider OIDC (issuer + subject) yang terhubung ke user
+type UserIdentity struct {
+	ID          uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      uint       `gorm:"column:user_id;not null;index:idx_user_identities_user"`
+	Issuer      string     `gorm:"column:issuer;type:varchar(255);not null;uniqueIndex:idx_user_identities_issuer_subject"`
+	Subject     string     `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_user_identities_issuer_subject"`
+	Email       string     `gorm:"column:email;type:varchar(255);not null;default:''"` // email dari id_token saat login terakhir
+	LastLoginAt *time.Time `gorm:"column:last_login_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (i *UserIdentity) TableName() string {
+	return "user_identities"
+}
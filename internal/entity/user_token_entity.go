@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeEmailVerification = "email_verification"
+)
+
+// UserToken token sekali pakai untuk reset password dan verifikasi email, hanya hash SHA-256 yang disimpan
+type UserToken struct {
+	ID          uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      uint       `gorm:"column:user_id;not null;index:idx_user_tokens_user_purpose"`
+	Purpose     string     `gorm:"column:purpose;type:varchar(32);not null;index:idx_user_tokens_user_purpose"`
+	Email       string     `gorm:"column:email;type:varchar(255);not null"` // email tujuan saat token dibuat
+	TokenHash   string     `gorm:"column:token_hash;type:char(64);uniqueIndex:idx_user_tokens_token_hash;not null"`
+	RequestedIP string     `gorm:"column:requested_ip;type:varchar(64);not null;default:''"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt      *time.Time `gorm:"column:used_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime;not null"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (t *UserToken) TableName() string {
+	return "user_tokens"
+}
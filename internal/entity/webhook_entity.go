@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// Webhook endpoint milik presenter, RoomID nil berarti berlaku untuk semua room milik user
+type Webhook struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"column:user_id;not null;index:idx_webhooks_user"`
+	RoomID    *uint     `gorm:"column:room_id;index:idx_webhooks_room"`
+	URL       string    `gorm:"column:url;type:varchar(2048);not null"`
+	Secret    string    `gorm:"column:secret;type:varchar(100);not null"` // kunci HMAC, hanya ditampilkan saat create
+	Events    []string  `gorm:"column:events;type:text;serializer:json;not null"`
+	IsActive  bool      `gorm:"column:is_active;default:true;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime;not null"`
+
+	// Relationships
+	User User  `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	Room *Room `gorm:"foreignKey:RoomID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (w *Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery satu pengiriman event ke webhook, termasuk retry
+type WebhookDelivery struct {
+	ID             uint       `gorm:"column:id;primaryKey;autoIncrement"`
+	WebhookID      uint       `gorm:"column:webhook_id;not null;index:idx_webhook_deliveries_webhook_created"`
+	RoomID         uint       `gorm:"column:room_id;not null"`
+	Event          string     `gorm:"column:event;type:varchar(50);not null"`
+	Payload        string     `gorm:"column:payload;type:text;not null"` // body JSON yang ditandatangani
+	Status         string     `gorm:"column:status;type:varchar(10);default:'pending';not null"`
+	Attempts       int        `gorm:"column:attempts;default:0;not null"`
+	ResponseStatus *int       `gorm:"column:response_status"`
+	ResponseBody   string     `gorm:"column:response_body;type:text;not null;default:''"`
+	LastError      string     `gorm:"column:last_error;type:text;not null;default:''"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	ReplayOf       *uint      `gorm:"column:replay_of"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime;not null;index:idx_webhook_deliveries_webhook_created"`
+
+	// Relationships
+	Webhook Webhook `gorm:"foreignKey:WebhookID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (d *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogSender untuk development dan test: email ditulis ke log,
+// dan jika Path diisi juga ditambahkan sebagai satu baris JSON ke file
+type LogSender struct {
+	Log  *logrus.Logger
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewLogSender create new instance of LogSender
+func NewLogSender(log *logrus.Logger, path string) *LogSender {
+	return &LogSender{
+		Log:  log,
+		Path: path,
+	}
+}
+
+// fileEntry satu baris JSON di file mail
+type fileEntry struct {
+	Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+// Send tulis email ke log (dan file), tidak pernah dikirim keluar
+func (s *LogSender) Send(ctx context.Context, message *Message) error {
+	s.Log.Infof("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	if s.Path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(fileEntry{Message: *message, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,15 @@
+package mail
+
+import "context"
+
+// Message email plain text yang dikirim ke satu penerima
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Sender mengirim email, implementasi dipilih dari config "mail.driver"
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,69 @@
+package model
+
+// ForgotPasswordRequest minta email reset password, respons selalu sama agar email tidak bisa di-enumerasi
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	IP    string `json:"-"` // ip peminta, diisi controller
+}
+
+// ResetPasswordRequest set password baru memakai token dari email
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,len=64,hexadecimal"`
+	Password string `json:"password" validate:"required,min=8,max=100"`
+}
+
+// SendVerificationEmailRequest kirim (ulang) email verifikasi ke email user saat ini
+type SendVerificationEmailRequest struct {
+	UserID uint   `validate:"required,min=1"`
+	IP     string `json:"-"` // ip peminta, diisi controller
+}
+
+// VerifyEmailRequest verifikasi email memakai token dari email
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,len=64,hexadecimal"`
+}
+
+// GetProfileRequest ambil profil user yang sedang login
+type GetProfileRequest struct {
+	UserID uint `validate:"required,min=1"`
+}
+
+// UpdateProfileRequest ubah profil user, field nil tidak diubah.
+// Email, role dan password hanya bisa diubah dengan password saat ini (re-authentication)
+type UpdateProfileRequest struct {
+	Username        *string `json:"username" validate:"omitempty,min=3,max=30,alphanum"`
+	Email           *string `json:"email" validate:"omitempty,email,max=255"`
+	Role            *string `json:"role" validate:"omitempty,oneof=presenter admin"`
+	NewPassword     *string `json:"new_password" validate:"omitempty,min=8,max=100"`
+	CurrentPassword string  `json:"current_password" validate:"max=100"`
+
+	UserID uint   `json:"-" validate:"required,min=1"`
+	IP     string `json:"-"` // ip peminta, diisi controller
+	Auth   *Auth  `json:"-"` // claims session saat ini, access token-nya diterbitkan ulang, diisi controller
+}
+
+// UpdateProfileResponse profil baru dan access token session saat ini dengan claims yang sudah diubah
+type UpdateProfileResponse struct {
+	User              UserResponse `json:"user"`
+	Token             string       `json:"-"` // access token baru, diset sebagai cookie
+	RevokedSessionIDs []string     `json:"-"` // session lain yang dicabut karena password berubah
+}
+
+// DeleteAccountRequest hapus akun user, wajib konfirmasi password
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required,max=100"`
+
+	UserID uint `json:"-" validate:"required,min=1"`
+}
+
+// DeleteAccountResponse data untuk memutus koneksi websocket setelah akun dihapus
+type DeleteAccountResponse struct {
+	SessionIDs  []string     // session user yang dicabut
+	ClosedRooms []ClosedRoom // room aktif milik user yang ikut terhapus
+}
+
+// ClosedRoom room aktif yang terhapus bersama akun owner, participant-nya diberi tahu lewat websocket
+type ClosedRoom struct {
+	Room           UpdateToCloseRoom
+	ParticipantIDs []uint
+}
@@ -0,0 +1,117 @@
+package model
+
+import "time"
+
+// Event presence history
+const (
+	PresenceEventJoin  = "join"
+	PresenceEventLeave = "leave"
+)
+
+// RecordPresenceRequest request untuk mencatat participant online / offline (dari websocket hub)
+type RecordPresenceRequest struct {
+	RoomID        uint   `validate:"required,min=1"`
+	ParticipantID uint   `validate:"required,min=1"`
+	Event         string `validate:"required,oneof=join leave"`
+	OnlineCount   int    `validate:"min=0"`
+	At            time.Time
+}
+
+// GetRoomAnalyticsRequest request analytics room (owner only)
+// BucketMinutes 0 berarti lebar bucket dipilih otomatis dari durasi room
+type GetRoomAnalyticsRequest struct {
+	PresenterID   uint `json:"-" validate:"required,min=1"`
+	RoomID        uint `json:"-" validate:"required,min=1"`
+	BucketMinutes int  `json:"-" validate:"omitempty,min=1,max=1440"`
+}
+
+// RoomAnalyticsResponse analytics room, series time-bucketed dari created_at room sampai closed_at / sekarang
+type RoomAnalyticsResponse struct {
+	RoomID        uint                 `json:"room_id"`
+	From          time.Time            `json:"from"`
+	To            time.Time            `json:"to"`
+	BucketMinutes int                  `json:"bucket_minutes"`
+	Participants  ParticipantAnalytics `json:"participants"`
+	Messages      MessageAnalytics     `json:"messages"`
+	Questions     QuestionAnalytics    `json:"questions"`
+	Polls         PollAnalytics        `json:"polls"`
+	XP            XPDistribution       `json:"xp"`
+	Series        []AnalyticsBucket    `json:"series"`
+}
+
+// AnalyticsBucket satu titik series
+type AnalyticsBucket struct {
+	Start     time.Time `json:"start"`
+	Joins     int       `json:"joins"`
+	Leaves    int       `json:"leaves"`
+	Online    int       `json:"online"` // jumlah online tertinggi di bucket
+	Messages  int       `json:"messages"`
+	Questions int       `json:"questions"`
+}
+
+// ParticipantAnalytics ringkasan kehadiran
+type ParticipantAnalytics struct {
+	Total          int        `json:"total"`
+	OnlineNow      int        `json:"online_now"`
+	PeakConcurrent int        `json:"peak_concurrent"`
+	PeakAt         *time.Time `json:"peak_at,omitempty"`
+}
+
+// MessageAnalytics ringkasan chat
+type MessageAnalytics struct {
+	Total     int     `json:"total"`
+	PerMinute float64 `json:"per_minute"`
+}
+
+// QuestionAnalytics ringkasan Q&A
+type QuestionAnalytics struct {
+	Total              int     `json:"total"`
+	Answered           int     `json:"answered"`
+	Upvotes            int     `json:"upvotes"`
+	UpvotesPerQuestion float64 `json:"upvotes_per_question"`
+}
+
+// PollAnalytics tingkat partisipasi poll
+type PollAnalytics struct {
+	Total                    int                 `json:"total"`
+	AverageParticipationRate float64             `json:"average_participation_rate"`
+	Items                    []PollParticipation `json:"items"`
+}
+
+// PollParticipation partisipasi satu poll, rate = voters / total participant room
+type PollParticipation struct {
+	PollID            uint    `json:"poll_id"`
+	Question          string  `json:"question"`
+	Type              string  `json:"type"`
+	Status            string  `json:"status"`
+	Voters            int     `json:"voters"`
+	ParticipationRate float64 `json:"participation_rate"`
+}
+
+// XPDistribution sebaran xp_score participant
+type XPDistribution struct {
+	Average float64    `json:"average"`
+	Median  float64    `json:"median"`
+	Max     int        `json:"max"`
+	Buckets []XPBucket `json:"buckets"`
+}
+
+// XPBucket jumlah participant dengan xp_score di rentang [min, max], max nil berarti tanpa batas atas
+type XPBucket struct {
+	Min   int  `json:"min"`
+	Max   *int `json:"max"`
+	Count int  `json:"count"`
+}
+
+// TimeBucketCount jumlah baris per bucket (bucket = index sejak epoch / lebar bucket)
+type TimeBucketCount struct {
+	Bucket int64
+	Count  int
+}
+
+// QuestionStats agregat question approved room
+type QuestionStats struct {
+	Total    int
+	Answered int
+	Upvotes  int
+}
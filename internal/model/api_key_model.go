@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// APIKeyPrefix awalan setiap API key, dipakai middleware untuk membedakan API key dari JWT
+const APIKeyPrefix = "rsk_"
+
+// Scope API key, format <resource>:<read|write>
+const (
+	ScopeRoomsRead         = "rooms:read"
+	ScopeRoomsWrite        = "rooms:write"
+	ScopeParticipantsRead  = "participants:read"
+	ScopeParticipantsWrite = "participants:write"
+	ScopeMessagesRead      = "messages:read"
+	ScopeMessagesWrite     = "messages:write"
+	ScopeQuestionsRead     = "questions:read"
+	ScopeQuestionsWrite    = "questions:write"
+	ScopePollsRead         = "polls:read"
+	ScopePollsWrite        = "polls:write"
+	ScopeWebhooksRead      = "webhooks:read"
+	ScopeWebhooksWrite     = "webhooks:write"
+)
+
+// CreateAPIKeyRequest request untuk membuat API key, ExpiresInDays 0 berarti tidak kedaluwarsa
+type CreateAPIKeyRequest struct {
+	UserID        uint     `json:"-" validate:"required,min=1"`
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,max=12,dive,oneof=rooms:read rooms:write participants:read participants:write messages:read messages:write questions:read questions:write polls:read polls:write webhooks:read webhooks:write"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+// ListAPIKeysRequest request untuk list API key milik user
+type ListAPIKeysRequest struct {
+	UserID uint `json:"-" validate:"required,min=1"`
+}
+
+// RevokeAPIKeyRequest request untuk mencabut API key
+type RevokeAPIKeyRequest struct {
+	UserID   uint `json:"-" validate:"required,min=1"`
+	APIKeyID uint `json:"-" validate:"required,min=1"`
+}
+
+// AuthenticateAPIKeyRequest request dari auth middleware untuk API key di header Authorization
+type AuthenticateAPIKeyRequest struct {
+	Key string `validate:"required,startswith=rsk_,max=100"`
+	IP  string `validate:"max=64"`
+}
+
+// VerifyAPIKeyRequest request dari auth middleware untuk token room yang dibuat lewat API key
+type VerifyAPIKeyRequest struct {
+	APIKeyID uint   `validate:"required,min=1"`
+	IP       string `validate:"max=64"`
+}
+
+// APIKeyResponse response API key, Key hanya diisi saat create
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Key        string     `json:"key,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyListResponse response list API key
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
@@ -15,11 +15,42 @@ type Auth struct {
 	Role        string `json:"role"`                   // "presenter" | "admin" | "anonymous"
 
 	// Flags
-	IsAnonymous bool `json:"is_anonymous"`
-	IsRoomOwner bool `json:"is_room_owner"` // true jika user adalah pembuat room (host)
+	IsAnonymous bool   `json:"is_anonymous"`
+	IsRoomOwner bool   `json:"is_room_owner"`       // true jika user adalah pembuat room (host)
+	RoomRole    string `json:"room_role,omitempty"` // owner | co_host | moderator | participant
+
+	// API key, diisi jika request (atau token room hasil join / create) berasal dari API key
+	APIKeyID *uint    `json:"api_key_id,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+
+	// Session login, access token hanya berlaku selama session masih ada di Redis
+	SessionID string `json:"sid,omitempty"`
 
 	// Standard claims
 	jwt.RegisteredClaims
 }
 
 // Update struct for updating Auth information
+
+// CanHost true untuk owner dan co-host: kelola poll, quiz, announcement dan conference
+func (a *Auth) CanHost() bool {
+	return a.IsRoomOwner || RoomRoleCanHost(a.RoomRole)
+}
+
+// CanModerate true untuk owner, co-host dan moderator: antrian dan validasi Q&A
+func (a *Auth) CanModerate() bool {
+	return a.IsRoomOwner || RoomRoleCanModerate(a.RoomRole)
+}
+
+// HasScope true jika request boleh mengakses scope ini, login biasa (bukan API key) selalu boleh
+func (a *Auth) HasScope(scope string) bool {
+	if a.APIKeyID == nil {
+		return true
+	}
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
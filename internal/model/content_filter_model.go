@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// GetContentFilterRequest request untuk melihat konfigurasi filter konten room (presenter only)
+type GetContentFilterRequest struct {
+	PresenterID uint `json:"-" validate:"required,min=1"`
+	RoomID      uint `json:"-" validate:"required,min=1"`
+}
+
+// UpdateContentFilterRequest request untuk mengganti seluruh konfigurasi filter konten room (presenter only)
+type UpdateContentFilterRequest struct {
+	PresenterID         uint     `json:"-" validate:"required,min=1"`
+	RoomID              uint     `json:"-" validate:"required,min=1"`
+	Enabled             bool     `json:"enabled"`
+	BlockedWords        []string `json:"blocked_words" validate:"max=500,dive,required,max=50"`
+	WordAction          string   `json:"word_action" validate:"required,oneof=mask reject moderate"`
+	BlockLinks          bool     `json:"block_links"`
+	LinkAction          string   `json:"link_action" validate:"required,oneof=mask reject moderate"`
+	RepeatWindowSeconds int      `json:"repeat_window_seconds" validate:"min=0,max=3600"`
+	RepeatAction        string   `json:"repeat_action" validate:"required,oneof=reject moderate"`
+	FloodMaxMessages    int      `json:"flood_max_messages" validate:"min=0,max=100"`
+	FloodWindowSeconds  int      `json:"flood_window_seconds" validate:"required,min=1,max=3600"`
+}
+
+// ContentFilterResponse konfigurasi filter konten room
+type ContentFilterResponse struct {
+	RoomID              uint       `json:"room_id"`
+	Enabled             bool       `json:"enabled"`
+	BlockedWords        []string   `json:"blocked_words"`
+	WordAction          string     `json:"word_action"`
+	BlockLinks          bool       `json:"block_links"`
+	LinkAction          string     `json:"link_action"`
+	RepeatWindowSeconds int        `json:"repeat_window_seconds"`
+	RepeatAction        string     `json:"repeat_action"`
+	FloodMaxMessages    int        `json:"flood_max_messages"`
+	FloodWindowSeconds  int        `json:"flood_window_seconds"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"reisify/internal/entity"
+	"reisify/internal/model"
+)
+
+// APIKeyToResponse convert entity APIKey to model APIKeyResponse (tanpa key)
+func APIKeyToResponse(apiKey *entity.APIKey) *model.APIKeyResponse {
+	scopes := apiKey.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &model.APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		LastUsedIP: apiKey.LastUsedIP,
+		ExpiresAt:  apiKey.ExpiresAt,
+		RevokedAt:  apiKey.RevokedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+// APIKeysToListResponse convert list of APIKey to APIKeyListResponse
+func APIKeysToListResponse(apiKeys []entity.APIKey) *model.APIKeyListResponse {
+	responses := make([]model.APIKeyResponse, len(apiKeys))
+	for i := range apiKeys {
+		responses[i] = *APIKeyToResponse(&apiKeys[i])
+	}
+	return &model.APIKeyListResponse{
+		APIKeys: responses,
+	}
+}
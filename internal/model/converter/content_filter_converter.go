@@ -0,0 +1,32 @@
+package converter
+
+import (
+	"reisify/internal/entity"
+	"reisify/internal/model"
+)
+
+// ContentFilterToResponse convert entity RoomContentFilter to model ContentFilterResponse
+func ContentFilterToResponse(filter *entity.RoomContentFilter) *model.ContentFilterResponse {
+	words := filter.BlockedWords
+	if words == nil {
+		words = []string{}
+	}
+
+	response := &model.ContentFilterResponse{
+		RoomID:              filter.RoomID,
+		Enabled:             filter.Enabled,
+		BlockedWords:        words,
+		WordAction:          filter.WordAction,
+		BlockLinks:          filter.BlockLinks,
+		LinkAction:          filter.LinkAction,
+		RepeatWindowSeconds: filter.RepeatWindowSeconds,
+		RepeatAction:        filter.RepeatAction,
+		FloodMaxMessages:    filter.FloodMaxMessages,
+		FloodWindowSeconds:  filter.FloodWindowSeconds,
+	}
+	// konfigurasi default (belum pernah disimpan) tidak punya updated_at
+	if !filter.UpdatedAt.IsZero() {
+		response.UpdatedAt = &filter.UpdatedAt
+	}
+	return response
+}
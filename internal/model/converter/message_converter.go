@@ -16,6 +16,7 @@ func MessageToResponse(message *entity.Message) *model.MessageResponse {
 		},
 		Content:   message.Content,
 		CreatedAt: message.CreatedAt,
+		EditedAt:  message.EditedAt,
 	}
 }
 
@@ -31,3 +32,15 @@ func MessagesToMessageListResponse(messages []entity.Message, hasMore bool) *mod
 		HasMore:  hasMore,
 	}
 }
+
+// MessageToDeleteResponse convert message yang dihapus ke model DeleteMessageResponse
+func MessageToDeleteResponse(message *entity.Message, deletedBy uint, byModerator bool, xpRevoked int) *model.DeleteMessageResponse {
+	return &model.DeleteMessageResponse{
+		ID:            message.ID,
+		RoomID:        message.RoomID,
+		ParticipantID: message.ParticipantID,
+		DeletedBy:     deletedBy,
+		ByModerator:   byModerator,
+		XPRevoked:     xpRevoked,
+	}
+}
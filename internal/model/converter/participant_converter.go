@@ -18,11 +18,7 @@ func ParticipantToResponse(participant *entity.Participant) *model.ParticipantRe
 }
 
 // ParticipantToResponseWithRole convert entity Participant to model ParticipantResponse with room role
-func ParticipantToResponseWithRole(participant *entity.Participant, isRoomOwner bool) *model.ParticipantResponse {
-	roomRole := "audience"
-	if isRoomOwner {
-		roomRole = "host"
-	}
+func ParticipantToResponseWithRole(participant *entity.Participant, roomRole string) *model.ParticipantResponse {
 	return &model.ParticipantResponse{
 		ID:          participant.ID,
 		RoomID:      participant.RoomID,
@@ -34,19 +30,21 @@ func ParticipantToResponseWithRole(participant *entity.Participant, isRoomOwner
 	}
 }
 
-// ParticipantToJoinRoomResponse convert entity Participant and token to model JoinRoomResponse
-func ParticipantToJoinRoomResponse(participant *entity.Participant, token string) *model.JoinRoomResponse {
+// ParticipantToJoinRoomResponse convert entity Participant and tokens to model JoinRoomResponse
+func ParticipantToJoinRoomResponse(participant *entity.Participant, tokens *model.TokenPair) *model.JoinRoomResponse {
 	return &model.JoinRoomResponse{
-		Participant: *ParticipantToResponse(participant),
-		Token:       token,
+		Participant:  *ParticipantToResponse(participant),
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 }
 
-// ParticipantToJoinRoomResponseWithRole convert entity Participant and token to model JoinRoomResponse with room role
-func ParticipantToJoinRoomResponseWithRole(participant *entity.Participant, token string, isRoomOwner bool) *model.JoinRoomResponse {
+// ParticipantToJoinRoomResponseWithRole convert entity Participant and tokens to model JoinRoomResponse with room role
+func ParticipantToJoinRoomResponseWithRole(participant *entity.Participant, tokens *model.TokenPair, roomRole string) *model.JoinRoomResponse {
 	return &model.JoinRoomResponse{
-		Participant: *ParticipantToResponseWithRole(participant, isRoomOwner),
-		Token:       token,
+		Participant:  *ParticipantToResponseWithRole(participant, roomRole),
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 }
 
@@ -101,3 +99,37 @@ func ParticipantsToLeaderboardResponse(participants []entity.Participant, myRank
 		TotalParticipants: totalParticipants,
 	}
 }
+
+// ParticipantToModerationResponse convert participant yang dimoderasi ke model ParticipantModerationResponse
+func ParticipantToModerationResponse(participant *entity.Participant, action string, reason string) *model.ParticipantModerationResponse {
+	return &model.ParticipantModerationResponse{
+		Action:        action,
+		ParticipantID: participant.ID,
+		DisplayName:   participant.DisplayName,
+		Reason:        reason,
+		MutedUntil:    participant.MutedUntil,
+	}
+}
+
+// RoomBanToResponse convert entity RoomBan to model BanResponse
+func RoomBanToResponse(ban *entity.RoomBan) *model.BanResponse {
+	return &model.BanResponse{
+		ID:            ban.ID,
+		ParticipantID: ban.ParticipantID,
+		DisplayName:   ban.DisplayName,
+		IsAnonymous:   ban.UserID == nil,
+		Reason:        ban.Reason,
+		CreatedAt:     ban.CreatedAt,
+	}
+}
+
+// RoomBansToListResponse convert list of RoomBan to model BanListResponse
+func RoomBansToListResponse(bans []entity.RoomBan) *model.BanListResponse {
+	responses := make([]model.BanResponse, len(bans))
+	for i := range bans {
+		responses[i] = *RoomBanToResponse(&bans[i])
+	}
+	return &model.BanListResponse{
+		Bans: responses,
+	}
+}
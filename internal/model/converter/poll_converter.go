@@ -1,10 +1,21 @@
 package converter
 
 import (
+	"math"
 	"reisify/internal/entity"
 	"reisify/internal/model"
+	"time"
 )
 
+// PollEndsAt waktu berakhirnya countdown poll (activated_at + time_limit_seconds), nil jika tanpa timer
+func PollEndsAt(poll *entity.Poll) *time.Time {
+	if poll.TimeLimitSeconds <= 0 || poll.ActivatedAt == nil {
+		return nil
+	}
+	endsAt := poll.ActivatedAt.Add(time.Duration(poll.TimeLimitSeconds) * time.Second)
+	return &endsAt
+}
+
 // PollOptionToResponse convert entity PollOption to model PollOptionResponse
 func PollOptionToResponse(option *entity.PollOption) model.PollOptionResponse {
 	return model.PollOptionResponse{
@@ -55,48 +66,81 @@ func PollOptionsToResponseWithPercentage(options []entity.PollOption, totalVotes
 // PollToResponse convert entity Poll to model PollResponse
 func PollToResponse(poll *entity.Poll) *model.PollResponse {
 	return &model.PollResponse{
-		ID:          poll.ID,
-		RoomID:      poll.RoomID,
-		Question:    poll.Question,
-		Status:      poll.Status,
-		CreatedAt:   poll.CreatedAt,
-		ActivatedAt: poll.ActivatedAt,
-		ClosedAt:    poll.ClosedAt,
-		Options:     PollOptionsToResponse(poll.Options),
+		ID:            poll.ID,
+		RoomID:        poll.RoomID,
+		Question:      poll.Question,
+		Type:          poll.Type,
+		MaxSelections: poll.MaxSelections,
+		Status:        poll.Status,
+		CreatedAt:     poll.CreatedAt,
+		ScheduledAt:   poll.ScheduledAt,
+		ActivatedAt:   poll.ActivatedAt,
+		ClosedAt:      poll.ClosedAt,
+		Options:       PollOptionsToResponse(poll.Options),
+		QuizID:        poll.QuizID,
+		TimeLimit:     poll.TimeLimitSeconds,
+		EndsAt:        PollEndsAt(poll),
 	}
 }
 
 // PollToResponseWithOptions convert entity Poll dengan options yang sudah ada
-func PollToResponseWithOptions(poll *entity.Poll, totalVotes int, hasVoted bool, myVoteID *uint) *model.PollResponse {
-	return &model.PollResponse{
-		ID:          poll.ID,
-		RoomID:      poll.RoomID,
-		Question:    poll.Question,
-		Status:      poll.Status,
-		TotalVotes:  totalVotes,
-		CreatedAt:   poll.CreatedAt,
-		ActivatedAt: poll.ActivatedAt,
-		ClosedAt:    poll.ClosedAt,
-		Options:     PollOptionsToResponseWithPercentage(poll.Options, totalVotes),
-		HasVoted:    hasVoted,
-		MyVoteID:    myVoteID,
+// myVoteIDs berisi option yang dipilih participant (urut ranking untuk ranked choice)
+func PollToResponseWithOptions(poll *entity.Poll, totalVotes int, myVoteIDs []uint) *model.PollResponse {
+	response := &model.PollResponse{
+		ID:            poll.ID,
+		RoomID:        poll.RoomID,
+		Question:      poll.Question,
+		Type:          poll.Type,
+		MaxSelections: poll.MaxSelections,
+		Status:        poll.Status,
+		TotalVotes:    totalVotes,
+		CreatedAt:     poll.CreatedAt,
+		ScheduledAt:   poll.ScheduledAt,
+		ActivatedAt:   poll.ActivatedAt,
+		ClosedAt:      poll.ClosedAt,
+		Options:       PollOptionsToResponseWithPercentage(poll.Options, totalVotes),
+		HasVoted:      len(myVoteIDs) > 0,
+		QuizID:        poll.QuizID,
+		TimeLimit:     poll.TimeLimitSeconds,
+		EndsAt:        PollEndsAt(poll),
 	}
+	if len(myVoteIDs) > 0 {
+		response.MyVoteID = &myVoteIDs[0]
+		response.MyVoteIDs = myVoteIDs
+	}
+	return response
 }
 
 // PollToCreateResponse convert untuk create poll response
 func PollToCreateResponse(poll *entity.Poll) *model.CreatePollResponse {
 	return &model.CreatePollResponse{
 		Poll: model.PollResponse{
-			ID:        poll.ID,
-			RoomID:    poll.RoomID,
-			Question:  poll.Question,
-			Status:    poll.Status,
-			CreatedAt: poll.CreatedAt,
-			Options:   PollOptionsToResponse(poll.Options),
+			ID:            poll.ID,
+			RoomID:        poll.RoomID,
+			Question:      poll.Question,
+			Type:          poll.Type,
+			MaxSelections: poll.MaxSelections,
+			Status:        poll.Status,
+			CreatedAt:     poll.CreatedAt,
+			ScheduledAt:   poll.ScheduledAt,
+			ActivatedAt:   poll.ActivatedAt,
+			Options:       PollOptionsToResponse(poll.Options),
+			QuizID:        poll.QuizID,
+			TimeLimit:     poll.TimeLimitSeconds,
+			EndsAt:        PollEndsAt(poll),
 		},
 	}
 }
 
+// PollToDraftResponse convert draft poll untuk presenter, termasuk jawaban benar quiz
+func PollToDraftResponse(poll *entity.Poll) *model.PollResponse {
+	response := PollToResponse(poll)
+	if poll.QuizID != nil {
+		response.CorrectOptionIDs = PollOptionsToCorrectIDs(poll.Options)
+	}
+	return response
+}
+
 // PollResponseToResponse convert entity PollResponse (vote) to model PollResponseResponse
 func PollResponseToResponse(response *entity.PollResponse) model.PollResponseResponse {
 	return model.PollResponseResponse{
@@ -108,21 +152,74 @@ func PollResponseToResponse(response *entity.PollResponse) model.PollResponseRes
 	}
 }
 
+// PollResponsesToResponse convert semua response satu vote, response pertama jadi response utama
+func PollResponsesToResponse(responses []entity.PollResponse) model.PollResponseResponse {
+	if len(responses) == 0 {
+		return model.PollResponseResponse{}
+	}
+
+	result := PollResponseToResponse(&responses[0])
+	if len(responses) > 1 {
+		result.SelectedOptionIDs = make([]uint, len(responses))
+		for i, response := range responses {
+			result.SelectedOptionIDs[i] = response.PollOptionID
+		}
+	}
+	return result
+}
+
+// PollOptionsToRatingResults hitung rata-rata rating poll, nilai tiap option = urutan option (1..skala)
+func PollOptionsToRatingResults(options []entity.PollOption) *model.RatingResults {
+	results := &model.RatingResults{Scale: len(options)}
+
+	sum := 0
+	for _, option := range options {
+		sum += option.Order * option.VoteCount
+		results.Count += option.VoteCount
+	}
+
+	if results.Count > 0 {
+		// Round to 2 decimal places
+		results.Average = math.Round(float64(sum)/float64(results.Count)*100) / 100
+	}
+	return results
+}
+
+// PollToResultsResponse convert hasil poll sesuai tipe poll
+// ranked hanya dipakai untuk ranked choice poll (hasil instant-runoff), terms untuk open-text poll
+func PollToResultsResponse(poll *entity.Poll, totalVotes int, ranked *model.RankedChoiceResults, terms []model.PollTermResponse) model.UpdatedPollResultsResponse {
+	results := model.UpdatedPollResultsResponse{
+		PollID:     poll.ID,
+		RoomID:     poll.RoomID,
+		Type:       poll.Type,
+		TotalVotes: totalVotes,
+		Options:    PollOptionsToResponseWithPercentage(poll.Options, totalVotes),
+	}
+
+	switch poll.Type {
+	case model.PollTypeRankedChoice:
+		results.Ranked = ranked
+	case model.PollTypeRating:
+		results.Rating = PollOptionsToRatingResults(poll.Options)
+	case model.PollTypeOpenText:
+		results.Terms = terms
+		if results.Terms == nil {
+			results.Terms = []model.PollTermResponse{}
+		}
+	}
+	return results
+}
+
 // PollToVoteResponse convert untuk submit vote response
 func PollToVoteResponse(
-	response *entity.PollResponse,
-	poll *entity.Poll,
-	totalVotes int,
+	response model.PollResponseResponse,
+	results model.UpdatedPollResultsResponse,
 	xpPoints int,
 	newTotal int,
 ) *model.SubmitPollVoteResponse {
 	return &model.SubmitPollVoteResponse{
-		Response: PollResponseToResponse(response),
-		UpdatedResults: model.UpdatedPollResultsResponse{
-			PollID:     poll.ID,
-			TotalVotes: totalVotes,
-			Options:    PollOptionsToResponseWithPercentage(poll.Options, totalVotes),
-		},
+		Response:       response,
+		UpdatedResults: results,
 		XPEarned: &model.XPEarned{
 			Points:   xpPoints,
 			NewTotal: newTotal,
@@ -131,33 +228,87 @@ func PollToVoteResponse(
 }
 
 // PollToCloseResponse convert untuk close poll response
-func PollToCloseResponse(poll *entity.Poll, totalVotes int) *model.ClosePollResponse {
+func PollToCloseResponse(poll *entity.Poll, results model.UpdatedPollResultsResponse) *model.ClosePollResponse {
 	response := &model.ClosePollResponse{}
 	response.Poll.ID = poll.ID
 	response.Poll.Status = poll.Status
 	response.Poll.ClosedAt = poll.ClosedAt
 	response.Poll.FinalResults = model.FinalPollResultsResponse{
-		TotalVotes: totalVotes,
-		Options:    PollOptionsToResponseWithPercentage(poll.Options, totalVotes),
+		Type:       results.Type,
+		TotalVotes: results.TotalVotes,
+		Options:    results.Options,
+		Ranked:     results.Ranked,
+		Rating:     results.Rating,
+		Terms:      results.Terms,
+	}
+
+	// jawaban benar quiz baru dibuka setelah poll ditutup
+	if poll.QuizID != nil {
+		response.Poll.FinalResults.CorrectOptionIDs = PollOptionsToCorrectIDs(poll.Options)
 	}
 	return response
 }
 
+// PollOptionsToCorrectIDs ambil id option yang ditandai benar
+func PollOptionsToCorrectIDs(options []entity.PollOption) []uint {
+	ids := make([]uint, 0)
+	for _, option := range options {
+		if option.IsCorrect {
+			ids = append(ids, option.ID)
+		}
+	}
+	return ids
+}
+
+// PollTextResponseToResponse convert jawaban open-text ke model PollResponseResponse (vote)
+func PollTextResponseToResponse(answer *entity.PollTextResponse) model.PollResponseResponse {
+	return model.PollResponseResponse{
+		ID:            answer.ID,
+		PollID:        answer.PollID,
+		ParticipantID: answer.ParticipantID,
+		Text:          answer.Content,
+		CreatedAt:     answer.CreatedAt,
+	}
+}
+
+// PollTextResponseToAnswerResponse convert jawaban open-text untuk tampilan presenter
+func PollTextResponseToAnswerResponse(answer *entity.PollTextResponse) model.PollAnswerResponse {
+	return model.PollAnswerResponse{
+		ID:            answer.ID,
+		ParticipantID: answer.ParticipantID,
+		Content:       answer.Content,
+		Normalized:    answer.Normalized,
+		IsHidden:      answer.IsHidden,
+		CreatedAt:     answer.CreatedAt,
+	}
+}
+
+// PollTextResponsesToListResponse convert list jawaban open-text
+func PollTextResponsesToListResponse(answers []entity.PollTextResponse) *model.ListPollAnswersResponse {
+	result := make([]model.PollAnswerResponse, len(answers))
+	for i, answer := range answers {
+		result[i] = PollTextResponseToAnswerResponse(&answer)
+	}
+	return &model.ListPollAnswersResponse{
+		Answers: result,
+	}
+}
+
 // PollsToHistoryResponse convert untuk poll history response
-func PollsToHistoryResponse(polls []entity.Poll, total int64) *model.PollHistoryResponse {
+// totalVotes berisi jumlah participant yang vote per poll
+func PollsToHistoryResponse(polls []entity.Poll, totalVotes map[uint]int, total int64) *model.PollHistoryResponse {
 	result := make([]model.PollResponse, len(polls))
 	for i, poll := range polls {
-		totalVotes := 0
-		for _, opt := range poll.Options {
-			totalVotes += opt.VoteCount
-		}
 		result[i] = model.PollResponse{
-			ID:         poll.ID,
-			Question:   poll.Question,
-			Status:     poll.Status,
-			TotalVotes: totalVotes,
-			CreatedAt:  poll.CreatedAt,
-			ClosedAt:   poll.ClosedAt,
+			ID:          poll.ID,
+			Question:    poll.Question,
+			Type:        poll.Type,
+			Status:      poll.Status,
+			TotalVotes:  totalVotes[poll.ID],
+			CreatedAt:   poll.CreatedAt,
+			ScheduledAt: poll.ScheduledAt,
+			ClosedAt:    poll.ClosedAt,
+			QuizID:      poll.QuizID,
 		}
 	}
 	return &model.PollHistoryResponse{
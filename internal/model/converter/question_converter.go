@@ -16,6 +16,7 @@ func QuestionToResponse(question *entity.Question) *model.QuestionResponse {
 		Status:                 question.Status,
 		IsValidatedByPresenter: question.IsValidatedByPresenter,
 		XPAwarded:              question.XPAwarded,
+		ModerationStatus:       question.ModerationStatus,
 		CreatedAt:              question.CreatedAt,
 	}
 }
@@ -33,24 +34,30 @@ func QuestionToResponseWithParticipant(question *entity.Question, hasVoted bool)
 		Status:                 question.Status,
 		IsValidatedByPresenter: question.IsValidatedByPresenter,
 		HasVoted:               hasVoted,
+		ModerationStatus:       question.ModerationStatus,
 		CreatedAt:              question.CreatedAt,
 	}
 }
 
+// QuestionReplyToResponse convert entity QuestionReply to model ReplyResponse
+func QuestionReplyToResponse(reply *entity.QuestionReply) *model.ReplyResponse {
+	return &model.ReplyResponse{
+		ID:         reply.ID,
+		QuestionID: reply.QuestionID,
+		Participant: model.ParticipantInfo{
+			ID:          reply.Participant.ID,
+			DisplayName: reply.Participant.DisplayName,
+		},
+		Content:     reply.Content,
+		IsPresenter: reply.IsPresenter,
+		CreatedAt:   reply.CreatedAt,
+	}
+}
+
 // QuestionToSubmitResponse convert untuk submit response
 func QuestionToSubmitResponse(question *entity.Question, xpPoints int, newTotal int) *model.SubmitQuestionResponse {
 	return &model.SubmitQuestionResponse{
-		Question: model.QuestionResponse{
-			ID:                     question.ID,
-			RoomID:                 question.RoomID,
-			ParticipantID:          question.ParticipantID,
-			Content:                question.Content,
-			UpvoteCount:            question.UpvoteCount,
-			Status:                 question.Status,
-			IsValidatedByPresenter: question.IsValidatedByPresenter,
-			XPAwarded:              question.XPAwarded,
-			CreatedAt:              question.CreatedAt,
-		},
+		Question: *QuestionToResponse(question),
 		XPEarned: &model.XPEarned{
 			Points:   xpPoints,
 			NewTotal: newTotal,
@@ -58,6 +65,45 @@ func QuestionToSubmitResponse(question *entity.Question, xpPoints int, newTotal
 	}
 }
 
+// QuestionToQueuedResponse convert untuk submit response saat question masuk antrian moderasi (belum ada XP)
+func QuestionToQueuedResponse(question *entity.Question) *model.SubmitQuestionResponse {
+	return &model.SubmitQuestionResponse{
+		Question: *QuestionToResponse(question),
+	}
+}
+
+// QuestionToModerateResponse convert untuk moderate response, xpEarned nil jika tidak ada XP
+func QuestionToModerateResponse(question *entity.Question, action string, reason string, xpEarned *model.XPEarned) *model.ModerateQuestionResponse {
+	response := QuestionToResponse(question)
+	response.Participant = model.ParticipantInfo{
+		ID:          question.Participant.ID,
+		DisplayName: question.Participant.DisplayName,
+	}
+	return &model.ModerateQuestionResponse{
+		Action:   action,
+		Question: *response,
+		Reason:   reason,
+		XPEarned: xpEarned,
+	}
+}
+
+// QuestionsToQueueResponse convert antrian moderasi
+func QuestionsToQueueResponse(questions []entity.Question, total int64, limit int, offset int) *model.QuestionQueueResponse {
+	responses := make([]model.QuestionResponse, len(questions))
+	for i := range questions {
+		responses[i] = *QuestionToResponseWithParticipant(&questions[i], false)
+		responses[i].ParticipantID = questions[i].ParticipantID
+	}
+	return &model.QuestionQueueResponse{
+		Questions: responses,
+		Paging: model.QuestionPaging{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}
+}
+
 // VoteToResponse convert entity Vote to model VoteResponse
 func VoteToResponse(vote *entity.Vote) *model.VoteResponse {
 	return &model.VoteResponse{
@@ -0,0 +1,73 @@
+package converter
+
+import (
+	"math"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+)
+
+// QuizToResponse convert entity Quiz to model QuizResponse
+func QuizToResponse(quiz *entity.Quiz) *model.QuizResponse {
+	return &model.QuizResponse{
+		ID:         quiz.ID,
+		RoomID:     quiz.RoomID,
+		Title:      quiz.Title,
+		Status:     quiz.Status,
+		CreatedAt:  quiz.CreatedAt,
+		FinishedAt: quiz.FinishedAt,
+	}
+}
+
+// QuizToSummaryResponse gabungkan pertanyaan quiz dengan agregat jawaban menjadi ringkasan quiz
+func QuizToSummaryResponse(
+	quiz *entity.Quiz,
+	polls []entity.Poll,
+	questionStats []model.QuizQuestionStat,
+	ranking []model.QuizParticipantStat,
+) *model.QuizSummaryResponse {
+	statsByPoll := make(map[uint]model.QuizQuestionStat, len(questionStats))
+	for _, stat := range questionStats {
+		statsByPoll[stat.PollID] = stat
+	}
+
+	questions := make([]model.QuizQuestionSummary, len(polls))
+	for i, poll := range polls {
+		stat := statsByPoll[poll.ID]
+		question := model.QuizQuestionSummary{
+			PollID:         poll.ID,
+			Question:       poll.Question,
+			Status:         poll.Status,
+			TotalAnswers:   stat.TotalAnswers,
+			CorrectAnswers: stat.CorrectAnswers,
+			AvgResponseMs:  int(math.Round(stat.AvgResponseMs)),
+		}
+		if stat.TotalAnswers > 0 {
+			accuracy := float64(stat.CorrectAnswers) / float64(stat.TotalAnswers) * 100
+			question.Accuracy = math.Round(accuracy*100) / 100
+		}
+		// jangan bocorkan jawaban benar untuk pertanyaan yang masih berjalan
+		if poll.Status == "closed" {
+			question.CorrectOptionIDs = PollOptionsToCorrectIDs(poll.Options)
+		}
+		questions[i] = question
+	}
+
+	entries := make([]model.QuizRankingEntry, len(ranking))
+	for i, stat := range ranking {
+		entries[i] = model.QuizRankingEntry{
+			Rank:           i + 1,
+			ParticipantID:  stat.ParticipantID,
+			DisplayName:    stat.DisplayName,
+			CorrectAnswers: stat.CorrectAnswers,
+			TotalAnswers:   stat.TotalAnswers,
+			Points:         stat.Points,
+			AvgResponseMs:  int(math.Round(stat.AvgResponseMs)),
+		}
+	}
+
+	return &model.QuizSummaryResponse{
+		Quiz:      *QuizToResponse(quiz),
+		Questions: questions,
+		Ranking:   entries,
+	}
+}
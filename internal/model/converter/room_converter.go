@@ -8,13 +8,15 @@ import (
 // RoomToResponse convert entity Room to model RoomResponse
 func RoomToResponse(room *entity.Room) *model.RoomResponse {
 	return &model.RoomResponse{
-		ID:          room.ID,
-		RoomCode:    room.RoomCode,
-		Title:       room.Title,
-		PresenterID: room.PresenterID,
-		Status:      room.Status,
-		CreatedAt:   room.CreatedAt,
-		ClosedAt:    room.ClosedAt,
+		ID:                 room.ID,
+		RoomCode:           room.RoomCode,
+		Title:              room.Title,
+		PresenterID:        room.PresenterID,
+		Status:             room.Status,
+		QuestionModeration: room.QuestionModeration,
+		ParticipantReplies: room.ParticipantReplies,
+		CreatedAt:          room.CreatedAt,
+		ClosedAt:           room.ClosedAt,
 	}
 }
 
@@ -29,10 +31,12 @@ func RoomToCreateRoomResponse(room *entity.Room, participantID uint) *model.Crea
 // RoomToDetailResponse convert entity Room with relations to model RoomDetailResponse
 func RoomToDetailResponse(room *entity.Room) *model.RoomDetailResponse {
 	return &model.RoomDetailResponse{
-		ID:       room.ID,
-		RoomCode: room.RoomCode,
-		Title:    room.Title,
-		Status:   room.Status,
+		ID:                 room.ID,
+		RoomCode:           room.RoomCode,
+		Title:              room.Title,
+		Status:             room.Status,
+		QuestionModeration: room.QuestionModeration,
+		ParticipantReplies: room.ParticipantReplies,
 		Presenter: model.PresenterInfo{
 			ID:       room.Presenter.ID,
 			Username: room.Presenter.Username,
@@ -74,3 +78,33 @@ func RoomsToListResponse(roomsList []*model.RoomListItem) *model.RoomListRespons
 		RoomListItem: roomsList,
 	}
 }
+
+// RoomToSettingsResponse convert entity Room to model RoomSettingsResponse
+func RoomToSettingsResponse(room *entity.Room) *model.RoomSettingsResponse {
+	return &model.RoomSettingsResponse{
+		RoomID:             room.ID,
+		QuestionModeration: room.QuestionModeration,
+		ParticipantReplies: room.ParticipantReplies,
+	}
+}
+
+// RoomRoleToModeratorResponse convert model RoomRoleResponse to model ModeratorResponse
+func RoomRoleToModeratorResponse(role *model.RoomRoleResponse) *model.ModeratorResponse {
+	return &model.ModeratorResponse{
+		UserID:    role.UserID,
+		Username:  role.Username,
+		CreatedAt: role.CreatedAt,
+	}
+}
+
+// RoomRoleToResponse convert entity RoomRole to model RoomRoleResponse
+func RoomRoleToResponse(role *entity.RoomRole, participantID *uint) *model.RoomRoleResponse {
+	return &model.RoomRoleResponse{
+		UserID:        role.UserID,
+		Username:      role.User.Username,
+		Role:          role.Role,
+		ParticipantID: participantID,
+		GrantedBy:     role.GrantedBy,
+		CreatedAt:     role.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package converter
+
+import "reisify/internal/model"
+
+// SessionToResponse convert SessionInfo to model SessionResponse, currentID session yang sedang dipakai
+func SessionToResponse(session *model.SessionInfo, currentID string) *model.SessionResponse {
+	return &model.SessionResponse{
+		ID:           session.ID,
+		Device:       session.Device,
+		UserAgent:    session.UserAgent,
+		IP:           session.IP,
+		RoomID:       session.RoomID,
+		Current:      session.ID == currentID,
+		CreatedAt:    session.CreatedAt,
+		LastActiveAt: session.LastActiveAt,
+	}
+}
+
+// SessionsToListResponse convert list of SessionInfo to SessionListResponse
+func SessionsToListResponse(sessions []model.SessionInfo, currentID string) *model.SessionListResponse {
+	responses := make([]model.SessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = *SessionToResponse(&sessions[i], currentID)
+	}
+	return &model.SessionListResponse{
+		Sessions: responses,
+	}
+}
@@ -8,18 +8,20 @@ import (
 // UserToResponse convert entity User to model UserResponse
 func UserToResponse(user *entity.User) *model.UserResponse {
 	return &model.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Role:            user.Role,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
 	}
 }
 
-// UserToAuthResponse convert entity User and token to model AuthResponse
-func UserToAuthResponse(user *entity.User, token string) *model.AuthResponse {
+// UserToAuthResponse convert entity User and tokens to model AuthResponse
+func UserToAuthResponse(user *entity.User, tokens *model.TokenPair) *model.AuthResponse {
 	return &model.AuthResponse{
-		User:  *UserToResponse(user),
-		Token: token,
+		User:         *UserToResponse(user),
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 }
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"encoding/json"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+)
+
+// WebhookToResponse convert entity Webhook to model WebhookResponse (tanpa secret)
+func WebhookToResponse(webhook *entity.Webhook) *model.WebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &model.WebhookResponse{
+		ID:        webhook.ID,
+		RoomID:    webhook.RoomID,
+		URL:       webhook.URL,
+		Events:    events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// WebhooksToListResponse convert list of Webhook to WebhookListResponse
+func WebhooksToListResponse(webhooks []entity.Webhook) *model.WebhookListResponse {
+	responses := make([]model.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		responses[i] = *WebhookToResponse(&webhooks[i])
+	}
+	return &model.WebhookListResponse{Webhooks: responses}
+}
+
+// WebhookDeliveryToResponse convert entity WebhookDelivery to model WebhookDeliveryResponse
+func WebhookDeliveryToResponse(delivery *entity.WebhookDelivery) *model.WebhookDeliveryResponse {
+	return &model.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		RoomID:         delivery.RoomID,
+		Event:          delivery.Event,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+// WebhookDeliveriesToListResponse convert list of WebhookDelivery to WebhookDeliveryListResponse
+func WebhookDeliveriesToListResponse(deliveries []entity.WebhookDelivery) *model.WebhookDeliveryListResponse {
+	responses := make([]model.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = *WebhookDeliveryToResponse(&deliveries[i])
+	}
+	return &model.WebhookDeliveryListResponse{Deliveries: responses}
+}
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Format export room
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatHTML = "html"
+)
+
+// ExportRoomRequest request untuk export data sesi room (owner only)
+type ExportRoomRequest struct {
+	PresenterID uint   `json:"-" validate:"required,min=1"`
+	RoomID      uint   `json:"-" validate:"required,min=1"`
+	Format      string `json:"-" validate:"required,oneof=json csv html"`
+}
+
+// RoomExport dokumen export lengkap satu room
+type RoomExport struct {
+	Room        RoomExportInfo     `json:"room"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	Questions   []QuestionResponse `json:"questions"`
+	Polls       []PollExport       `json:"polls"`
+	Messages    []MessageExport    `json:"messages"`
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+}
+
+// RoomExportInfo ringkasan room di dokumen export
+type RoomExportInfo struct {
+	ID                uint       `json:"id"`
+	RoomCode          string     `json:"room_code"`
+	Title             string     `json:"title"`
+	Status            string     `json:"status"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ClosedAt          *time.Time `json:"closed_at,omitempty"`
+	TotalParticipants int        `json:"total_participants"`
+	TotalQuestions    int        `json:"total_questions"`
+	TotalPolls        int        `json:"total_polls"`
+	TotalMessages     int        `json:"total_messages"`
+}
+
+// PollExport hasil akhir poll, term word cloud untuk open-text poll
+type PollExport struct {
+	PollResponse
+	Terms []PollTermResponse `json:"terms,omitempty"`
+}
+
+// MessageExport chat message di dokumen export
+type MessageExport struct {
+	ID          uint            `json:"id"`
+	Participant ParticipantInfo `json:"participant"`
+	Content     string          `json:"content"`
+	CreatedAt   time.Time       `json:"created_at"`
+	EditedAt    *time.Time      `json:"edited_at,omitempty"`
+}
+
+// ExportFile hasil export yang siap dikirim sebagai attachment
+type ExportFile struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
@@ -14,6 +14,32 @@ type MessageResponse struct {
 	Participant ParticipantInfo `json:"participant"`
 	Content     string          `json:"content"`
 	CreatedAt   time.Time       `json:"created_at"`
+	EditedAt    *time.Time      `json:"edited_at,omitempty"`
+}
+
+// UpdateMessageRequest request untuk edit message oleh author
+type UpdateMessageRequest struct {
+	MessageID     uint   `json:"-" validate:"required,min=1"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	ParticipantID uint   `json:"-" validate:"required,min=1"`
+	Content       string `json:"content" validate:"required,min=1,max=1000"`
+}
+
+// DeleteMessageRequest request untuk hapus message (author atau owner room)
+type DeleteMessageRequest struct {
+	MessageID     uint `json:"-" validate:"required,min=1"`
+	RoomID        uint `json:"-" validate:"required,min=1"`
+	ParticipantID uint `json:"-" validate:"required,min=1"`
+}
+
+// DeleteMessageResponse response setelah message dihapus
+type DeleteMessageResponse struct {
+	ID            uint `json:"id"`
+	RoomID        uint `json:"room_id"`
+	ParticipantID uint `json:"participant_id"`       // author message
+	DeletedBy     uint `json:"deleted_by"`           // participant yang menghapus
+	ByModerator   bool `json:"by_moderator"`         // dihapus owner room, bukan author
+	XPRevoked     int  `json:"xp_revoked,omitempty"` // XP author yang dibatalkan
 }
 
 type GetMessagesRequest struct {
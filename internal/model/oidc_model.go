@@ -0,0 +1,27 @@
+package model
+
+// OIDCLoginRequest mulai login SSO, Redirect path frontend tujuan setelah login (opsional)
+type OIDCLoginRequest struct {
+	Redirect string `validate:"omitempty,max=512"`
+}
+
+// OIDCLoginResponse url authorization provider dan state yang juga disimpan di cookie browser
+type OIDCLoginResponse struct {
+	AuthURL string
+	State   string
+}
+
+// OIDCCallbackRequest parameter callback dari provider
+type OIDCCallbackRequest struct {
+	Code        string         `validate:"required,max=2048"`
+	State       string         `validate:"required,max=128"`
+	CookieState string         // state dari cookie browser yang memulai login
+	Error       string         // error dari provider, misal access_denied
+	Client      *SessionClient // device session login, diisi controller
+}
+
+// OIDCCallbackResponse hasil login SSO dan url frontend tujuan redirect
+type OIDCCallbackResponse struct {
+	Auth     *AuthResponse
+	Redirect string
+}
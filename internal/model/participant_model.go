@@ -4,10 +4,26 @@ import (
 	"time"
 )
 
+// Status presence participant di room, dari koneksi websocket dan heartbeat client
+const (
+	PresenceOnline  = "online"  // terkoneksi dan aktif
+	PresenceIdle    = "idle"    // terkoneksi, client melaporkan idle (tab tidak aktif, tidak ada input)
+	PresenceAway    = "away"    // terkoneksi tapi heartbeat berhenti (misal aplikasi di background)
+	PresenceOffline = "offline" // tidak ada koneksi
+)
+
 type JoinRoomRequest struct {
 	Username    string `json:"username" validate:"required,min=3,max=30,alphanum"`
 	DisplayName string `json:"display_name,omitempty" validate:"omitempty,min=2,max=100"`
 	RoomCode    string `json:"room_code" validate:"required,len=6,alphanum"`
+
+	// diteruskan ke token room jika join dilakukan dengan API key
+	APIKeyID *uint    `json:"-"`
+	Scopes   []string `json:"-"`
+
+	// session login yang dipakai ulang untuk token room
+	SessionID string         `json:"-"`
+	Client    *SessionClient `json:"-"`
 }
 
 type ParticipantResponse struct {
@@ -16,7 +32,7 @@ type ParticipantResponse struct {
 	DisplayName string    `json:"display_name"`
 	XPScore     int       `json:"xp_score"`
 	IsAnonymous bool      `json:"is_anonymous"`
-	RoomRole    string    `json:"room_role,omitempty"` // "host" atau "audience"
+	RoomRole    string    `json:"room_role,omitempty"` // owner | co_host | moderator | participant
 	JoinedAt    time.Time `json:"joined_at,omitempty"`
 }
 
@@ -27,23 +43,98 @@ type ListParticipantsRequest struct {
 	Size          int  `json:"size" validate:"required,min=1,max=100"`
 }
 
+// ListOnlineParticipantsRequest request data participant yang sedang online (ID dari hub websocket)
+type ListOnlineParticipantsRequest struct {
+	RoomID         uint   `json:"-" validate:"required,min=1"`
+	ParticipantIDs []uint `json:"-"`
+}
+
 type ParticipantListItem struct {
 	ID          uint   `json:"id"`
 	DisplayName string `json:"display_name"`
 	XPScore     int    `json:"xp_score"`
 	IsAnonymous bool   `json:"is_anonymous"`
+	Status      string `json:"status,omitempty"` // online | idle | away | offline, diisi dari hub websocket
 }
 
 type ParticipantListResponse struct {
 	Participants []*ParticipantListItem `json:"participants"`
 }
 
+// RoomPresenceResponse participant yang sedang terkoneksi ke room beserta status presence
+type RoomPresenceResponse struct {
+	Participants []*ParticipantListItem `json:"participants"`
+	OnlineCount  int                    `json:"online_count"`
+}
+
 type JoinRoomResponse struct {
-	Participant ParticipantResponse `json:"participant"`
-	Token       string              `json:"-"` // token is set as HTTP-only cookie, not returned in body
+	Participant  ParticipantResponse `json:"participant"`
+	Token        string              `json:"-"` // token is set as HTTP-only cookie, not returned in body
+	RefreshToken string              `json:"-"` // refresh token is set as HTTP-only cookie, empty if session is reused
 }
 
 type ParticipantInfo struct {
 	ID          uint   `json:"id"`
 	DisplayName string `json:"display_name"`
 }
+
+// ModerateParticipantRequest request untuk kick / ban participant (owner, co-host atau moderator)
+type ModerateParticipantRequest struct {
+	ActorID       uint   `json:"-" validate:"required,min=1"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	ParticipantID uint   `json:"-" validate:"required,min=1"`
+	Reason        string `json:"reason" validate:"omitempty,max=255"`
+}
+
+// MuteParticipantRequest request untuk mute participant sementara (owner, co-host atau moderator)
+type MuteParticipantRequest struct {
+	ActorID         uint `json:"-" validate:"required,min=1"`
+	RoomID          uint `json:"-" validate:"required,min=1"`
+	ParticipantID   uint `json:"-" validate:"required,min=1"`
+	DurationSeconds int  `json:"duration_seconds" validate:"required,min=1,max=86400"`
+}
+
+// UnmuteParticipantRequest request untuk membatalkan mute participant (owner, co-host atau moderator)
+type UnmuteParticipantRequest struct {
+	ActorID       uint `json:"-" validate:"required,min=1"`
+	RoomID        uint `json:"-" validate:"required,min=1"`
+	ParticipantID uint `json:"-" validate:"required,min=1"`
+}
+
+// ListBansRequest request untuk daftar ban di room (owner, co-host atau moderator)
+type ListBansRequest struct {
+	ActorID uint `json:"-" validate:"required,min=1"`
+	RoomID  uint `json:"-" validate:"required,min=1"`
+}
+
+// UnbanRequest request untuk menghapus ban (owner, co-host atau moderator)
+type UnbanRequest struct {
+	ActorID uint `json:"-" validate:"required,min=1"`
+	RoomID  uint `json:"-" validate:"required,min=1"`
+	BanID   uint `json:"-" validate:"required,min=1"`
+}
+
+// ParticipantModerationResponse hasil kick / ban / mute, juga dipakai sebagai payload websocket
+type ParticipantModerationResponse struct {
+	Action        string     `json:"action"` // kicked, banned, muted, unmuted
+	ParticipantID uint       `json:"participant_id"`
+	DisplayName   string     `json:"display_name"`
+	Reason        string     `json:"reason,omitempty"`
+	MutedUntil    *time.Time `json:"muted_until,omitempty"`
+	BanID         uint       `json:"ban_id,omitempty"`
+}
+
+// BanResponse response untuk single ban
+type BanResponse struct {
+	ID            uint      `json:"id"`
+	ParticipantID *uint     `json:"participant_id,omitempty"`
+	DisplayName   string    `json:"display_name"`
+	IsAnonymous   bool      `json:"is_anonymous"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BanListResponse response untuk daftar ban
+type BanListResponse struct {
+	Bans []BanResponse `json:"bans"`
+}
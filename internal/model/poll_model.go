@@ -2,16 +2,70 @@ package model
 
 import "time"
 
+// Poll types
+const (
+	PollTypeSingleChoice   = "single_choice"   // pilih satu option
+	PollTypeMultipleChoice = "multiple_choice" // pilih sampai max_selections option
+	PollTypeRankedChoice   = "ranked_choice"   // urutkan option, dihitung dengan instant-runoff
+	PollTypeRating         = "rating"          // skala rating, option = titik skala
+	PollTypeOpenText       = "open_text"       // jawaban free-text, hasil berupa term frequency (word cloud)
+)
+
+// DefaultRatingScale skala default untuk rating poll (1-5)
+const DefaultRatingScale = 5
+
 // ========================================
 // Request Models
 // ========================================
 
 // CreatePollRequest request untuk membuat poll baru
+// untuk rating poll, options opsional (label tiap titik skala), default 1..rating_scale
+// untuk open_text poll, options tidak dipakai dan max_selections = jumlah jawaban per participant
 type CreatePollRequest struct {
-	RoomID      uint     `json:"-" validate:"required,min=1"`
-	PresenterID uint     `json:"-" validate:"required,min=1"`
-	Question    string   `json:"question" validate:"required,min=3,max=500"`
-	Options     []string `json:"options" validate:"required,min=2,max=10,dive,min=1,max=255"`
+	RoomID        uint     `json:"-" validate:"required,min=1"`
+	PresenterID   uint     `json:"-" validate:"required,min=1"`
+	Question      string   `json:"question" validate:"required,min=3,max=500"`
+	Type          string   `json:"type" validate:"omitempty,oneof=single_choice multiple_choice ranked_choice rating open_text"`
+	Options       []string `json:"options" validate:"omitempty,min=2,max=10,dive,min=1,max=255"`
+	MaxSelections int      `json:"max_selections" validate:"omitempty,min=1,max=10"`
+	RatingScale   int      `json:"rating_scale" validate:"omitempty,min=2,max=10"`
+
+	// quiz mode: poll jadi pertanyaan quiz, correct_options = index option yang benar (0-based)
+	QuizID           uint  `json:"quiz_id" validate:"omitempty,min=1"`
+	CorrectOptions   []int `json:"correct_options" validate:"omitempty,max=10,unique,dive,min=0,max=9"`
+	TimeLimitSeconds int   `json:"time_limit_seconds" validate:"omitempty,min=5,max=600"`
+
+	// draft: simpan tanpa mengaktifkan, scheduled_at: draft diaktifkan otomatis pada waktu tersebut
+	Draft       bool       `json:"draft"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// UpdatePollRequest request untuk edit draft poll, field kosong berarti tidak diubah
+// options mengganti semua option (urutan array = urutan option), tipe poll tidak bisa diubah
+type UpdatePollRequest struct {
+	PollID           uint       `json:"-" validate:"required,min=1"`
+	PresenterID      uint       `json:"-" validate:"required,min=1"`
+	Question         *string    `json:"question" validate:"omitempty,min=3,max=500"`
+	Options          []string   `json:"options" validate:"omitempty,min=2,max=10,dive,min=1,max=255"`
+	MaxSelections    *int       `json:"max_selections" validate:"omitempty,min=1,max=10"`
+	CorrectOptions   []int      `json:"correct_options" validate:"omitempty,max=10,unique,dive,min=0,max=9"`
+	TimeLimitSeconds *int       `json:"time_limit_seconds" validate:"omitempty,min=5,max=600"`
+	ScheduledAt      *time.Time `json:"scheduled_at"`
+	Unschedule       bool       `json:"unschedule"` // hapus jadwal aktivasi
+}
+
+// ReorderPollOptionsRequest request untuk mengubah urutan option draft poll
+type ReorderPollOptionsRequest struct {
+	PollID      uint   `json:"-" validate:"required,min=1"`
+	PresenterID uint   `json:"-" validate:"required,min=1"`
+	OptionIDs   []uint `json:"option_ids" validate:"required,min=2,max=10,unique,dive,min=1"`
+}
+
+// ActivatePollRequest request untuk mengaktifkan draft poll
+type ActivatePollRequest struct {
+	PollID      uint `json:"-" validate:"required,min=1"`
+	PresenterID uint `json:"-" validate:"required,min=1"`
+	RoomID      uint `json:"-" validate:"omitempty,min=1"` // jika diisi, poll harus milik room ini (websocket)
 }
 
 // GetActivePollsRequest request untuk mendapatkan active polls
@@ -22,23 +76,50 @@ type GetActivePollsRequest struct {
 
 // GetPollHistoryRequest request untuk mendapatkan poll history
 type GetPollHistoryRequest struct {
-	RoomID uint   `json:"-" validate:"required,min=1"`
-	Status string `json:"status" validate:"omitempty,oneof=active closed all"`
-	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	Status        string `json:"status" validate:"omitempty,oneof=draft active closed all"`
+	Limit         int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	IncludeDrafts bool   `json:"-"` // hanya presenter yang boleh melihat draft
 }
 
 // SubmitPollVoteRequest request untuk submit vote pada poll
+// single_choice: option_id, multiple_choice: option_ids, ranked_choice: option_ids urut preferensi,
+// rating: rating (1..skala) atau option_id, open_text: text
 type SubmitPollVoteRequest struct {
-	PollID        uint `json:"-" validate:"required,min=1"`
-	ParticipantID uint `json:"-" validate:"required,min=1"`
-	RoomID        uint `json:"-" validate:"required,min=1"`
-	OptionID      uint `json:"option_id" validate:"required,min=1"`
+	PollID        uint   `json:"-" validate:"required,min=1"`
+	ParticipantID uint   `json:"-" validate:"required,min=1"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	OptionID      uint   `json:"option_id" validate:"required_without_all=OptionIDs Rating Text,omitempty,min=1"`
+	OptionIDs     []uint `json:"option_ids" validate:"omitempty,max=10,unique,dive,min=1"`
+	Rating        int    `json:"rating" validate:"omitempty,min=1,max=10"`
+	Text          string `json:"text" validate:"omitempty,min=1,max=100"`
+}
+
+// GetPollResultsRequest request untuk mendapatkan hasil poll
+type GetPollResultsRequest struct {
+	PollID uint `json:"-" validate:"required,min=1"`
+	RoomID uint `json:"-" validate:"required,min=1"`
+}
+
+// ListPollAnswersRequest request untuk list jawaban open-text poll (presenter only)
+type ListPollAnswersRequest struct {
+	PollID      uint `json:"-" validate:"required,min=1"`
+	PresenterID uint `json:"-" validate:"required,min=1"`
+}
+
+// HidePollAnswerRequest request untuk hide / unhide jawaban open-text poll (presenter only)
+type HidePollAnswerRequest struct {
+	PollID      uint  `json:"-" validate:"required,min=1"`
+	AnswerID    uint  `json:"-" validate:"required,min=1"`
+	PresenterID uint  `json:"-" validate:"required,min=1"`
+	Hidden      *bool `json:"hidden" validate:"required"`
 }
 
 // ClosePollRequest request untuk menutup poll
 type ClosePollRequest struct {
 	PollID      uint `json:"-" validate:"required,min=1"`
 	PresenterID uint `json:"-" validate:"required,min=1"`
+	RoomID      uint `json:"-" validate:"omitempty,min=1"` // jika diisi, poll harus milik room ini (websocket)
 }
 
 // ========================================
@@ -57,17 +138,26 @@ type PollOptionResponse struct {
 
 // PollResponse response untuk single poll
 type PollResponse struct {
-	ID          uint                 `json:"id"`
-	RoomID      uint                 `json:"room_id,omitempty"`
-	Question    string               `json:"question"`
-	Status      string               `json:"status"`
-	TotalVotes  int                  `json:"total_votes,omitempty"`
-	CreatedAt   time.Time            `json:"created_at"`
-	ActivatedAt *time.Time           `json:"activated_at,omitempty"`
-	ClosedAt    *time.Time           `json:"closed_at,omitempty"`
-	Options     []PollOptionResponse `json:"options,omitempty"`
-	HasVoted    bool                 `json:"has_voted,omitempty"`
-	MyVoteID    *uint                `json:"my_vote_id,omitempty"`
+	ID               uint                 `json:"id"`
+	RoomID           uint                 `json:"room_id,omitempty"`
+	Question         string               `json:"question"`
+	Type             string               `json:"type"`
+	MaxSelections    int                  `json:"max_selections,omitempty"`
+	Status           string               `json:"status"`
+	TotalVotes       int                  `json:"total_votes,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	ScheduledAt      *time.Time           `json:"scheduled_at,omitempty"`
+	ActivatedAt      *time.Time           `json:"activated_at,omitempty"`
+	ClosedAt         *time.Time           `json:"closed_at,omitempty"`
+	Options          []PollOptionResponse `json:"options,omitempty"`
+	HasVoted         bool                 `json:"has_voted,omitempty"`
+	MyVoteID         *uint                `json:"my_vote_id,omitempty"`
+	MyVoteIDs        []uint               `json:"my_vote_ids,omitempty"` // multiple/ranked choice, urut sesuai ranking
+	MyAnswers        []string             `json:"my_answers,omitempty"`  // open_text
+	QuizID           *uint                `json:"quiz_id,omitempty"`
+	TimeLimit        int                  `json:"time_limit_seconds,omitempty"`
+	EndsAt           *time.Time           `json:"ends_at,omitempty"`            // activated_at + time_limit_seconds
+	CorrectOptionIDs []uint               `json:"correct_option_ids,omitempty"` // quiz draft, hanya untuk presenter
 }
 
 // CreatePollResponse response setelah membuat poll
@@ -88,18 +178,60 @@ type PollHistoryResponse struct {
 
 // PollResponseResponse response untuk single poll response (vote)
 type PollResponseResponse struct {
-	ID            uint      `json:"id"`
-	PollID        uint      `json:"poll_id"`
-	ParticipantID uint      `json:"participant_id"`
-	PollOptionID  uint      `json:"poll_option_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID                uint      `json:"id"`
+	PollID            uint      `json:"poll_id"`
+	ParticipantID     uint      `json:"participant_id"`
+	PollOptionID      uint      `json:"poll_option_id"`
+	SelectedOptionIDs []uint    `json:"selected_option_ids,omitempty"` // semua option yang dipilih (urut ranking)
+	Text              string    `json:"text,omitempty"`                // jawaban open_text
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// RankedChoiceTally jumlah suara satu option pada satu putaran instant-runoff
+type RankedChoiceTally struct {
+	OptionID uint `json:"option_id"`
+	Votes    int  `json:"votes"`
+}
+
+// RankedChoiceRound satu putaran instant-runoff
+type RankedChoiceRound struct {
+	Round      int                 `json:"round"`
+	Tallies    []RankedChoiceTally `json:"tallies"`
+	Exhausted  int                 `json:"exhausted"` // ballot yang semua pilihannya sudah tereliminasi
+	Eliminated []uint              `json:"eliminated,omitempty"`
+}
+
+// RankedChoiceResults hasil instant-runoff untuk ranked choice poll
+type RankedChoiceResults struct {
+	Rounds         []RankedChoiceRound `json:"rounds"`
+	WinnerOptionID *uint               `json:"winner_option_id,omitempty"`
+	Tied           []uint              `json:"tied,omitempty"` // option yang seri di putaran terakhir (tidak ada pemenang tunggal)
+}
+
+// PollTermResponse satu term word cloud beserta frekuensinya
+type PollTermResponse struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+// RatingResults ringkasan rating poll
+type RatingResults struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+	Scale   int     `json:"scale"`
 }
 
 // UpdatedPollResultsResponse response untuk updated poll results setelah vote
+// total_votes = jumlah participant yang vote, percentage option dihitung terhadap total_votes
 type UpdatedPollResultsResponse struct {
 	PollID     uint                 `json:"poll_id"`
+	RoomID     uint                 `json:"-"` // room poll, tujuan broadcast hasil
+	Type       string               `json:"type"`
 	TotalVotes int                  `json:"total_votes"`
 	Options    []PollOptionResponse `json:"options"`
+	Ranked     *RankedChoiceResults `json:"ranked,omitempty"`
+	Rating     *RatingResults       `json:"rating,omitempty"`
+	Terms      []PollTermResponse   `json:"terms,omitempty"`
 }
 
 // SubmitPollVoteResponse response setelah submit vote
@@ -107,12 +239,40 @@ type SubmitPollVoteResponse struct {
 	Response       PollResponseResponse       `json:"response"`
 	UpdatedResults UpdatedPollResultsResponse `json:"updated_results"`
 	XPEarned       *XPEarned                  `json:"xp_earned,omitempty"`
+	Quiz           *QuizAnswerResponse        `json:"quiz,omitempty"` // hanya untuk pertanyaan quiz
 }
 
 // FinalPollResultsResponse hasil akhir poll
 type FinalPollResultsResponse struct {
+	Type       string               `json:"type"`
 	TotalVotes int                  `json:"total_votes"`
 	Options    []PollOptionResponse `json:"options"`
+	Ranked     *RankedChoiceResults `json:"ranked,omitempty"`
+	Rating     *RatingResults       `json:"rating,omitempty"`
+	Terms      []PollTermResponse   `json:"terms,omitempty"`
+
+	CorrectOptionIDs []uint `json:"correct_option_ids,omitempty"` // quiz, baru dibuka saat poll ditutup
+}
+
+// PollAnswerResponse satu jawaban open-text poll (untuk presenter)
+type PollAnswerResponse struct {
+	ID            uint      `json:"id"`
+	ParticipantID uint      `json:"participant_id"`
+	Content       string    `json:"content"`
+	Normalized    string    `json:"normalized"`
+	IsHidden      bool      `json:"is_hidden"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ListPollAnswersResponse response list jawaban open-text poll
+type ListPollAnswersResponse struct {
+	Answers []PollAnswerResponse `json:"answers"`
+}
+
+// HidePollAnswerResponse response setelah hide / unhide jawaban
+type HidePollAnswerResponse struct {
+	Answer         PollAnswerResponse         `json:"answer"`
+	UpdatedResults UpdatedPollResultsResponse `json:"updated_results"`
 }
 
 // ClosePollResponse response setelah close poll
@@ -33,6 +33,31 @@ type ValidateQuestionRequest struct {
 	Status      string `json:"status" validate:"required,oneof=answered highlighted"`
 }
 
+// GetQuestionQueueRequest request untuk melihat antrian moderasi (owner dan moderator)
+type GetQuestionQueueRequest struct {
+	RoomID uint `json:"-" validate:"required,min=1"`
+	UserID uint `json:"-" validate:"required,min=1"`
+	Limit  int  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int  `json:"offset" validate:"omitempty,min=0"`
+}
+
+// ModerateQuestionRequest request untuk approve / reject / edit question di antrian moderasi
+type ModerateQuestionRequest struct {
+	QuestionID  uint   `json:"-" validate:"required,min=1"`
+	ModeratorID uint   `json:"-" validate:"required,min=1"`
+	Action      string `json:"action" validate:"required,oneof=approve reject edit"`
+	Content     string `json:"content" validate:"required_if=Action edit,omitempty,min=1,max=1000"` // wajib untuk edit, opsional untuk approve
+	Reason      string `json:"reason" validate:"omitempty,max=255"`                                 // alasan reject, dikirim ke author
+}
+
+// ReplyQuestionRequest request untuk membalas question (presenter, atau participant jika room mengizinkan)
+type ReplyQuestionRequest struct {
+	QuestionID    uint   `json:"-" validate:"required,min=1"`
+	ParticipantID uint   `json:"-" validate:"required,min=1"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	Content       string `json:"content" validate:"required,min=1,max=1000"`
+}
+
 // QuestionResponse response untuk single question
 type QuestionResponse struct {
 	ID                     uint            `json:"id"`
@@ -45,9 +70,21 @@ type QuestionResponse struct {
 	IsValidatedByPresenter bool            `json:"is_validated_by_presenter"`
 	XPAwarded              int             `json:"xp_awarded,omitempty"`
 	HasVoted               bool            `json:"has_voted,omitempty"`
+	ModerationStatus       string          `json:"moderation_status,omitempty"`
+	Replies                []ReplyResponse `json:"replies,omitempty"`
 	CreatedAt              time.Time       `json:"created_at"`
 }
 
+// ReplyResponse response untuk single reply question
+type ReplyResponse struct {
+	ID          uint            `json:"id"`
+	QuestionID  uint            `json:"question_id"`
+	Participant ParticipantInfo `json:"participant"`
+	Content     string          `json:"content"`
+	IsPresenter bool            `json:"is_presenter"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
 // SubmitQuestionResponse response setelah submit question
 type SubmitQuestionResponse struct {
 	Question QuestionResponse `json:"question"`
@@ -73,6 +110,20 @@ type QuestionPaging struct {
 	Offset int   `json:"offset"`
 }
 
+// QuestionQueueResponse response untuk antrian moderasi
+type QuestionQueueResponse struct {
+	Questions []QuestionResponse `json:"questions"`
+	Paging    QuestionPaging     `json:"paging"`
+}
+
+// ModerateQuestionResponse response setelah moderasi question
+type ModerateQuestionResponse struct {
+	Action   string           `json:"action"`
+	Question QuestionResponse `json:"question"`
+	Reason   string           `json:"reason,omitempty"`
+	XPEarned *XPEarned        `json:"xp_earned,omitempty"` // XP author saat approve
+}
+
 // VoteResponse response untuk vote
 type VoteResponse struct {
 	ID            uint      `json:"id"`
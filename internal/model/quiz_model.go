@@ -0,0 +1,87 @@
+package model
+
+import "time"
+
+// CreateQuizRequest request untuk membuat quiz baru di room (presenter only)
+type CreateQuizRequest struct {
+	RoomID      uint   `json:"-" validate:"required,min=1"`
+	PresenterID uint   `json:"-" validate:"required,min=1"`
+	Title       string `json:"title" validate:"required,min=3,max=255"`
+}
+
+// FinishQuizRequest request untuk mengakhiri quiz (presenter only)
+type FinishQuizRequest struct {
+	QuizID      uint `json:"-" validate:"required,min=1"`
+	PresenterID uint `json:"-" validate:"required,min=1"`
+}
+
+// GetQuizSummaryRequest request untuk mendapatkan ringkasan quiz
+type GetQuizSummaryRequest struct {
+	QuizID uint `json:"-" validate:"required,min=1"`
+	RoomID uint `json:"-" validate:"required,min=1"`
+}
+
+// QuizResponse response untuk single quiz
+type QuizResponse struct {
+	ID         uint       `json:"id"`
+	RoomID     uint       `json:"room_id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// QuizAnswerResponse hasil penilaian jawaban quiz untuk participant yang menjawab
+type QuizAnswerResponse struct {
+	IsCorrect  bool `json:"is_correct"`
+	ResponseMs int  `json:"response_ms"`
+	Points     int  `json:"points"`
+}
+
+// QuizQuestionStat agregat jawaban per pertanyaan quiz (hasil query)
+type QuizQuestionStat struct {
+	PollID         uint
+	TotalAnswers   int
+	CorrectAnswers int
+	AvgResponseMs  float64
+}
+
+// QuizParticipantStat agregat jawaban per participant (hasil query)
+type QuizParticipantStat struct {
+	ParticipantID  uint
+	DisplayName    string
+	TotalAnswers   int
+	CorrectAnswers int
+	Points         int
+	AvgResponseMs  float64
+}
+
+// QuizQuestionSummary akurasi satu pertanyaan quiz
+type QuizQuestionSummary struct {
+	PollID           uint    `json:"poll_id"`
+	Question         string  `json:"question"`
+	Status           string  `json:"status"`
+	TotalAnswers     int     `json:"total_answers"`
+	CorrectAnswers   int     `json:"correct_answers"`
+	Accuracy         float64 `json:"accuracy"` // persen jawaban benar
+	AvgResponseMs    int     `json:"avg_response_ms"`
+	CorrectOptionIDs []uint  `json:"correct_option_ids,omitempty"` // hanya jika pertanyaan sudah ditutup
+}
+
+// QuizRankingEntry posisi participant di ranking akhir quiz
+type QuizRankingEntry struct {
+	Rank           int    `json:"rank"`
+	ParticipantID  uint   `json:"participant_id"`
+	DisplayName    string `json:"display_name"`
+	CorrectAnswers int    `json:"correct_answers"`
+	TotalAnswers   int    `json:"total_answers"`
+	Points         int    `json:"points"`
+	AvgResponseMs  int    `json:"avg_response_ms"`
+}
+
+// QuizSummaryResponse ringkasan quiz: akurasi per pertanyaan dan ranking akhir
+type QuizSummaryResponse struct {
+	Quiz      QuizResponse          `json:"quiz"`
+	Questions []QuizQuestionSummary `json:"questions"`
+	Ranking   []QuizRankingEntry    `json:"ranking"`
+}
@@ -12,13 +12,15 @@ type CloseRoomRequest struct {
 }
 
 type RoomResponse struct {
-	ID          uint       `json:"id"`
-	RoomCode    string     `json:"room_code"`
-	Title       string     `json:"title"`
-	PresenterID uint       `json:"presenter_id"`
-	Status      string     `json:"status"`
-	CreatedAt   time.Time  `json:"created_at"`
-	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	ID                 uint       `json:"id"`
+	RoomCode           string     `json:"room_code"`
+	Title              string     `json:"title"`
+	PresenterID        uint       `json:"presenter_id"`
+	Status             string     `json:"status"`
+	QuestionModeration bool       `json:"question_moderation"`
+	ParticipantReplies bool       `json:"participant_replies"`
+	CreatedAt          time.Time  `json:"created_at"`
+	ClosedAt           *time.Time `json:"closed_at,omitempty"`
 }
 
 type CreateRoomResponse struct {
@@ -27,13 +29,15 @@ type CreateRoomResponse struct {
 }
 
 type RoomDetailResponse struct {
-	ID        uint          `json:"id"`
-	RoomCode  string        `json:"room_code"`
-	Title     string        `json:"title"`
-	Status    string        `json:"status"`
-	Presenter PresenterInfo `json:"presenter"`
-	Stats     RoomStats     `json:"stats"`
-	CreatedAt time.Time     `json:"created_at"`
+	ID                 uint          `json:"id"`
+	RoomCode           string        `json:"room_code"`
+	Title              string        `json:"title"`
+	Status             string        `json:"status"`
+	QuestionModeration bool          `json:"question_moderation"`
+	ParticipantReplies bool          `json:"participant_replies"`
+	Presenter          PresenterInfo `json:"presenter"`
+	Stats              RoomStats     `json:"stats"`
+	CreatedAt          time.Time     `json:"created_at"`
 }
 
 type PresenterInfo struct {
@@ -93,3 +97,50 @@ type SendAnnouncementRequest struct {
 	RoomID      uint   `json:"-" validate:"required,min=1"`
 	Message     string `json:"message" validate:"required,min=1,max=1000"`
 }
+
+// UpdateRoomSettingsRequest request untuk mengubah pengaturan room (presenter only), minimal satu field diisi
+type UpdateRoomSettingsRequest struct {
+	PresenterID        uint  `json:"-" validate:"required,min=1"`
+	RoomID             uint  `json:"-" validate:"required,min=1"`
+	QuestionModeration *bool `json:"question_moderation" validate:"required_without=ParticipantReplies"`
+	ParticipantReplies *bool `json:"participant_replies" validate:"required_without=QuestionModeration"`
+}
+
+// RoomSettingsResponse pengaturan room
+type RoomSettingsResponse struct {
+	RoomID             uint `json:"room_id"`
+	QuestionModeration bool `json:"question_moderation"`
+	ParticipantReplies bool `json:"participant_replies"`
+}
+
+// AddModeratorRequest request untuk menunjuk participant (user terdaftar) sebagai moderator
+type AddModeratorRequest struct {
+	PresenterID   uint `json:"-" validate:"required,min=1"`
+	RoomID        uint `json:"-" validate:"required,min=1"`
+	ParticipantID uint `json:"participant_id" validate:"required,min=1"`
+}
+
+// RemoveModeratorRequest request untuk mencabut moderator
+type RemoveModeratorRequest struct {
+	PresenterID uint `json:"-" validate:"required,min=1"`
+	RoomID      uint `json:"-" validate:"required,min=1"`
+	UserID      uint `json:"-" validate:"required,min=1"`
+}
+
+// ListModeratorsRequest request untuk daftar moderator room
+type ListModeratorsRequest struct {
+	PresenterID uint `json:"-" validate:"required,min=1"`
+	RoomID      uint `json:"-" validate:"required,min=1"`
+}
+
+// ModeratorResponse moderator room
+type ModeratorResponse struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ModeratorListResponse daftar moderator room
+type ModeratorListResponse struct {
+	Moderators []ModeratorResponse `json:"moderators"`
+}
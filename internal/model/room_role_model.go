@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// Role user di dalam room
+const (
+	RoomRoleOwner       = "owner"
+	RoomRoleCoHost      = "co_host"
+	RoomRoleModerator   = "moderator"
+	RoomRoleParticipant = "participant"
+)
+
+// RoomRoleCanHost owner dan co-host boleh menjalankan sesi (poll, quiz, announcement, conference)
+func RoomRoleCanHost(role string) bool {
+	return role == RoomRoleOwner || role == RoomRoleCoHost
+}
+
+// RoomRoleCanModerate owner, co-host dan moderator boleh memoderasi dan memvalidasi Q&A
+func RoomRoleCanModerate(role string) bool {
+	return RoomRoleCanHost(role) || role == RoomRoleModerator
+}
+
+// GrantRoomRoleRequest request untuk memberi role ke participant (user terdaftar) di room
+type GrantRoomRoleRequest struct {
+	ActorID       uint   `json:"-" validate:"required,min=1"`
+	RoomID        uint   `json:"-" validate:"required,min=1"`
+	ParticipantID uint   `json:"participant_id" validate:"required,min=1"`
+	Role          string `json:"role" validate:"required,oneof=co_host moderator"`
+}
+
+// InviteRoomRoleRequest request untuk memberi role ke user yang belum join room
+type InviteRoomRoleRequest struct {
+	ActorID  uint   `json:"-" validate:"required,min=1"`
+	RoomID   uint   `json:"-" validate:"required,min=1"`
+	Username string `json:"username" validate:"required,min=3,max=30,alphanum"`
+	Role     string `json:"role" validate:"required,oneof=co_host moderator"`
+}
+
+// RevokeRoomRoleRequest request untuk mencabut role user di room.
+// Role diisi jika hanya role tertentu yang boleh dicabut (endpoint moderator).
+type RevokeRoomRoleRequest struct {
+	ActorID uint   `json:"-" validate:"required,min=1"`
+	RoomID  uint   `json:"-" validate:"required,min=1"`
+	UserID  uint   `json:"-" validate:"required,min=1"`
+	Role    string `json:"-" validate:"omitempty,oneof=co_host moderator"`
+}
+
+// ListRoomRolesRequest request untuk daftar role room, role kosong berarti semua
+type ListRoomRolesRequest struct {
+	ActorID uint   `json:"-" validate:"required,min=1"`
+	RoomID  uint   `json:"-" validate:"required,min=1"`
+	Role    string `json:"-" validate:"omitempty,oneof=co_host moderator"`
+}
+
+// RoomRoleResponse role user di room
+type RoomRoleResponse struct {
+	UserID        uint      `json:"user_id"`
+	Username      string    `json:"username"`
+	Role          string    `json:"role"`
+	ParticipantID *uint     `json:"participant_id,omitempty"` // nil jika user belum join (invite)
+	GrantedBy     *uint     `json:"granted_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RoomRoleListResponse daftar role room
+type RoomRoleListResponse struct {
+	Roles []RoomRoleResponse `json:"roles"`
+}
+
+// RoomRoleChangeResponse hasil grant / revoke, juga dipakai sebagai payload websocket room:role_updated
+type RoomRoleChangeResponse struct {
+	RoomID        uint   `json:"room_id"`
+	UserID        uint   `json:"user_id"`
+	Role          string `json:"role"`                     // role baru, participant jika dicabut
+	ParticipantID *uint  `json:"participant_id,omitempty"` // participant user di room, jika sudah join
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// SessionClient device yang membuat atau memakai session, diisi controller dari request
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionInfo data session login yang tersimpan di Redis
+type SessionInfo struct {
+	ID            string
+	UserID        *uint
+	ParticipantID *uint
+	RoomID        *uint
+	Device        string
+	UserAgent     string
+	IP            string
+	CreatedAt     time.Time
+	LastActiveAt  time.Time
+}
+
+// ListSessionsRequest request untuk list session login milik user
+type ListSessionsRequest struct {
+	UserID    uint   `json:"-" validate:"required,min=1"`
+	SessionID string `json:"-" validate:"max=64"` // session saat ini, ditandai current
+}
+
+// RevokeSessionRequest request untuk mencabut satu session milik user
+type RevokeSessionRequest struct {
+	UserID    uint   `json:"-" validate:"required,min=1"`
+	SessionID string `json:"-" validate:"required,len=32,hexadecimal"`
+}
+
+// SessionResponse response session login
+type SessionResponse struct {
+	ID           string    `json:"id"`
+	Device       string    `json:"device"`
+	UserAgent    string    `json:"user_agent"`
+	IP           string    `json:"ip"`
+	RoomID       *uint     `json:"room_id"`
+	Current      bool      `json:"current"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+// SessionListResponse response list session login
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
@@ -3,16 +3,41 @@ package model
 import "time"
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              uint       `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Role            string     `json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
 }
 
 type AuthResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"-"` // token is set as HTTP-only cookie, not returned in body
+	User         UserResponse `json:"user"`
+	Token        string       `json:"-"` // token is set as HTTP-only cookie, not returned in body
+	RefreshToken string       `json:"-"` // refresh token is set as HTTP-only cookie, empty if session is reused
+}
+
+// TokenPair access token pendek dan refresh token session
+type TokenPair struct {
+	AccessToken     string
+	RefreshToken    string // kosong jika session yang sudah ada dipakai ulang
+	AccessExpiresAt time.Time
+}
+
+// RefreshTokenRequest refresh token dari cookie atau body
+type RefreshTokenRequest struct {
+	RefreshToken string         `json:"refresh_token" validate:"required,max=200"`
+	Client       *SessionClient `json:"-"` // device yang refresh, diisi controller
+}
+
+// LogoutRequest logout session saat ini
+type LogoutRequest struct {
+	SessionID string `validate:"required,max=64"`
+}
+
+// LogoutAllRequest logout dari semua session user
+type LogoutAllRequest struct {
+	UserID uint `validate:"required,min=1"`
 }
 
 type RegisterUserRequest struct {
@@ -20,16 +45,24 @@ type RegisterUserRequest struct {
 	Email    string `json:"email" validate:"required,email,max=255"`
 	Password string `json:"password" validate:"required,min=8,max=100"`
 	Role     string `json:"role" validate:"required,oneof=presenter admin"`
+
+	Client *SessionClient `json:"-"` // device session login, diisi controller
 }
 
 type LoginUserRequest struct {
 	Username string `json:"username" validate:"required,max=100"`
 	Password string `json:"password" validate:"required,max=100"`
+
+	Client *SessionClient `json:"-"` // device session login, diisi controller
 }
 
 type AnonymousUserRequest struct {
 	RoomCode    string `json:"room_code" validate:"required,len=6,alphanum"`
 	DisplayName string `json:"display_name" validate:"required,min=3,max=30"`
+	DeviceID    string `json:"device_id" validate:"omitempty,max=128"` // id device dari client, opsional
+	Fingerprint string `json:"-" validate:"omitempty,len=64"`          // hash device, diisi controller
+
+	Client *SessionClient `json:"-"` // device session login, diisi controller
 }
 
 type VerifyUserRequest struct {
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status webhook delivery
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+// WebhookEvents event room yang bisa dipilih webhook (sama dengan nama event websocket).
+// Daftar ini harus sama dengan tag oneof di CreateWebhookRequest dan UpdateWebhookRequest.
+var WebhookEvents = []string{
+	"room:closed", "room:announce", "room:user_joined", "room:user_left",
+	"question:created", "question:upvoted", "question:validated", "question:replied",
+	"poll:created", "poll:closed", "quiz:summary",
+	"message:new", "message:updated", "message:deleted",
+	"participant:kicked", "participant:banned",
+}
+
+// IsWebhookEvent cek apakah event bisa dikirim ke webhook
+func IsWebhookEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhookRequest request untuk mendaftarkan webhook, RoomID kosong berarti semua room milik user
+type CreateWebhookRequest struct {
+	UserID uint     `json:"-" validate:"required,min=1"`
+	RoomID *uint    `json:"room_id" validate:"omitempty,min=1"`
+	URL    string   `json:"url" validate:"required,url,startswith=http,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,max=20,dive,oneof=room:closed room:announce room:user_joined room:user_left question:created question:upvoted question:validated question:replied poll:created poll:closed quiz:summary message:new message:updated message:deleted participant:kicked participant:banned"`
+}
+
+// UpdateWebhookRequest request untuk mengubah webhook, field nil tidak diubah
+type UpdateWebhookRequest struct {
+	UserID    uint     `json:"-" validate:"required,min=1"`
+	WebhookID uint     `json:"-" validate:"required,min=1"`
+	URL       *string  `json:"url" validate:"omitempty,url,startswith=http,max=2048"`
+	Events    []string `json:"events" validate:"omitempty,min=1,max=20,dive,oneof=room:closed room:announce room:user_joined room:user_left question:created question:upvoted question:validated question:replied poll:created poll:closed quiz:summary message:new message:updated message:deleted participant:kicked participant:banned"`
+	IsActive  *bool    `json:"is_active"`
+}
+
+// GetWebhookRequest request untuk get / delete webhook
+type GetWebhookRequest struct {
+	UserID    uint `json:"-" validate:"required,min=1"`
+	WebhookID uint `json:"-" validate:"required,min=1"`
+}
+
+// ListWebhooksRequest request untuk list webhook milik user
+type ListWebhooksRequest struct {
+	UserID uint `json:"-" validate:"required,min=1"`
+}
+
+// ListWebhookDeliveriesRequest request untuk delivery log webhook
+type ListWebhookDeliveriesRequest struct {
+	UserID    uint   `json:"-" validate:"required,min=1"`
+	WebhookID uint   `json:"-" validate:"required,min=1"`
+	Status    string `json:"-" validate:"omitempty,oneof=pending success failed"`
+	Limit     int    `json:"-" validate:"omitempty,min=1,max=100"`
+}
+
+// ReplayWebhookDeliveryRequest request untuk mengirim ulang delivery
+type ReplayWebhookDeliveryRequest struct {
+	UserID     uint `json:"-" validate:"required,min=1"`
+	WebhookID  uint `json:"-" validate:"required,min=1"`
+	DeliveryID uint `json:"-" validate:"required,min=1"`
+}
+
+// WebhookResponse response webhook, Secret hanya diisi saat create
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	RoomID    *uint     `json:"room_id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookListResponse response list webhook
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveryResponse response satu delivery
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	WebhookID      uint            `json:"webhook_id"`
+	RoomID         uint            `json:"room_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	ReplayOf       *uint           `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryListResponse response delivery log
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// WebhookPayload body JSON yang dikirim ke webhook
+type WebhookPayload struct {
+	ID        string          `json:"id"` // id event, sama untuk semua webhook dan saat replay
+	Event     string          `json:"event"`
+	RoomID    uint            `json:"room_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
@@ -29,9 +29,9 @@ func (r *ActivityRepository) GetTimelineRaw(db *gorm.DB, roomID uint, before *ti
 	query := `
 		SELECT 'message' as type, id, created_at FROM messages WHERE room_id = ? AND deleted_at IS NULL
 		UNION ALL
-		SELECT 'question' as type, id, created_at FROM questions WHERE room_id = ?
+		SELECT 'question' as type, id, created_at FROM questions WHERE room_id = ? AND moderation_status = 'approved'
 		UNION ALL
-		SELECT 'poll' as type, id, created_at FROM polls WHERE room_id = ?
+		SELECT 'poll' as type, id, created_at FROM polls WHERE room_id = ? AND status <> 'draft'
 	`
 	args := []interface{}{roomID, roomID, roomID}
 
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"reisify/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepository query agregat untuk analytics room
+type AnalyticsRepository struct {
+	Log *logrus.Logger
+}
+
+// NewAnalyticsRepository create new instance of AnalyticsRepository
+func NewAnalyticsRepository(log *logrus.Logger) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		Log: log,
+	}
+}
+
+// GetMessageBuckets jumlah chat message (tidak dihapus) per bucket waktu
+func (r *AnalyticsRepository) GetMessageBuckets(db *gorm.DB, roomID uint, bucketSeconds int) ([]model.TimeBucketCount, error) {
+	return r.countByBucket(db, "messages", "room_id = ? AND deleted_at IS NULL", roomID, bucketSeconds)
+}
+
+// GetQuestionBuckets jumlah question approved per bucket waktu
+func (r *AnalyticsRepository) GetQuestionBuckets(db *gorm.DB, roomID uint, bucketSeconds int) ([]model.TimeBucketCount, error) {
+	return r.countByBucket(db, "questions", "room_id = ? AND moderation_status = 'approved'", roomID, bucketSeconds)
+}
+
+// countByBucket group by FLOOR(epoch / bucketSeconds), bucket dikembalikan sebagai index sejak epoch
+func (r *AnalyticsRepository) countByBucket(db *gorm.DB, table, where string, roomID uint, bucketSeconds int) ([]model.TimeBucketCount, error) {
+	var buckets []model.TimeBucketCount
+	err := db.Table(table).
+		Select("FLOOR(EXTRACT(EPOCH FROM created_at) / ?)::bigint AS bucket, COUNT(*) AS count", bucketSeconds).
+		Where(where, roomID).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&buckets).Error
+	if err != nil {
+		r.Log.WithField("error", err).Errorf("countByBucket - failed to query %s", table)
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// GetQuestionStats total question approved, yang sudah dijawab dan total upvote
+func (r *AnalyticsRepository) GetQuestionStats(db *gorm.DB, roomID uint) (*model.QuestionStats, error) {
+	var stats model.QuestionStats
+	err := db.Table("questions").
+		Select("COUNT(*) AS total, COALESCE(SUM(CASE WHEN status = 'answered' THEN 1 ELSE 0 END), 0) AS answered, COALESCE(SUM(upvote_count), 0) AS upvotes").
+		Where("room_id = ? AND moderation_status = 'approved'", roomID).
+		Scan(&stats).Error
+	return &stats, err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository repository untuk operasi database APIKey
+type APIKeyRepository struct {
+	Repository[entity.APIKey]
+	Log *logrus.Logger
+}
+
+// NewAPIKeyRepository create new instance of APIKeyRepository
+func NewAPIKeyRepository(log *logrus.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		Log: log,
+	}
+}
+
+// FindByKeyHash cari API key berdasarkan hash, nil jika tidak ada
+func (r *APIKeyRepository) FindByKeyHash(db *gorm.DB, keyHash string) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	err := db.Where("key_hash = ?", keyHash).First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// FindByIdAndUserID cari API key milik user, nil jika tidak ada
+func (r *APIKeyRepository) FindByIdAndUserID(db *gorm.DB, id, userID uint) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	err := db.Where("id = ? AND user_id = ?", id, userID).First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// ListByUserID daftar API key milik user, termasuk yang sudah dicabut
+func (r *APIKeyRepository) ListByUserID(db *gorm.DB, userID uint) ([]entity.APIKey, error) {
+	var apiKeys []entity.APIKey
+	err := db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// CountActiveByUserID jumlah API key user yang belum dicabut dan belum kedaluwarsa
+func (r *APIKeyRepository) CountActiveByUserID(db *gorm.DB, userID uint, now time.Time) (int64, error) {
+	var total int64
+	err := db.Model(&entity.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Count(&total).Error
+	return total, err
+}
+
+// Revoke set revoked_at jika belum dicabut
+func (r *APIKeyRepository) Revoke(db *gorm.DB, id uint, now time.Time) error {
+	return db.Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", now).Error
+}
+
+// TouchLastUsed update last_used_at / last_used_ip, dilewati jika sudah diupdate setelah since dari IP yang sama
+// supaya tidak ada write di setiap request
+func (r *APIKeyRepository) TouchLastUsed(db *gorm.DB, id uint, ip string, now, since time.Time) error {
+	return db.Model(&entity.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ? OR last_used_ip <> ?)", id, since, ip).
+		Updates(map[string]interface{}{"last_used_at": now, "last_used_ip": ip}).Error
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"errors"
 	"reisify/internal/entity"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -59,3 +61,33 @@ func (r *MessageRepository) CountByRoomID(db *gorm.DB, roomID uint) (int64, erro
 	err := db.Model(entity.Message{}).Where("room_id = ?", roomID).Count(&count).Error
 	return count, err
 }
+
+// FindByIdWithParticipant mencari message (yang belum dihapus) beserta participant
+func (r *MessageRepository) FindByIdWithParticipant(db *gorm.DB, id uint) (*entity.Message, error) {
+	var message entity.Message
+	err := db.Preload("Participant").Where("id = ?", id).Take(&message).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &message, err
+}
+
+// UpdateContent mengubah isi message dan menandai waktu edit
+func (r *MessageRepository) UpdateContent(db *gorm.DB, message *entity.Message) error {
+	return db.Model(message).Updates(map[string]interface{}{
+		"content":   message.Content,
+		"edited_at": message.EditedAt,
+	}).Error
+}
+
+// SoftDelete menandai message sebagai terhapus oleh participant tertentu, mengembalikan jumlah row yang berubah
+// (0 jika message sudah dihapus lebih dulu)
+func (r *MessageRepository) SoftDelete(db *gorm.DB, message *entity.Message, deletedBy uint) (int64, error) {
+	result := db.Model(&entity.Message{}).
+		Where("id = ? AND deleted_at IS NULL", message.ID).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"deleted_by": deletedBy,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"errors"
 	"reisify/internal/entity"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ParticipantRepository struct {
@@ -29,6 +31,20 @@ func (r *ParticipantRepository) FindByRoomIDAndUserID(db *gorm.DB, roomID uint,
 	return &participant, err
 }
 
+// LockForUpdate kunci row participant sampai transaksi selesai, dipakai untuk memproses aksi participant
+// yang sama secara berurutan (misal cek "sudah vote" lalu insert response)
+func (r *ParticipantRepository) LockForUpdate(db *gorm.DB, participantID uint) error {
+	var participant entity.Participant
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", participantID).
+		Take(&participant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
 // List mencari participant berdasarkan room ID dengan pagination
 func (r *ParticipantRepository) List(db *gorm.DB, roomID uint, page int, size int) ([]entity.Participant, int64, error) {
 	var participants []entity.Participant
@@ -46,6 +62,16 @@ func (r *ParticipantRepository) List(db *gorm.DB, roomID uint, page int, size in
 	return participants, total, nil
 }
 
+// ListByIDsInRoom mencari participant room berdasarkan daftar ID, urut berdasarkan ID
+func (r *ParticipantRepository) ListByIDsInRoom(db *gorm.DB, roomID uint, participantIDs []uint) ([]entity.Participant, error) {
+	var participants []entity.Participant
+	if len(participantIDs) == 0 {
+		return participants, nil
+	}
+	err := db.Where("room_id = ? AND id IN ?", roomID, participantIDs).Order("id ASC").Find(&participants).Error
+	return participants, err
+}
+
 // FindParticipantInRoom mencari participant berdasarkan room ID dan participant ID
 func (r *ParticipantRepository) FindParticipantInRoom(db *gorm.DB, roomID uint, participantID uint) (*entity.Participant, error) {
 	var participant entity.Participant
@@ -69,6 +95,13 @@ func (r *ParticipantRepository) ListLeaderboard(db *gorm.DB, roomID uint) ([]ent
 	return leaderboard, nil
 }
 
+// ListRanking mengambil seluruh participant room, diurutkan berdasarkan poin tertinggi (untuk export)
+func (r *ParticipantRepository) ListRanking(db *gorm.DB, roomID uint) ([]entity.Participant, error) {
+	var participants []entity.Participant
+	err := db.Where("room_id = ?", roomID).Order("xp_score DESC, id ASC").Find(&participants).Error
+	return participants, err
+}
+
 // GetRankAndScoreByParticipantID mengambil peringkat dan skor peserta dalam sebuah room
 func (r *ParticipantRepository) GetRankAndScoreByParticipantID(db *gorm.DB, roomID, participantID uint) (int64, int64, error) {
 	// get xp score of the participant
@@ -97,3 +130,44 @@ func (r *ParticipantRepository) CountByRoomID(db *gorm.DB, roomID uint) (int64,
 	err := db.Model(entity.Participant{}).Where("room_id = ?", roomID).Count(&count).Error
 	return count, err
 }
+
+// IsMuted cek apakah participant sedang di-mute
+func (r *ParticipantRepository) IsMuted(db *gorm.DB, participantID uint) (bool, error) {
+	var count int64
+	err := db.Model(entity.Participant{}).
+		Where("id = ? AND muted_until > ?", participantID, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateMutedUntil set atau hapus (nil) batas waktu mute participant
+func (r *ParticipantRepository) UpdateMutedUntil(db *gorm.DB, participantID uint, mutedUntil *time.Time) error {
+	return db.Model(entity.Participant{}).Where("id = ?", participantID).Update("muted_until", mutedUntil).Error
+}
+
+// ListByRoomIDs id dan room participant di beberapa room
+func (r *ParticipantRepository) ListByRoomIDs(db *gorm.DB, roomIDs []uint) ([]entity.Participant, error) {
+	var participants []entity.Participant
+	if len(roomIDs) == 0 {
+		return participants, nil
+	}
+	err := db.Select("id", "room_id").Where("room_id IN ?", roomIDs).Find(&participants).Error
+	return participants, err
+}
+
+// ListIDsByUserID id semua participant milik user di semua room
+func (r *ParticipantRepository) ListIDsByUserID(db *gorm.DB, userID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Model(&entity.Participant{}).Where("user_id = ?", userID).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// AnonymizeByUserID lepaskan participant dari user dan hapus data identitasnya (hapus akun)
+func (r *ParticipantRepository) AnonymizeByUserID(db *gorm.DB, userID uint, displayName string) error {
+	return db.Model(&entity.Participant{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"user_id":      nil,
+		"display_name": displayName,
+		"is_anonymous": true,
+		"fingerprint":  nil,
+	}).Error
+}
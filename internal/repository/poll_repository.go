@@ -3,9 +3,12 @@ package repository
 import (
 	"errors"
 	"reisify/internal/entity"
+	"reisify/internal/model"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PollRepository struct {
@@ -44,12 +47,17 @@ func (r *PollRepository) GetActivePollsByRoomID(db *gorm.DB, roomID uint) ([]ent
 }
 
 // GetPollsByRoomID retrieves polls by room ID with optional status filter
-func (r *PollRepository) GetPollsByRoomID(db *gorm.DB, roomID uint, status string, limit int) ([]entity.Poll, int64, error) {
+// draft hanya diikutkan jika includeDrafts true (presenter)
+func (r *PollRepository) GetPollsByRoomID(db *gorm.DB, roomID uint, status string, limit int, includeDrafts bool) ([]entity.Poll, int64, error) {
 	var polls []entity.Poll
 	var total int64
 
 	query := db.Model(&entity.Poll{}).Where("room_id = ?", roomID)
 
+	if !includeDrafts {
+		query = query.Where("status <> ?", "draft")
+	}
+
 	if status != "" && status != "all" {
 		query = query.Where("status = ?", status)
 	}
@@ -83,12 +91,98 @@ func (r *PollRepository) GetPollByIDWithOptions(db *gorm.DB, pollID uint) (*enti
 	return &poll, err
 }
 
+// GetPollByIDForUpdate retrieves a poll by ID with its options and locks the poll row
+func (r *PollRepository) GetPollByIDForUpdate(db *gorm.DB, pollID uint) (*entity.Poll, error) {
+	var poll entity.Poll
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", pollID).
+		First(&poll).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Where("poll_id = ?", pollID).Order("\"order\" ASC").Find(&poll.Options).Error
+	return &poll, err
+}
+
+// GetDueScheduledPollIDs retrieves draft polls yang jadwal aktivasinya sudah lewat di room yang masih aktif.
+// Row yang sedang dikunci node lain di-skip supaya beberapa scheduler bisa jalan bersamaan.
+func (r *PollRepository) GetDueScheduledPollIDs(db *gorm.DB, now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := db.Model(&entity.Poll{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND scheduled_at IS NOT NULL AND scheduled_at <= ?", "draft", now).
+		Where("room_id IN (SELECT id FROM rooms WHERE status = ?)", "active").
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ActivatePoll updates draft poll status to active dan hapus jadwal aktivasi
+func (r *PollRepository) ActivatePoll(db *gorm.DB, poll *entity.Poll) error {
+	return db.Model(poll).Updates(map[string]interface{}{
+		"status":       "active",
+		"activated_at": gorm.Expr("NOW()"),
+		"scheduled_at": nil,
+	}).Error
+}
+
+// UnschedulePoll hapus jadwal aktivasi draft poll
+func (r *PollRepository) UnschedulePoll(db *gorm.DB, pollID uint) error {
+	return db.Model(&entity.Poll{}).Where("id = ?", pollID).Update("scheduled_at", nil).Error
+}
+
+// UpdateDraftPoll updates editable fields of a draft poll
+func (r *PollRepository) UpdateDraftPoll(db *gorm.DB, poll *entity.Poll) error {
+	return db.Model(poll).Updates(map[string]interface{}{
+		"question":           poll.Question,
+		"max_selections":     poll.MaxSelections,
+		"time_limit_seconds": poll.TimeLimitSeconds,
+		"scheduled_at":       poll.ScheduledAt,
+	}).Error
+}
+
+// ReplacePollOptions hapus semua option poll lalu buat ulang (hanya untuk draft, belum ada response)
+func (r *PollRepository) ReplacePollOptions(db *gorm.DB, poll *entity.Poll, options []entity.PollOption) error {
+	if err := db.Where("poll_id = ?", poll.ID).Delete(&entity.PollOption{}).Error; err != nil {
+		return err
+	}
+
+	poll.Options = options
+	if len(options) == 0 {
+		return nil
+	}
+
+	for i := range options {
+		options[i].PollID = poll.ID
+		options[i].Order = i + 1
+	}
+	return db.Create(&options).Error
+}
+
+// UpdateOptionOrder updates display order of a poll option
+func (r *PollRepository) UpdateOptionOrder(db *gorm.DB, optionID uint, order int) error {
+	return db.Model(&entity.PollOption{}).
+		Where("id = ?", optionID).
+		Update("order", order).Error
+}
+
 // CreatePollWithOptions creates a poll with its options in a single transaction
 func (r *PollRepository) CreatePollWithOptions(db *gorm.DB, poll *entity.Poll, options []entity.PollOption) error {
 	if err := db.Create(poll).Error; err != nil {
 		return err
 	}
 
+	// open-text poll tidak punya option
+	if len(options) == 0 {
+		poll.Options = options
+		return nil
+	}
+
 	for i := range options {
 		options[i].PollID = poll.ID
 		options[i].Order = i + 1
@@ -122,11 +216,49 @@ func (r *PollRepository) GetPollResponseByParticipant(db *gorm.DB, pollID, parti
 	return &response, err
 }
 
+// GetPollResponsesByParticipant retrieves all of a participant's responses for a poll ordered by rank
+func (r *PollRepository) GetPollResponsesByParticipant(db *gorm.DB, pollID, participantID uint) ([]entity.PollResponse, error) {
+	var responses []entity.PollResponse
+	err := db.Where("poll_id = ? AND participant_id = ?", pollID, participantID).
+		Order("rank ASC, id ASC").
+		Find(&responses).Error
+	return responses, err
+}
+
 // CreatePollResponse creates a new poll response (vote)
 func (r *PollRepository) CreatePollResponse(db *gorm.DB, response *entity.PollResponse) error {
 	return db.Create(response).Error
 }
 
+// CreatePollResponses creates several poll responses (multiple / ranked choice vote)
+func (r *PollRepository) CreatePollResponses(db *gorm.DB, responses []entity.PollResponse) error {
+	return db.Create(&responses).Error
+}
+
+// GetRankedBallots returns every participant's ranking for a ranked choice poll,
+// each ballot is a list of option IDs ordered by preference
+func (r *PollRepository) GetRankedBallots(db *gorm.DB, pollID uint) ([][]uint, error) {
+	var rows []entity.PollResponse
+	err := db.Select("participant_id", "poll_option_id", "rank").
+		Where("poll_id = ?", pollID).
+		Order("participant_id ASC, rank ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ballots := make([][]uint, 0)
+	var current uint
+	for i, row := range rows {
+		if i == 0 || row.ParticipantID != current {
+			ballots = append(ballots, []uint{})
+			current = row.ParticipantID
+		}
+		ballots[len(ballots)-1] = append(ballots[len(ballots)-1], row.PollOptionID)
+	}
+	return ballots, nil
+}
+
 // IncrementOptionVoteCount increments the vote count for a poll option
 func (r *PollRepository) IncrementOptionVoteCount(db *gorm.DB, optionID uint) error {
 	return db.Model(&entity.PollOption{}).
@@ -134,11 +266,94 @@ func (r *PollRepository) IncrementOptionVoteCount(db *gorm.DB, optionID uint) er
 		UpdateColumn("vote_count", gorm.Expr("vote_count + ?", 1)).Error
 }
 
-// GetTotalVotesByPollID returns the total number of votes for a poll
+// GetTotalVotesByPollID returns the number of participants who voted on a poll
 func (r *PollRepository) GetTotalVotesByPollID(db *gorm.DB, pollID uint) (int, error) {
-	var totalVotes int64
-	err := db.Model(&entity.PollResponse{}).Where("poll_id = ?", pollID).Count(&totalVotes).Error
-	return int(totalVotes), err
+	totals, err := r.GetTotalVotesByPollIDs(db, []uint{pollID})
+	if err != nil {
+		return 0, err
+	}
+	return totals[pollID], nil
+}
+
+// GetTotalVotesByPollIDs returns the number of participants who voted per poll
+func (r *PollRepository) GetTotalVotesByPollIDs(db *gorm.DB, pollIDs []uint) (map[uint]int, error) {
+	var rows []struct {
+		PollID uint
+		Total  int
+	}
+	result := make(map[uint]int)
+	if len(pollIDs) == 0 {
+		return result, nil
+	}
+
+	// option based polls dan open-text polls disimpan di tabel berbeda, satu poll hanya punya salah satunya
+	for _, table := range []interface{}{&entity.PollResponse{}, &entity.PollTextResponse{}} {
+		rows = rows[:0]
+		err := db.Model(table).
+			Select("poll_id, COUNT(DISTINCT participant_id) AS total").
+			Where("poll_id IN ?", pollIDs).
+			Group("poll_id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			result[row.PollID] += row.Total
+		}
+	}
+	return result, nil
+}
+
+// CreateTextResponse creates a new open-text answer
+func (r *PollRepository) CreateTextResponse(db *gorm.DB, response *entity.PollTextResponse) error {
+	return db.Create(response).Error
+}
+
+// GetTextResponsesByParticipant retrieves a participant's open-text answers for a poll
+func (r *PollRepository) GetTextResponsesByParticipant(db *gorm.DB, pollID, participantID uint) ([]entity.PollTextResponse, error) {
+	var responses []entity.PollTextResponse
+	err := db.Where("poll_id = ? AND participant_id = ?", pollID, participantID).
+		Order("id ASC").
+		Find(&responses).Error
+	return responses, err
+}
+
+// GetTextResponsesByPollID retrieves all open-text answers for a poll, newest first
+func (r *PollRepository) GetTextResponsesByPollID(db *gorm.DB, pollID uint) ([]entity.PollTextResponse, error) {
+	var responses []entity.PollTextResponse
+	err := db.Where("poll_id = ?", pollID).
+		Order("created_at DESC, id DESC").
+		Find(&responses).Error
+	return responses, err
+}
+
+// GetTextResponseByID retrieves an open-text answer by ID
+func (r *PollRepository) GetTextResponseByID(db *gorm.DB, answerID uint) (*entity.PollTextResponse, error) {
+	var response entity.PollTextResponse
+	err := db.Where("id = ?", answerID).First(&response).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &response, err
+}
+
+// SetTextResponseHidden updates the hidden flag of an open-text answer
+func (r *PollRepository) SetTextResponseHidden(db *gorm.DB, response *entity.PollTextResponse, hidden bool) error {
+	return db.Model(response).Update("is_hidden", hidden).Error
+}
+
+// GetTermFrequencies returns the most frequent normalized terms of visible open-text answers
+func (r *PollRepository) GetTermFrequencies(db *gorm.DB, pollID uint, limit int) ([]model.PollTermResponse, error) {
+	var terms []model.PollTermResponse
+	err := db.Model(&entity.PollTextResponse{}).
+		Select("normalized AS term, COUNT(*) AS count").
+		Where("poll_id = ? AND is_hidden = ? AND normalized <> ''", pollID, false).
+		Group("normalized").
+		Order("count DESC, term ASC").
+		Limit(limit).
+		Scan(&terms).Error
+	return terms, err
 }
 
 // ClosePoll updates poll status to closed
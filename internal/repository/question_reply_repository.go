@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"reisify/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// QuestionReplyRepository repository untuk operasi database QuestionReply
+type QuestionReplyRepository struct {
+	Repository[entity.QuestionReply]
+	Log *logrus.Logger
+}
+
+// NewQuestionReplyRepository create new instance of QuestionReplyRepository
+func NewQuestionReplyRepository(log *logrus.Logger) *QuestionReplyRepository {
+	return &QuestionReplyRepository{
+		Log: log,
+	}
+}
+
+// ListByQuestionIDs ambil semua reply untuk beberapa question sekaligus, urut dari yang terlama
+func (r *QuestionReplyRepository) ListByQuestionIDs(db *gorm.DB, questionIDs []uint) ([]entity.QuestionReply, error) {
+	var replies []entity.QuestionReply
+	err := db.Preload("Participant").
+		Where("question_id IN ?", questionIDs).
+		Order("created_at ASC, id ASC").
+		Find(&replies).Error
+	return replies, err
+}
@@ -31,11 +31,13 @@ func (r *QuestionRepository) FindByIdWithParticipant(db *gorm.DB, id uint) (*ent
 	return &question, err
 }
 
-// List mendapatkan list questions dengan filter dan sorting
-func (r *QuestionRepository) List(db *gorm.DB, roomID uint, status string, sortBy string, limit int, offset int) ([]entity.Question, error) {
+// List mendapatkan list questions dengan filter dan sorting.
+// Hanya question approved yang dikembalikan, ditambah question milik viewerID yang masih di antrian moderasi.
+func (r *QuestionRepository) List(db *gorm.DB, roomID uint, viewerID uint, status string, sortBy string, limit int, offset int) ([]entity.Question, error) {
 	var questions []entity.Question
 
-	query := db.Preload("Participant").Where("room_id = ?", roomID)
+	query := db.Preload("Participant").Where("room_id = ?", roomID).
+		Where("moderation_status = ? OR (moderation_status = ? AND participant_id = ?)", "approved", "queued", viewerID)
 
 	// filter by status
 	if status != "" {
@@ -64,10 +66,11 @@ func (r *QuestionRepository) List(db *gorm.DB, roomID uint, status string, sortB
 	return questions, err
 }
 
-// Count menghitung total questions berdasarkan room dan status
-func (r *QuestionRepository) Count(db *gorm.DB, roomID uint, status string) (int64, error) {
+// Count menghitung total questions berdasarkan room dan status, dengan aturan visibilitas yang sama dengan List
+func (r *QuestionRepository) Count(db *gorm.DB, roomID uint, viewerID uint, status string) (int64, error) {
 	var count int64
-	query := db.Model(&entity.Question{}).Where("room_id = ?", roomID)
+	query := db.Model(&entity.Question{}).Where("room_id = ?", roomID).
+		Where("moderation_status = ? OR (moderation_status = ? AND participant_id = ?)", "approved", "queued", viewerID)
 
 	if status != "" {
 		query = query.Where("status = ?", status)
@@ -99,6 +102,40 @@ func (r *QuestionRepository) UpdateValidation(db *gorm.DB, questionID uint, stat
 		}).Error
 }
 
+// ListQueued daftar question di antrian moderasi, yang paling lama di depan
+func (r *QuestionRepository) ListQueued(db *gorm.DB, roomID uint, limit int, offset int) ([]entity.Question, int64, error) {
+	var questions []entity.Question
+	var total int64
+
+	query := db.Model(&entity.Question{}).Where("room_id = ? AND moderation_status = ?", roomID, "queued")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Participant").
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&questions).Error
+	return questions, total, err
+}
+
+// UpdateModeration simpan hasil moderasi question yang masih di antrian, false jika sudah dimoderasi
+func (r *QuestionRepository) UpdateModeration(db *gorm.DB, question *entity.Question) (bool, error) {
+	result := db.Model(&entity.Question{}).Where("id = ? AND moderation_status = ?", question.ID, "queued").
+		Updates(map[string]interface{}{
+			"content":           question.Content,
+			"moderation_status": question.ModerationStatus,
+			"moderated_by":      question.ModeratedBy,
+			"moderated_at":      question.ModeratedAt,
+			"xp_awarded":        question.XPAwarded,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // GetRoomIDByQuestionID get room id dari question
 func (r *QuestionRepository) GetRoomIDByQuestionID(db *gorm.DB, questionID uint) (uint, error) {
 	var question entity.Question
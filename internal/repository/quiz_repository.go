@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type QuizRepository struct {
+	Repository[entity.Quiz]
+	Log *logrus.Logger
+}
+
+func NewQuizRepository(log *logrus.Logger) *QuizRepository {
+	return &QuizRepository{
+		Log: log,
+	}
+}
+
+// GetQuizByID retrieves quiz by ID, return nil jika tidak ditemukan
+func (r *QuizRepository) GetQuizByID(db *gorm.DB, quizID uint) (*entity.Quiz, error) {
+	var quiz entity.Quiz
+	err := db.Where("id = ?", quizID).First(&quiz).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &quiz, err
+}
+
+// GetQuizPolls retrieves semua pertanyaan quiz (urut dibuat) beserta options
+func (r *QuizRepository) GetQuizPolls(db *gorm.DB, quizID uint) ([]entity.Poll, error) {
+	var polls []entity.Poll
+	err := db.Where("quiz_id = ?", quizID).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("poll_options.\"order\" ASC")
+		}).
+		Order("created_at ASC, id ASC").
+		Find(&polls).Error
+	return polls, err
+}
+
+// FinishQuiz updates quiz status to finished
+func (r *QuizRepository) FinishQuiz(db *gorm.DB, quiz *entity.Quiz) error {
+	return db.Model(quiz).Updates(map[string]interface{}{
+		"status":      "finished",
+		"finished_at": gorm.Expr("NOW()"),
+	}).Error
+}
+
+// CreateAnswer insert hasil penilaian jawaban quiz
+func (r *QuizRepository) CreateAnswer(db *gorm.DB, answer *entity.QuizAnswer) error {
+	return db.Create(answer).Error
+}
+
+// GetQuestionStats agregat jawaban per pertanyaan quiz
+func (r *QuizRepository) GetQuestionStats(db *gorm.DB, quizID uint) ([]model.QuizQuestionStat, error) {
+	var stats []model.QuizQuestionStat
+	err := db.Model(&entity.QuizAnswer{}).
+		Select("poll_id, COUNT(*) AS total_answers, "+
+			"COUNT(*) FILTER (WHERE is_correct) AS correct_answers, "+
+			"COALESCE(AVG(response_ms), 0) AS avg_response_ms").
+		Where("quiz_id = ?", quizID).
+		Group("poll_id").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetRanking agregat jawaban per participant, urut points tertinggi lalu rata-rata waktu tercepat
+func (r *QuizRepository) GetRanking(db *gorm.DB, quizID uint) ([]model.QuizParticipantStat, error) {
+	var stats []model.QuizParticipantStat
+	err := db.Table("quiz_answers qa").
+		Select("qa.participant_id, p.display_name, COUNT(*) AS total_answers, "+
+			"COUNT(*) FILTER (WHERE qa.is_correct) AS correct_answers, "+
+			"COALESCE(SUM(qa.points), 0) AS points, "+
+			"COALESCE(AVG(qa.response_ms), 0) AS avg_response_ms").
+		Joins("JOIN participants p ON p.id = qa.participant_id").
+		Where("qa.quiz_id = ?", quizID).
+		Group("qa.participant_id, p.display_name").
+		Order("points DESC, avg_response_ms ASC, qa.participant_id ASC").
+		Scan(&stats).Error
+	return stats, err
+}
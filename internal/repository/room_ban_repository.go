@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RoomBanRepository repository untuk operasi database RoomBan
+type RoomBanRepository struct {
+	Repository[entity.RoomBan]
+	Log *logrus.Logger
+}
+
+// NewRoomBanRepository create new instance of RoomBanRepository
+func NewRoomBanRepository(log *logrus.Logger) *RoomBanRepository {
+	return &RoomBanRepository{
+		Log: log,
+	}
+}
+
+// IsUserBanned cek apakah user terdaftar diblokir dari room
+func (r *RoomBanRepository) IsUserBanned(db *gorm.DB, roomID, userID uint) (bool, error) {
+	var count int64
+	err := db.Model(&entity.RoomBan{}).
+		Where("room_id = ? AND user_id = ?", roomID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsFingerprintBanned cek apakah device anonymous diblokir dari room
+func (r *RoomBanRepository) IsFingerprintBanned(db *gorm.DB, roomID uint, fingerprint string) (bool, error) {
+	var count int64
+	err := db.Model(&entity.RoomBan{}).
+		Where("room_id = ? AND fingerprint = ?", roomID, fingerprint).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListByRoomID daftar ban di room, terbaru dulu
+func (r *RoomBanRepository) ListByRoomID(db *gorm.DB, roomID uint) ([]entity.RoomBan, error) {
+	var bans []entity.RoomBan
+	err := db.Where("room_id = ?", roomID).Order("created_at DESC").Find(&bans).Error
+	return bans, err
+}
+
+// FindByIdAndRoomID mencari ban di room tertentu
+func (r *RoomBanRepository) FindByIdAndRoomID(db *gorm.DB, id, roomID uint) (*entity.RoomBan, error) {
+	var ban entity.RoomBan
+	err := db.Where("id = ? AND room_id = ?", id, roomID).Take(&ban).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &ban, err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RoomContentFilterRepository repository untuk operasi database RoomContentFilter
+type RoomContentFilterRepository struct {
+	Repository[entity.RoomContentFilter]
+	Log *logrus.Logger
+}
+
+// NewRoomContentFilterRepository create new instance of RoomContentFilterRepository
+func NewRoomContentFilterRepository(log *logrus.Logger) *RoomContentFilterRepository {
+	return &RoomContentFilterRepository{
+		Log: log,
+	}
+}
+
+// FindByRoomID ambil konfigurasi filter room, nil jika room belum pernah dikonfigurasi
+func (r *RoomContentFilterRepository) FindByRoomID(db *gorm.DB, roomID uint) (*entity.RoomContentFilter, error) {
+	var filter entity.RoomContentFilter
+	err := db.Where("room_id = ?", roomID).First(&filter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// Upsert simpan konfigurasi filter room (insert atau replace)
+func (r *RoomContentFilterRepository) Upsert(db *gorm.DB, filter *entity.RoomContentFilter) error {
+	return db.Omit("Room").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "room_id"}},
+		UpdateAll: true,
+	}).Create(filter).Error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"reisify/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RoomPresenceEventRepository repository untuk riwayat presence participant
+type RoomPresenceEventRepository struct {
+	Repository[entity.RoomPresenceEvent]
+	Log *logrus.Logger
+}
+
+// NewRoomPresenceEventRepository create new instance of RoomPresenceEventRepository
+func NewRoomPresenceEventRepository(log *logrus.Logger) *RoomPresenceEventRepository {
+	return &RoomPresenceEventRepository{
+		Log: log,
+	}
+}
+
+// ListByRoomID seluruh presence event room, urut kronologis
+func (r *RoomPresenceEventRepository) ListByRoomID(db *gorm.DB, roomID uint) ([]entity.RoomPresenceEvent, error) {
+	var events []entity.RoomPresenceEvent
+	err := db.Where("room_id = ?", roomID).Order("created_at ASC, id ASC").Find(&events).Error
+	return events, err
+}
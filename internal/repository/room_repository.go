@@ -70,3 +70,10 @@ func (r *RoomRepository) FindByRoomCode(db *gorm.DB, code string) (*entity.Room,
 func (r *RoomRepository) SoftDelete(db *gorm.DB, roomID uint) error {
 	return db.Delete(&entity.Room{}, roomID).Error
 }
+
+// ListByPresenterID semua room milik presenter tanpa relasi
+func (r *RoomRepository) ListByPresenterID(db *gorm.DB, presenterID uint) ([]entity.Room, error) {
+	var rooms []entity.Room
+	err := db.Where("presenter_id = ?", presenterID).Find(&rooms).Error
+	return rooms, err
+}
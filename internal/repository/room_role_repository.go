@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RoomRoleRepository repository untuk operasi database RoomRole
+type RoomRoleRepository struct {
+	Repository[entity.RoomRole]
+	Log *logrus.Logger
+}
+
+// NewRoomRoleRepository create new instance of RoomRoleRepository
+func NewRoomRoleRepository(log *logrus.Logger) *RoomRoleRepository {
+	return &RoomRoleRepository{
+		Log: log,
+	}
+}
+
+// FindByRoomIDAndUserID cari role user di room, nil jika user tidak punya role tambahan
+func (r *RoomRoleRepository) FindByRoomIDAndUserID(db *gorm.DB, roomID, userID uint) (*entity.RoomRole, error) {
+	var role entity.RoomRole
+	err := db.Preload("User").Where("room_id = ? AND user_id = ?", roomID, userID).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetRole role efektif user di room: owner, co_host, moderator atau participant
+func (r *RoomRoleRepository) GetRole(db *gorm.DB, room *entity.Room, userID uint) (string, error) {
+	if room.PresenterID == userID {
+		return model.RoomRoleOwner, nil
+	}
+
+	var roles []string
+	err := db.Model(&entity.RoomRole{}).
+		Where("room_id = ? AND user_id = ?", room.ID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error
+	if err != nil {
+		return "", err
+	}
+	if len(roles) == 0 {
+		return model.RoomRoleParticipant, nil
+	}
+	return roles[0], nil
+}
+
+// UpdateRole ganti role dan pemberi role yang sudah ada
+func (r *RoomRoleRepository) UpdateRole(db *gorm.DB, roomRole *entity.RoomRole) error {
+	return db.Model(roomRole).Updates(map[string]interface{}{
+		"role":       roomRole.Role,
+		"granted_by": roomRole.GrantedBy,
+	}).Error
+}
+
+// ListByRoomID daftar role room dengan data user, role kosong berarti semua role
+func (r *RoomRoleRepository) ListByRoomID(db *gorm.DB, roomID uint, role string) ([]entity.RoomRole, error) {
+	var roles []entity.RoomRole
+	query := db.Preload("User").Where("room_id = ?", roomID)
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	err := query.Order("created_at ASC").Find(&roles).Error
+	return roles, err
+}
+
+// GetUserIDsByRoomID user id semua pemegang role tambahan di room
+func (r *RoomRoleRepository) GetUserIDsByRoomID(db *gorm.DB, roomID uint) ([]uint, error) {
+	var userIDs []uint
+	err := db.Model(&entity.RoomRole{}).
+		Where("room_id = ?", roomID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// DeleteByRoomIDAndUserID cabut role user di room, mengembalikan jumlah row yang terhapus
+func (r *RoomRoleRepository) DeleteByRoomIDAndUserID(db *gorm.DB, roomID, userID uint) (int64, error) {
+	result := db.Where("room_id = ? AND user_id = ?", roomID, userID).Delete(&entity.RoomRole{})
+	return result.RowsAffected, result.Error
+}
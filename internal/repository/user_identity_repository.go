@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// UserIdentityRepository repository untuk operasi database UserIdentity
+type UserIdentityRepository struct {
+	Repository[entity.UserIdentity]
+	Log *logrus.Logger
+}
+
+// NewUserIdentityRepository create new instance of UserIdentityRepository
+func NewUserIdentityRepository(log *logrus.Logger) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		Log: log,
+	}
+}
+
+// FindByIssuerAndSubject cari identity berdasarkan issuer dan subject, nil jika tidak ada
+func (r *UserIdentityRepository) FindByIssuerAndSubject(db *gorm.DB, issuer, subject string) (*entity.UserIdentity, error) {
+	var identity entity.UserIdentity
+	err := db.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
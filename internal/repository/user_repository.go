@@ -41,3 +41,14 @@ func (r *UserRepository) FindByUsername(db *gorm.DB, username string) (*entity.U
 
 	return &user, err
 }
+
+// FindByEmail find user by email
+func (r *UserRepository) FindByEmail(db *gorm.DB, email string) (*entity.User, error) {
+	var user entity.User
+	err := db.Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &user, err
+}
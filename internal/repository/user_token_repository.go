@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// UserTokenRepository repository untuk operasi database UserToken
+type UserTokenRepository struct {
+	Repository[entity.UserToken]
+	Log *logrus.Logger
+}
+
+// NewUserTokenRepository create new instance of UserTokenRepository
+func NewUserTokenRepository(log *logrus.Logger) *UserTokenRepository {
+	return &UserTokenRepository{
+		Log: log,
+	}
+}
+
+// FindByHash cari token berdasarkan purpose dan hash, nil jika tidak ada
+func (r *UserTokenRepository) FindByHash(db *gorm.DB, purpose, tokenHash string) (*entity.UserToken, error) {
+	var token entity.UserToken
+	err := db.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// CountSince jumlah token yang diminta user untuk purpose tertentu sejak waktu since
+func (r *UserTokenRepository) CountSince(db *gorm.DB, userID uint, purpose string, since time.Time) (int64, error) {
+	var total int64
+	err := db.Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at > ?", userID, purpose, since).
+		Count(&total).Error
+	return total, err
+}
+
+// Consume tandai token terpakai, false jika token sudah dipakai atau kedaluwarsa
+func (r *UserTokenRepository) Consume(db *gorm.DB, id uint, now time.Time) (bool, error) {
+	result := db.Model(&entity.UserToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateActive tandai semua token user yang belum terpakai untuk purpose tertentu sebagai terpakai
+func (r *UserTokenRepository) InvalidateActive(db *gorm.DB, userID uint, purpose string, now time.Time) error {
+	return db.Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error
+}
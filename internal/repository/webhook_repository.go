@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"errors"
+	"reisify/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository repository untuk operasi database Webhook
+type WebhookRepository struct {
+	Repository[entity.Webhook]
+	Log *logrus.Logger
+}
+
+// NewWebhookRepository create new instance of WebhookRepository
+func NewWebhookRepository(log *logrus.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		Log: log,
+	}
+}
+
+// FindByIdAndUserID cari webhook milik user, nil jika tidak ada
+func (r *WebhookRepository) FindByIdAndUserID(db *gorm.DB, id, userID uint) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	err := db.Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListByUserID daftar webhook milik user
+func (r *WebhookRepository) ListByUserID(db *gorm.DB, userID uint) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	err := db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// ListActiveForRoom webhook aktif untuk room: yang didaftarkan ke room itu atau ke akun owner room
+func (r *WebhookRepository) ListActiveForRoom(db *gorm.DB, roomID, ownerID uint) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	err := db.Where("is_active = ?", true).
+		Where("room_id = ? OR (room_id IS NULL AND user_id = ?)", roomID, ownerID).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+// WebhookDeliveryRepository repository untuk operasi database WebhookDelivery
+type WebhookDeliveryRepository struct {
+	Repository[entity.WebhookDelivery]
+	Log *logrus.Logger
+}
+
+// NewWebhookDeliveryRepository create new instance of WebhookDeliveryRepository
+func NewWebhookDeliveryRepository(log *logrus.Logger) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		Log: log,
+	}
+}
+
+// CreateBatch insert beberapa delivery sekaligus
+func (r *WebhookDeliveryRepository) CreateBatch(db *gorm.DB, deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.Create(&deliveries).Error
+}
+
+// FindByIdAndWebhookID cari delivery milik webhook, nil jika tidak ada
+func (r *WebhookDeliveryRepository) FindByIdAndWebhookID(db *gorm.DB, id, webhookID uint) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := db.Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListByWebhookID delivery log webhook, terbaru dulu, status kosong berarti semua
+func (r *WebhookDeliveryRepository) ListByWebhookID(db *gorm.DB, webhookID uint, status string, limit int) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	query := db.Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDue ambil delivery pending yang jatuh tempo lalu tunda next_attempt_at sampai leaseUntil,
+// supaya node lain tidak mengirim delivery yang sama. Row yang dikunci node lain di-skip.
+func (r *WebhookDeliveryRepository) ClaimDue(db *gorm.DB, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	var ids []uint
+	err := db.Model(&entity.WebhookDelivery{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", "pending", now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	err = db.Model(&entity.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByIDsWithWebhook(db, ids)
+}
+
+// FindByIDsWithWebhook ambil delivery beserta webhook tujuan
+func (r *WebhookDeliveryRepository) FindByIDsWithWebhook(db *gorm.DB, ids []uint) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	err := db.Preload("Webhook").Where("id IN ?", ids).Order("id ASC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// UpdateResult simpan hasil satu percobaan pengiriman
+func (r *WebhookDeliveryRepository) UpdateResult(db *gorm.DB, delivery *entity.WebhookDelivery) error {
+	return db.Model(delivery).Select("status", "attempts", "response_status", "response_body", "last_error", "next_attempt_at", "delivered_at").Updates(delivery).Error
+}
@@ -52,3 +52,13 @@ func (r *XPTransactionRepository) GetTotalXPByParticipant(db *gorm.DB, participa
 	}
 	return participant.XPScore, nil
 }
+
+// SumPointsBySource total XP yang sudah diberikan untuk satu sumber (misal satu message)
+func (r *XPTransactionRepository) SumPointsBySource(db *gorm.DB, sourceType string, sourceID uint) (int, error) {
+	var total int
+	err := db.Model(&entity.XPTransaction{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("source_type = ? AND source_id = ?", sourceType, sourceID).
+		Scan(&total).Error
+	return total, err
+}
@@ -17,7 +17,8 @@ type TrackInfo struct {
 // ConferenceState represents the state of the conference
 type ConferenceState struct {
 	IsActive    bool             // apakah conference sudah dimulai
-	HostID      string           // participantID host/presenter
+	HostID      string           // participantID host/presenter yang memulai conference
+	Hosts       map[string]bool  // participantID owner / co-host yang boleh mengelola conference
 	Speakers    map[string]bool  // participantID yang dipromote jadi speaker
 	RaisedHands map[string]int64 // participantID -> timestamp raise hand
 }
@@ -40,6 +41,7 @@ func NewRoom(id uint, log *logrus.Logger) *Room {
 		Conference: &ConferenceState{
 			IsActive:    false,
 			HostID:      "",
+			Hosts:       make(map[string]bool),
 			Speakers:    make(map[string]bool),
 			RaisedHands: make(map[string]int64),
 		},
@@ -223,7 +225,7 @@ func (r *Room) StopConference(participantID string) error {
 	defer r.lock.Unlock()
 
 	// Only host can stop
-	if r.Conference.HostID != participantID {
+	if !r.isHost(participantID) {
 		return nil
 	}
 
@@ -252,7 +254,7 @@ func (r *Room) PromoteSpeaker(hostID, participantID string) bool {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	if r.Conference.HostID != hostID {
+	if !r.isHost(hostID) {
 		return false
 	}
 
@@ -266,7 +268,7 @@ func (r *Room) DemoteSpeaker(hostID, participantID string) bool {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	if r.Conference.HostID != hostID {
+	if !r.isHost(hostID) {
 		return false
 	}
 
@@ -281,11 +283,27 @@ func (r *Room) IsSpeaker(participantID string) bool {
 	return r.Conference.Speakers[participantID]
 }
 
-// IsHost checks if participant is the host
+// SetHost tandai participant sebagai host conference (owner / co-host) atau cabut statusnya
+func (r *Room) SetHost(participantID string, isHost bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if isHost {
+		r.Conference.Hosts[participantID] = true
+		return
+	}
+	delete(r.Conference.Hosts, participantID)
+}
+
+// IsHost checks if participant is the host or a co-host
 func (r *Room) IsHost(participantID string) bool {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
-	return r.Conference.HostID == participantID
+	return r.isHost(participantID)
+}
+
+// isHost caller harus memegang lock
+func (r *Room) isHost(participantID string) bool {
+	return r.Conference.HostID == participantID || r.Conference.Hosts[participantID]
 }
 
 // GetConferenceState returns current conference state
@@ -294,6 +312,10 @@ func (r *Room) GetConferenceState() ConferenceState {
 	defer r.lock.RUnlock()
 
 	// Deep copy maps
+	hosts := make(map[string]bool)
+	for k, v := range r.Conference.Hosts {
+		hosts[k] = v
+	}
 	speakers := make(map[string]bool)
 	for k, v := range r.Conference.Speakers {
 		speakers[k] = v
@@ -306,6 +328,7 @@ func (r *Room) GetConferenceState() ConferenceState {
 	return ConferenceState{
 		IsActive:    r.Conference.IsActive,
 		HostID:      r.Conference.HostID,
+		Hosts:       hosts,
 		Speakers:    speakers,
 		RaisedHands: raisedHands,
 	}
@@ -0,0 +1,656 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reisify/internal/entity"
+	"reisify/internal/mail"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"reisify/internal/util"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// PasswordResetTTL masa berlaku token reset password
+	PasswordResetTTL = time.Hour
+	// EmailVerificationTTL masa berlaku token verifikasi email
+	EmailVerificationTTL = 24 * time.Hour
+
+	// maksimal token per user per purpose dalam satu window
+	userTokenRequestLimit  = 3
+	userTokenRequestWindow = time.Hour
+
+	mailSendTimeout = 30 * time.Second
+)
+
+// deletedParticipantName nama pengganti participant milik akun yang dihapus
+const deletedParticipantName = "Deleted user"
+
+var (
+	errInvalidUserToken     = fiber.NewError(fiber.StatusBadRequest, "Invalid or expired token")
+	errWrongCurrentPassword = fiber.NewError(fiber.StatusForbidden, "Current password is incorrect")
+)
+
+type AccountUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	UserRepository        *repository.UserRepository
+	UserTokenRepository   *repository.UserTokenRepository
+	RoomRepository        *repository.RoomRepository
+	ParticipantRepository *repository.ParticipantRepository
+	TokenUtil             *util.TokenUtil
+	Mail                  mail.Sender
+	BaseURL               string // url frontend untuk link di email
+}
+
+// NewAccountUseCase create new instance of AccountUseCase
+func NewAccountUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, userRepository *repository.UserRepository, userTokenRepository *repository.UserTokenRepository, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository, tokenUtil *util.TokenUtil, mailSender mail.Sender, baseURL string) *AccountUseCase {
+	return &AccountUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		UserRepository:        userRepository,
+		UserTokenRepository:   userTokenRepository,
+		RoomRepository:        roomRepository,
+		ParticipantRepository: participantRepository,
+		TokenUtil:             tokenUtil,
+		Mail:                  mailSender,
+		BaseURL:               strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// ForgotPassword usecase untuk kirim link reset password,
+// email tidak terdaftar atau limit terlampaui tetap dianggap sukses agar tidak bocor ke peminta
+func (c *AccountUseCase) ForgotPassword(ctx context.Context, request *model.ForgotPasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ForgotPassword - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	user, err := c.UserRepository.FindByEmail(tx, request.Email)
+	if err != nil {
+		c.Log.Errorf("ForgotPassword - UserRepository.FindByEmail error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if user == nil {
+		c.Log.Warnf("ForgotPassword - Email not registered: %s", request.Email)
+		return nil
+	}
+
+	token, err := c.issueToken(tx, user, entity.UserTokenPurposePasswordReset, PasswordResetTTL, request.IP)
+	if err != nil {
+		if err == fiber.ErrTooManyRequests {
+			c.Log.Warnf("ForgotPassword - Too many requests for user %d", user.ID)
+			return nil
+		}
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ForgotPassword - Commit error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.sendMail(&mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. The link expires in %s and can only be used once.\n\n%s/reset-password?token=%s\n\nIf you did not request a password reset you can ignore this email.\n",
+			user.Username, formatTTL(PasswordResetTTL), c.BaseURL, token),
+	})
+	return nil
+}
+
+// ResetPassword usecase untuk set password baru dari token reset,
+// semua session user dicabut dan id session dikembalikan agar websocket ikut diputus
+func (c *AccountUseCase) ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) ([]string, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ResetPassword - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+	user, err := c.consumeToken(tx, entity.UserTokenPurposePasswordReset, request.Token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Errorf("ResetPassword - GenerateFromPassword error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	user.PasswordHash = string(password)
+	// link di email sudah dibuka, berarti email milik user
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Errorf("ResetPassword - UserRepository.Update error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.UserTokenRepository.InvalidateActive(tx, user.ID, entity.UserTokenPurposePasswordReset, now); err != nil {
+		c.Log.Errorf("ResetPassword - UserTokenRepository.InvalidateActive error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ResetPassword - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// password sudah berubah, gagal cabut session cukup di-log
+	sessionIDs, err := c.TokenUtil.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		c.Log.Errorf("ResetPassword - TokenUtil.RevokeUserSessions error: %v", err)
+	}
+
+	c.sendMail(&mail.Message{
+		To:      user.Email,
+		Subject: "Your password was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe password for your account was just reset and all devices were signed out.\n\nIf this wasn't you, reset your password again right away.\n",
+			user.Username),
+	})
+	return sessionIDs, nil
+}
+
+// SendVerificationEmail usecase untuk kirim (ulang) link verifikasi ke email user
+func (c *AccountUseCase) SendVerificationEmail(ctx context.Context, request *model.SendVerificationEmailRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("SendVerificationEmail - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil {
+		c.Log.Warnf("SendVerificationEmail - User not found: %d", request.UserID)
+		return fiber.ErrNotFound
+	}
+	if user.EmailVerifiedAt != nil {
+		return fiber.NewError(fiber.StatusConflict, "Email already verified")
+	}
+
+	token, err := c.issueToken(tx, user, entity.UserTokenPurposeEmailVerification, EmailVerificationTTL, request.IP)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("SendVerificationEmail - Commit error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.sendMail(&mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below. The link expires in %s.\n\n%s/verify-email?token=%s\n",
+			user.Username, formatTTL(EmailVerificationTTL), c.BaseURL, token),
+	})
+	return nil
+}
+
+// VerifyEmail usecase untuk tandai email user terverifikasi dari token verifikasi
+func (c *AccountUseCase) VerifyEmail(ctx context.Context, request *model.VerifyEmailRequest) (*model.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("VerifyEmail - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+	user, err := c.consumeToken(tx, entity.UserTokenPurposeEmailVerification, request.Token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+		if err := c.UserRepository.Update(tx, user); err != nil {
+			c.Log.Errorf("VerifyEmail - UserRepository.Update error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("VerifyEmail - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// Profile usecase untuk ambil profil user yang sedang login
+func (c *AccountUseCase) Profile(ctx context.Context, request *model.GetProfileRequest) (*model.UserResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Profile - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(db, user, request.UserID); err != nil {
+		c.Log.Warnf("Profile - User not found: %d", request.UserID)
+		return nil, fiber.ErrNotFound
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// UpdateProfile usecase untuk ubah username, email, role atau password.
+// Perubahan email, role dan password wajib menyertakan password saat ini. Ganti password mencabut session lain,
+// ganti email mereset status verifikasi dan mengirim link verifikasi ke email baru
+func (c *AccountUseCase) UpdateProfile(ctx context.Context, request *model.UpdateProfileRequest) (*model.UpdateProfileResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("UpdateProfile - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil {
+		c.Log.Warnf("UpdateProfile - User not found: %d", request.UserID)
+		return nil, fiber.ErrNotFound
+	}
+
+	usernameChanged := request.Username != nil && *request.Username != user.Username
+	emailChanged := request.Email != nil && !strings.EqualFold(*request.Email, user.Email)
+	roleChanged := request.Role != nil && *request.Role != user.Role
+	passwordChanged := request.NewPassword != nil
+
+	// tidak ada perubahan, access token lama tetap dipakai
+	if !usernameChanged && !emailChanged && !roleChanged && !passwordChanged {
+		return &model.UpdateProfileResponse{User: *converter.UserToResponse(user)}, nil
+	}
+
+	// re-authentication untuk perubahan sensitif
+	if emailChanged || roleChanged || passwordChanged {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.CurrentPassword)); err != nil {
+			c.Log.Warnf("UpdateProfile - Invalid current password for user %d", user.ID)
+			return nil, errWrongCurrentPassword
+		}
+	}
+
+	if usernameChanged {
+		existing, err := c.UserRepository.FindByUsername(tx, *request.Username)
+		if err != nil {
+			c.Log.Errorf("UpdateProfile - UserRepository.FindByUsername error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if existing != nil && existing.ID != user.ID {
+			c.Log.Warnf("UpdateProfile - Username already in use: %s", *request.Username)
+			return nil, fiber.NewError(fiber.StatusConflict, "Username already in use")
+		}
+		user.Username = *request.Username
+	}
+
+	oldEmail := user.Email
+	if emailChanged {
+		existing, err := c.UserRepository.FindByEmail(tx, *request.Email)
+		if err != nil {
+			c.Log.Errorf("UpdateProfile - UserRepository.FindByEmail error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if existing != nil && existing.ID != user.ID {
+			c.Log.Warnf("UpdateProfile - Email already in use: %s", *request.Email)
+			return nil, fiber.NewError(fiber.StatusConflict, "Email already in use")
+		}
+		user.Email = *request.Email
+		user.EmailVerifiedAt = nil
+	}
+
+	if roleChanged {
+		user.Role = *request.Role
+	}
+
+	if passwordChanged {
+		password, err := bcrypt.GenerateFromPassword([]byte(*request.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			c.Log.Errorf("UpdateProfile - GenerateFromPassword error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		user.PasswordHash = string(password)
+
+		// link reset yang belum dipakai tidak berlaku lagi
+		if err := c.UserTokenRepository.InvalidateActive(tx, user.ID, entity.UserTokenPurposePasswordReset, time.Now()); err != nil {
+			c.Log.Errorf("UpdateProfile - UserTokenRepository.InvalidateActive error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.Errorf("UpdateProfile - UserRepository.Update error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// link verifikasi ke email baru, limit terlampaui cukup di-log (bisa kirim ulang nanti)
+	verificationToken := ""
+	if emailChanged {
+		token, err := c.issueToken(tx, user, entity.UserTokenPurposeEmailVerification, EmailVerificationTTL, request.IP)
+		if err != nil && err != fiber.ErrTooManyRequests {
+			return nil, err
+		}
+		verificationToken = token
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("UpdateProfile - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.UpdateProfileResponse{User: *converter.UserToResponse(user)}
+
+	// profil sudah tersimpan, kegagalan di Redis cukup di-log
+	currentSessionID := ""
+	if request.Auth != nil {
+		currentSessionID = request.Auth.SessionID
+	}
+	if passwordChanged {
+		sessionIDs, err := c.TokenUtil.RevokeOtherUserSessions(ctx, user.ID, currentSessionID)
+		if err != nil {
+			c.Log.Errorf("UpdateProfile - TokenUtil.RevokeOtherUserSessions error: %v", err)
+		}
+		response.RevokedSessionIDs = sessionIDs
+	}
+
+	// claims semua session (termasuk token room) ikut diubah agar refresh berikutnya memakai profil baru.
+	// Role di token room adalah role di room, hanya claims akun yang memakai role user
+	if usernameChanged || emailChanged || roleChanged {
+		if err := c.TokenUtil.UpdateUserClaims(ctx, user.ID, func(auth *model.Auth) {
+			auth.Username = user.Username
+			auth.Email = user.Email
+			if auth.ParticipantID == nil {
+				auth.Role = user.Role
+			}
+		}); err != nil {
+			c.Log.Errorf("UpdateProfile - TokenUtil.UpdateUserClaims error: %v", err)
+		}
+
+		// access token session saat ini diterbitkan ulang dengan claims baru
+		if currentSessionID != "" {
+			auth := *request.Auth
+			auth.RegisteredClaims = jwt.RegisteredClaims{}
+			auth.Username = user.Username
+			auth.Email = user.Email
+			if auth.ParticipantID == nil {
+				auth.Role = user.Role
+			}
+			tokens, err := c.TokenUtil.CreateToken(ctx, &auth, nil)
+			if err != nil {
+				c.Log.Errorf("UpdateProfile - TokenUtil.CreateToken error: %v", err)
+			} else {
+				response.Token = tokens.AccessToken
+			}
+		}
+	}
+
+	if emailChanged {
+		c.sendMail(&mail.Message{
+			To:      oldEmail,
+			Subject: "Your email address was changed",
+			Body: fmt.Sprintf("Hi %s,\n\nThe email address of your account was changed to %s.\n\nIf this wasn't you, reset your password right away and contact support.\n",
+				user.Username, user.Email),
+		})
+		if verificationToken != "" {
+			c.sendMail(&mail.Message{
+				To:      user.Email,
+				Subject: "Verify your email address",
+				Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your new email address by opening the link below. The link expires in %s.\n\n%s/verify-email?token=%s\n",
+					user.Username, formatTTL(EmailVerificationTTL), c.BaseURL, verificationToken),
+			})
+		}
+	}
+	if passwordChanged {
+		c.sendMail(&mail.Message{
+			To:      user.Email,
+			Subject: "Your password was changed",
+			Body: fmt.Sprintf("Hi %s,\n\nThe password for your account was just changed and your other devices were signed out.\n\nIf this wasn't you, reset your password right away.\n",
+				user.Username),
+		})
+	}
+
+	return response, nil
+}
+
+// DeleteAccount usecase untuk hapus akun user setelah konfirmasi password.
+// Participant milik user di room lain dianonimkan (riwayat Q&A dan poll tetap ada), room milik user
+// ikut terhapus lewat foreign key. Return session dan room aktif yang perlu diputus dari websocket
+func (c *AccountUseCase) DeleteAccount(ctx context.Context, request *model.DeleteAccountRequest) (*model.DeleteAccountResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("DeleteAccount - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil {
+		c.Log.Warnf("DeleteAccount - User not found: %d", request.UserID)
+		return nil, fiber.ErrNotFound
+	}
+
+	// re-authentication
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
+		c.Log.Warnf("DeleteAccount - Invalid password for user %d", user.ID)
+		return nil, errWrongCurrentPassword
+	}
+
+	rooms, err := c.RoomRepository.ListByPresenterID(tx, user.ID)
+	if err != nil {
+		c.Log.Errorf("DeleteAccount - RoomRepository.ListByPresenterID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	roomIDs := make([]uint, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	// participant di room milik user ikut terhapus, token room mereka dicabut setelah commit
+	roomParticipants, err := c.ParticipantRepository.ListByRoomIDs(tx, roomIDs)
+	if err != nil {
+		c.Log.Errorf("DeleteAccount - ParticipantRepository.ListByRoomIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	ownParticipantIDs, err := c.ParticipantRepository.ListIDsByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.Errorf("DeleteAccount - ParticipantRepository.ListIDsByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.ParticipantRepository.AnonymizeByUserID(tx, user.ID, deletedParticipantName); err != nil {
+		c.Log.Errorf("DeleteAccount - ParticipantRepository.AnonymizeByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// room, api key, webhook, token email dan identity SSO terhapus lewat ON DELETE CASCADE
+	if err := c.UserRepository.Delete(tx, user); err != nil {
+		c.Log.Errorf("DeleteAccount - UserRepository.Delete error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("DeleteAccount - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := new(model.DeleteAccountResponse)
+	now := time.Now()
+	participantsByRoom := make(map[uint][]uint, len(rooms))
+	for _, participant := range roomParticipants {
+		participantsByRoom[participant.RoomID] = append(participantsByRoom[participant.RoomID], participant.ID)
+	}
+	for _, room := range rooms {
+		if room.Status != "active" {
+			continue
+		}
+		response.ClosedRooms = append(response.ClosedRooms, model.ClosedRoom{
+			Room:           model.UpdateToCloseRoom{ID: room.ID, Status: "closed", ClosedAt: &now},
+			ParticipantIDs: participantsByRoom[room.ID],
+		})
+	}
+
+	// akun sudah terhapus, kegagalan di Redis cukup di-log (session gagal di Redis tidak punya user lagi)
+	sessionIDs, err := c.TokenUtil.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		c.Log.Errorf("DeleteAccount - TokenUtil.RevokeUserSessions error: %v", err)
+	}
+	response.SessionIDs = sessionIDs
+	for _, participant := range roomParticipants {
+		if err := c.TokenUtil.InvalidateParticipantTokens(ctx, participant.ID); err != nil {
+			c.Log.Errorf("DeleteAccount - TokenUtil.InvalidateParticipantTokens error: %v", err)
+		}
+	}
+	for _, participantID := range ownParticipantIDs {
+		if err := c.TokenUtil.InvalidateParticipantTokens(ctx, participantID); err != nil {
+			c.Log.Errorf("DeleteAccount - TokenUtil.InvalidateParticipantTokens error: %v", err)
+		}
+	}
+
+	c.sendMail(&mail.Message{
+		To:      user.Email,
+		Subject: "Your account was deleted",
+		Body: fmt.Sprintf("Hi %s,\n\nYour account and the rooms you created have been deleted. Questions and votes you left in other rooms are kept without your name.\n",
+			user.Username),
+	})
+	return response, nil
+}
+
+// issueToken buat token baru untuk user setelah cek rate limit, token lama yang belum terpakai dibatalkan.
+// Return token mentah (hanya dikirim lewat email), fiber.ErrTooManyRequests jika limit terlampaui
+func (c *AccountUseCase) issueToken(tx *gorm.DB, user *entity.User, purpose string, ttl time.Duration, ip string) (string, error) {
+	now := time.Now()
+	total, err := c.UserTokenRepository.CountSince(tx, user.ID, purpose, now.Add(-userTokenRequestWindow))
+	if err != nil {
+		c.Log.Errorf("issueToken - UserTokenRepository.CountSince error: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+	if total >= userTokenRequestLimit {
+		return "", fiber.ErrTooManyRequests
+	}
+
+	if err := c.UserTokenRepository.InvalidateActive(tx, user.ID, purpose, now); err != nil {
+		c.Log.Errorf("issueToken - UserTokenRepository.InvalidateActive error: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+
+	token, err := newUserToken()
+	if err != nil {
+		c.Log.Errorf("issueToken - newUserToken error: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+
+	userToken := &entity.UserToken{
+		UserID:      user.ID,
+		Purpose:     purpose,
+		Email:       user.Email,
+		TokenHash:   util.HashToken(token),
+		RequestedIP: ip,
+		ExpiresAt:   now.Add(ttl),
+	}
+	if err := c.UserTokenRepository.Create(tx, userToken); err != nil {
+		c.Log.Errorf("issueToken - UserTokenRepository.Create error: %v", err)
+		return "", fiber.ErrInternalServerError
+	}
+	return token, nil
+}
+
+// consumeToken tandai token terpakai (sekali pakai) dan return user pemiliknya.
+// Token yang tidak ada, sudah dipakai, kedaluwarsa, atau email user sudah berubah ditolak
+func (c *AccountUseCase) consumeToken(tx *gorm.DB, purpose, token string, now time.Time) (*entity.User, error) {
+	userToken, err := c.UserTokenRepository.FindByHash(tx, purpose, util.HashToken(token))
+	if err != nil {
+		c.Log.Errorf("consumeToken - UserTokenRepository.FindByHash error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if userToken == nil {
+		c.Log.Warnf("consumeToken - Unknown %s token", purpose)
+		return nil, errInvalidUserToken
+	}
+
+	consumed, err := c.UserTokenRepository.Consume(tx, userToken.ID, now)
+	if err != nil {
+		c.Log.Errorf("consumeToken - UserTokenRepository.Consume error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !consumed {
+		c.Log.Warnf("consumeToken - %s token %d already used or expired", purpose, userToken.ID)
+		return nil, errInvalidUserToken
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, userToken.UserID); err != nil {
+		c.Log.Errorf("consumeToken - UserRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if user.Email != userToken.Email {
+		c.Log.Warnf("consumeToken - Email of user %d changed since %s token was issued", user.ID, purpose)
+		return nil, errInvalidUserToken
+	}
+	return user, nil
+}
+
+// sendMail kirim email di background agar waktu respons tidak bergantung ke server mail
+func (c *AccountUseCase) sendMail(message *mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+		defer cancel()
+		if err := c.Mail.Send(ctx, message); err != nil {
+			c.Log.Errorf("sendMail - Failed to send %q to %s: %v", message.Subject, message.To, err)
+		}
+	}()
+}
+
+// newUserToken token acak 32 byte dalam hex (64 karakter)
+func newUserToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// formatTTL durasi untuk teks email, misal "1 hour" atau "24 hours"
+func formatTTL(ttl time.Duration) string {
+	hours := int(ttl / time.Hour)
+	if hours == 1 {
+		return "1 hour"
+	}
+	if hours > 1 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d minutes", int(ttl/time.Minute))
+}
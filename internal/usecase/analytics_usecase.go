@@ -0,0 +1,359 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	analyticsMaxBuckets     = 1000 // batas jumlah titik series untuk bucket_minutes dari request
+	analyticsMaxAutoBuckets = 120  // target jumlah titik series saat bucket dipilih otomatis
+)
+
+// analyticsBucketSteps pilihan lebar bucket otomatis (menit)
+var analyticsBucketSteps = []int{1, 5, 15, 30, 60, 180, 360, 720, 1440}
+
+// xpDistributionBounds batas bawah tiap bucket sebaran XP, bucket terakhir tanpa batas atas
+var xpDistributionBounds = []int{0, 1, 10, 50, 100, 250, 500}
+
+type AnalyticsUseCase struct {
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	RoomRepository              *repository.RoomRepository
+	ParticipantRepository       *repository.ParticipantRepository
+	PollRepository              *repository.PollRepository
+	AnalyticsRepository         *repository.AnalyticsRepository
+	RoomPresenceEventRepository *repository.RoomPresenceEventRepository
+}
+
+// NewAnalyticsUseCase create new instance of AnalyticsUseCase
+func NewAnalyticsUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, roomRepository *repository.RoomRepository,
+	participantRepository *repository.ParticipantRepository, pollRepository *repository.PollRepository,
+	analyticsRepository *repository.AnalyticsRepository, roomPresenceEventRepository *repository.RoomPresenceEventRepository) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+		RoomRepository:              roomRepository,
+		ParticipantRepository:       participantRepository,
+		PollRepository:              pollRepository,
+		AnalyticsRepository:         analyticsRepository,
+		RoomPresenceEventRepository: roomPresenceEventRepository,
+	}
+}
+
+// RecordPresence mencatat participant online / offline ke presence history
+func (c *AnalyticsUseCase) RecordPresence(ctx context.Context, request *model.RecordPresenceRequest) error {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("RecordPresence - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	event := &entity.RoomPresenceEvent{
+		RoomID:        request.RoomID,
+		ParticipantID: request.ParticipantID,
+		Event:         request.Event,
+		OnlineCount:   request.OnlineCount,
+		CreatedAt:     request.At, // waktu dari hub, insert berjalan async sehingga urutan insert tidak dijamin
+	}
+	if err := c.RoomPresenceEventRepository.Create(c.DB.WithContext(ctx), event); err != nil {
+		c.Log.Errorf("RecordPresence - RoomPresenceEventRepository.Create error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// Get usecase analytics room (owner only): kehadiran, chat, Q&A, poll dan sebaran XP
+func (c *AnalyticsUseCase) Get(ctx context.Context, request *model.GetRoomAnalyticsRequest) (*model.RoomAnalyticsResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("GetAnalytics - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, request.RoomID, request.PresenterID)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - RoomRepository.FindByIdAndPresenterId error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	// rentang waktu: sejak room dibuat sampai ditutup / sekarang
+	from := room.CreatedAt
+	to := time.Now()
+	if room.ClosedAt != nil {
+		to = *room.ClosedAt
+	}
+	if to.Before(from) {
+		to = from
+	}
+
+	bucketMinutes := request.BucketMinutes
+	if bucketMinutes == 0 {
+		bucketMinutes = ChooseBucketMinutes(to.Sub(from))
+	}
+	bucketSeconds := int64(bucketMinutes) * 60
+	firstBucket := from.Unix() / bucketSeconds
+	bucketCount := int(to.Unix()/bucketSeconds-firstBucket) + 1
+	if bucketCount > analyticsMaxBuckets {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "bucket_minutes is too small for the room duration")
+	}
+
+	series := make([]model.AnalyticsBucket, bucketCount)
+	for i := range series {
+		series[i].Start = time.Unix((firstBucket+int64(i))*bucketSeconds, 0).UTC()
+	}
+	bucketIndex := func(bucket int64) int {
+		idx := int(bucket - firstBucket)
+		if idx < 0 {
+			return 0
+		}
+		if idx >= bucketCount {
+			return bucketCount - 1
+		}
+		return idx
+	}
+
+	// kehadiran dari presence history
+	events, err := c.RoomPresenceEventRepository.ListByRoomID(tx, room.ID)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - RoomPresenceEventRepository.ListByRoomID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	participants := FillPresenceSeries(series, events, func(t time.Time) int {
+		return bucketIndex(t.Unix() / bucketSeconds)
+	})
+
+	totalParticipants, err := c.ParticipantRepository.CountByRoomID(tx, room.ID)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - ParticipantRepository.CountByRoomID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	participants.Total = int(totalParticipants)
+
+	// chat dan question per bucket
+	messageBuckets, err := c.AnalyticsRepository.GetMessageBuckets(tx, room.ID, int(bucketSeconds))
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+	totalMessages := 0
+	for _, b := range messageBuckets {
+		series[bucketIndex(b.Bucket)].Messages += b.Count
+		totalMessages += b.Count
+	}
+
+	questionBuckets, err := c.AnalyticsRepository.GetQuestionBuckets(tx, room.ID, int(bucketSeconds))
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+	for _, b := range questionBuckets {
+		series[bucketIndex(b.Bucket)].Questions += b.Count
+	}
+
+	questionStats, err := c.AnalyticsRepository.GetQuestionStats(tx, room.ID)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - AnalyticsRepository.GetQuestionStats error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	polls, err := c.pollParticipation(tx, room.ID, participants.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := c.ParticipantRepository.ListRanking(tx, room.ID)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - ParticipantRepository.ListRanking error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	scores := make([]int, len(ranking))
+	for i, p := range ranking {
+		scores[i] = p.XPScore
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("GetAnalytics - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	minutes := math.Max(to.Sub(from).Minutes(), 1)
+	response := &model.RoomAnalyticsResponse{
+		RoomID:        room.ID,
+		From:          from,
+		To:            to,
+		BucketMinutes: bucketMinutes,
+		Participants:  participants,
+		Messages: model.MessageAnalytics{
+			Total:     totalMessages,
+			PerMinute: roundAnalytics(float64(totalMessages) / minutes),
+		},
+		Questions: model.QuestionAnalytics{
+			Total:    questionStats.Total,
+			Answered: questionStats.Answered,
+			Upvotes:  questionStats.Upvotes,
+		},
+		Polls:  polls,
+		XP:     ComputeXPDistribution(scores),
+		Series: series,
+	}
+	if questionStats.Total > 0 {
+		response.Questions.UpvotesPerQuestion = roundAnalytics(float64(questionStats.Upvotes) / float64(questionStats.Total))
+	}
+	return response, nil
+}
+
+// pollParticipation jumlah voter tiap poll non-draft dibanding total participant room
+func (c *AnalyticsUseCase) pollParticipation(tx *gorm.DB, roomID uint, totalParticipants int) (model.PollAnalytics, error) {
+	result := model.PollAnalytics{Items: make([]model.PollParticipation, 0)}
+
+	polls, _, err := c.PollRepository.GetPollsByRoomID(tx, roomID, "all", -1, false)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - PollRepository.GetPollsByRoomID error: %v", err)
+		return result, fiber.ErrInternalServerError
+	}
+
+	pollIDs := make([]uint, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+	voters, err := c.PollRepository.GetTotalVotesByPollIDs(tx, pollIDs)
+	if err != nil {
+		c.Log.Errorf("GetAnalytics - PollRepository.GetTotalVotesByPollIDs error: %v", err)
+		return result, fiber.ErrInternalServerError
+	}
+
+	sumRate := 0.0
+	for i := len(polls) - 1; i >= 0; i-- {
+		poll := polls[i]
+		item := model.PollParticipation{
+			PollID:   poll.ID,
+			Question: poll.Question,
+			Type:     poll.Type,
+			Status:   poll.Status,
+			Voters:   voters[poll.ID],
+		}
+		if totalParticipants > 0 {
+			item.ParticipationRate = roundAnalytics(float64(item.Voters) / float64(totalParticipants))
+		}
+		sumRate += item.ParticipationRate
+		result.Items = append(result.Items, item)
+	}
+
+	result.Total = len(result.Items)
+	if result.Total > 0 {
+		result.AverageParticipationRate = roundAnalytics(sumRate / float64(result.Total))
+	}
+	return result, nil
+}
+
+// ChooseBucketMinutes lebar bucket terkecil yang menghasilkan paling banyak analyticsMaxAutoBuckets titik
+func ChooseBucketMinutes(duration time.Duration) int {
+	for _, step := range analyticsBucketSteps {
+		if int(duration.Minutes())/step < analyticsMaxAutoBuckets {
+			return step
+		}
+	}
+	return analyticsBucketSteps[len(analyticsBucketSteps)-1]
+}
+
+// FillPresenceSeries isi joins, leaves dan online di series dari presence event (urut kronologis).
+// Online tiap bucket = jumlah online tertinggi di bucket, termasuk yang terbawa dari bucket sebelumnya.
+func FillPresenceSeries(series []model.AnalyticsBucket, events []entity.RoomPresenceEvent, indexOf func(time.Time) int) model.ParticipantAnalytics {
+	var result model.ParticipantAnalytics
+	lastOnline := make(map[int]int)
+
+	for _, event := range events {
+		idx := indexOf(event.CreatedAt)
+		switch event.Event {
+		case model.PresenceEventJoin:
+			series[idx].Joins++
+		case model.PresenceEventLeave:
+			series[idx].Leaves++
+		}
+		if event.OnlineCount > series[idx].Online {
+			series[idx].Online = event.OnlineCount
+		}
+		lastOnline[idx] = event.OnlineCount
+
+		if event.OnlineCount > result.PeakConcurrent {
+			peakAt := event.CreatedAt
+			result.PeakConcurrent = event.OnlineCount
+			result.PeakAt = &peakAt
+		}
+	}
+
+	carry := 0
+	for i := range series {
+		if carry > series[i].Online {
+			series[i].Online = carry
+		}
+		if online, ok := lastOnline[i]; ok {
+			carry = online
+		}
+	}
+	return result
+}
+
+// ComputeXPDistribution rata-rata, median, max dan histogram xp_score
+func ComputeXPDistribution(scores []int) model.XPDistribution {
+	distribution := model.XPDistribution{Buckets: make([]model.XPBucket, len(xpDistributionBounds))}
+	for i, lower := range xpDistributionBounds {
+		distribution.Buckets[i].Min = lower
+		if i+1 < len(xpDistributionBounds) {
+			upper := xpDistributionBounds[i+1] - 1
+			distribution.Buckets[i].Max = &upper
+		}
+	}
+	if len(scores) == 0 {
+		return distribution
+	}
+
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+
+	sum := 0
+	for _, score := range sorted {
+		sum += score
+
+		// skor negatif (XP ditarik moderator) masuk bucket pertama
+		idx := 0
+		for i := len(xpDistributionBounds) - 1; i > 0; i-- {
+			if score >= xpDistributionBounds[i] {
+				idx = i
+				break
+			}
+		}
+		distribution.Buckets[idx].Count++
+	}
+
+	n := len(sorted)
+	distribution.Average = roundAnalytics(float64(sum) / float64(n))
+	distribution.Max = sorted[n-1]
+	if n%2 == 1 {
+		distribution.Median = float64(sorted[n/2])
+	} else {
+		distribution.Median = float64(sorted[n/2-1]+sorted[n/2]) / 2
+	}
+	return distribution
+}
+
+// roundAnalytics bulatkan ke 2 angka di belakang koma
+func roundAnalytics(value float64) float64 {
+	return math.Round(value*100) / 100
+}
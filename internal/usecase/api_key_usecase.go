@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// APIKeyUsageInterval last_used_at hanya diupdate sekali per interval (kecuali IP berubah)
+	APIKeyUsageInterval = time.Minute
+
+	maxAPIKeysPerUser = 20
+	apiKeyPrefixLen   = 12 // "rsk_" + 8 karakter pertama, ditampilkan di list
+)
+
+type APIKeyUseCase struct {
+	DB               *gorm.DB
+	Log              *logrus.Logger
+	Validate         *validator.Validate
+	APIKeyRepository *repository.APIKeyRepository
+	UserRepository   *repository.UserRepository
+}
+
+// NewAPIKeyUseCase create new instance of APIKeyUseCase
+func NewAPIKeyUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, apiKeyRepository *repository.APIKeyRepository, userRepository *repository.UserRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		DB:               db,
+		Log:              log,
+		Validate:         validate,
+		APIKeyRepository: apiKeyRepository,
+		UserRepository:   userRepository,
+	}
+}
+
+// Create usecase untuk membuat API key, key mentah hanya dikembalikan di response ini
+func (c *APIKeyUseCase) Create(ctx context.Context, request *model.CreateAPIKeyRequest) (*model.APIKeyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("CreateAPIKey - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+	total, err := c.APIKeyRepository.CountActiveByUserID(tx, request.UserID, now)
+	if err != nil {
+		c.Log.Errorf("CreateAPIKey - APIKeyRepository.CountActiveByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total >= maxAPIKeysPerUser {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "API key limit reached")
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		c.Log.Errorf("CreateAPIKey - newAPIKey error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	apiKey := &entity.APIKey{
+		UserID:  request.UserID,
+		Name:    request.Name,
+		Prefix:  key[:apiKeyPrefixLen],
+		KeyHash: HashAPIKey(key),
+		Scopes:  uniqueStrings(request.Scopes),
+	}
+	if request.ExpiresInDays > 0 {
+		expiresAt := now.AddDate(0, 0, request.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+	if err := c.APIKeyRepository.Create(tx, apiKey); err != nil {
+		c.Log.Errorf("CreateAPIKey - APIKeyRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("CreateAPIKey - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.APIKeyToResponse(apiKey)
+	response.Key = key
+	return response, nil
+}
+
+// List usecase untuk daftar API key milik user
+func (c *APIKeyUseCase) List(ctx context.Context, request *model.ListAPIKeysRequest) (*model.APIKeyListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListAPIKeys - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	apiKeys, err := c.APIKeyRepository.ListByUserID(tx, request.UserID)
+	if err != nil {
+		c.Log.Errorf("ListAPIKeys - APIKeyRepository.ListByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ListAPIKeys - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.APIKeysToListResponse(apiKeys), nil
+}
+
+// Revoke usecase untuk mencabut API key, token room yang dibuat dengan key ini ikut tidak berlaku
+func (c *APIKeyUseCase) Revoke(ctx context.Context, request *model.RevokeAPIKeyRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("RevokeAPIKey - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	apiKey, err := c.APIKeyRepository.FindByIdAndUserID(tx, request.APIKeyID, request.UserID)
+	if err != nil {
+		c.Log.Errorf("RevokeAPIKey - APIKeyRepository.FindByIdAndUserID error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if apiKey == nil {
+		return fiber.ErrNotFound
+	}
+
+	if err := c.APIKeyRepository.Revoke(tx, apiKey.ID, time.Now()); err != nil {
+		c.Log.Errorf("RevokeAPIKey - APIKeyRepository.Revoke error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("RevokeAPIKey - Commit error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// Authenticate usecase untuk API key dari header Authorization, mengembalikan auth level akun dengan scope key
+func (c *APIKeyUseCase) Authenticate(ctx context.Context, request *model.AuthenticateAPIKeyRequest) (*model.Auth, error) {
+	db := c.DB.WithContext(ctx)
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("AuthenticateAPIKey - Invalid request: %v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	apiKey, err := c.APIKeyRepository.FindByKeyHash(db, HashAPIKey(request.Key))
+	if err != nil {
+		c.Log.Errorf("AuthenticateAPIKey - APIKeyRepository.FindByKeyHash error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.use(db, apiKey, request.IP); err != nil {
+		return nil, err
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(db, user, apiKey.UserID); err != nil {
+		c.Log.Warnf("AuthenticateAPIKey - UserRepository.FindById error: %v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	return &model.Auth{
+		UserID:      &user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		Role:        user.Role,
+		IsAnonymous: false,
+		APIKeyID:    &apiKey.ID,
+		Scopes:      apiKey.Scopes,
+	}, nil
+}
+
+// Verify usecase untuk token room yang dibuat lewat API key: key harus masih berlaku
+func (c *APIKeyUseCase) Verify(ctx context.Context, request *model.VerifyAPIKeyRequest) error {
+	db := c.DB.WithContext(ctx)
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("VerifyAPIKey - Invalid request: %v", err)
+		return fiber.ErrUnauthorized
+	}
+
+	apiKey := new(entity.APIKey)
+	if err := c.APIKeyRepository.FindById(db, apiKey, request.APIKeyID); err != nil {
+		c.Log.Warnf("VerifyAPIKey - APIKeyRepository.FindById error: %v", err)
+		return fiber.ErrUnauthorized
+	}
+	return c.use(db, apiKey, request.IP)
+}
+
+// use cek key masih berlaku lalu catat pemakaian
+func (c *APIKeyUseCase) use(db *gorm.DB, apiKey *entity.APIKey, ip string) error {
+	now := time.Now()
+	if !IsAPIKeyUsable(apiKey, now) {
+		return fiber.ErrUnauthorized
+	}
+
+	// kegagalan mencatat pemakaian tidak menggagalkan request
+	if err := c.APIKeyRepository.TouchLastUsed(db, apiKey.ID, ip, now, now.Add(-APIKeyUsageInterval)); err != nil {
+		c.Log.Warnf("APIKey - APIKeyRepository.TouchLastUsed error: %v", err)
+	}
+	return nil
+}
+
+// IsAPIKeyUsable true jika key ada, belum dicabut dan belum kedaluwarsa
+func IsAPIKeyUsable(apiKey *entity.APIKey, now time.Time) bool {
+	if apiKey == nil || apiKey.RevokedAt != nil {
+		return false
+	}
+	return apiKey.ExpiresAt == nil || apiKey.ExpiresAt.After(now)
+}
+
+// HashAPIKey hash SHA-256 hex dari API key, key sudah acak 192 bit sehingga tidak perlu bcrypt
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAPIKey generate API key acak dengan prefix rsk_
+func newAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return model.APIKeyPrefix + hex.EncodeToString(b), nil
+}
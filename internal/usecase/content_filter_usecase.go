@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Aksi hasil filter konten, urut dari yang paling ringan
+const (
+	ContentFilterAllow    = "allow"
+	ContentFilterMask     = "mask"
+	ContentFilterModerate = "moderate"
+	ContentFilterReject   = "reject"
+)
+
+var contentFilterSeverity = map[string]int{
+	ContentFilterAllow:    0,
+	ContentFilterMask:     1,
+	ContentFilterModerate: 2,
+	ContentFilterReject:   3,
+}
+
+// linkPattern mendeteksi URL dan domain telanjang (contoh: bit.ly/abc)
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|id|co|me|ly|gg|xyz|info|biz|app|dev|link|site)\b(?:/\S*)?`)
+
+// ContentFilterResult hasil pipeline filter konten
+type ContentFilterResult struct {
+	Action  string // allow, mask, moderate, reject
+	Content string // konten setelah masking
+	Reason  string
+}
+
+// escalate naikkan aksi jika aksi baru lebih berat
+func (r *ContentFilterResult) escalate(action, reason string) {
+	if contentFilterSeverity[action] > contentFilterSeverity[r.Action] {
+		r.Action = action
+		r.Reason = reason
+	}
+}
+
+// ContentFilterUseCase usecase untuk filter konten chat dan question per room
+type ContentFilterUseCase struct {
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	Redis                       *redis.Client
+	RoomRepository              *repository.RoomRepository
+	ParticipantRepository       *repository.ParticipantRepository
+	RoomContentFilterRepository *repository.RoomContentFilterRepository
+}
+
+// NewContentFilterUseCase create new instance of ContentFilterUseCase
+func NewContentFilterUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, redis *redis.Client, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository, roomContentFilterRepository *repository.RoomContentFilterRepository) *ContentFilterUseCase {
+	return &ContentFilterUseCase{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+		Redis:                       redis,
+		RoomRepository:              roomRepository,
+		ParticipantRepository:       participantRepository,
+		RoomContentFilterRepository: roomContentFilterRepository,
+	}
+}
+
+// Get usecase untuk melihat konfigurasi filter konten room (presenter only)
+func (c *ContentFilterUseCase) Get(ctx context.Context, request *model.GetContentFilterRequest) (*model.ContentFilterResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Get - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, request.RoomID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("Get - Failed to find room: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	filter, err := c.RoomContentFilterRepository.FindByRoomID(tx, request.RoomID)
+	if err != nil {
+		c.Log.Warnf("Get - Failed to find content filter: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if filter == nil {
+		filter = defaultContentFilter(request.RoomID)
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Get - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ContentFilterToResponse(filter), nil
+}
+
+// Update usecase untuk mengganti konfigurasi filter konten room (presenter only)
+func (c *ContentFilterUseCase) Update(ctx context.Context, request *model.UpdateContentFilterRequest) (*model.ContentFilterResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Update - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, request.RoomID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("Update - Failed to find room: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	filter := &entity.RoomContentFilter{
+		RoomID:              request.RoomID,
+		Enabled:             request.Enabled,
+		BlockedWords:        normalizeBlockedWords(request.BlockedWords),
+		WordAction:          request.WordAction,
+		BlockLinks:          request.BlockLinks,
+		LinkAction:          request.LinkAction,
+		RepeatWindowSeconds: request.RepeatWindowSeconds,
+		RepeatAction:        request.RepeatAction,
+		FloodMaxMessages:    request.FloodMaxMessages,
+		FloodWindowSeconds:  request.FloodWindowSeconds,
+	}
+
+	if err = c.RoomContentFilterRepository.Upsert(tx, filter); err != nil {
+		c.Log.Warnf("Update - Failed to save content filter: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Update - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ContentFilterToResponse(filter), nil
+}
+
+// Screen jalankan pipeline filter (flood, repeat, link, word list) untuk konten baru sebelum disimpan.
+// canQueue false berarti pemanggil tidak punya antrian moderasi, sehingga aksi moderate diperlakukan sebagai reject.
+// Konten yang ditolak dikembalikan sebagai fiber error.
+func (c *ContentFilterUseCase) Screen(ctx context.Context, tx *gorm.DB, roomID, participantID uint, content string, canQueue bool) (*ContentFilterResult, error) {
+	return c.screen(ctx, tx, roomID, participantID, content, canQueue, true)
+}
+
+// ScreenEdit jalankan link dan word list saja untuk konten yang diedit (tanpa repeat / flood)
+func (c *ContentFilterUseCase) ScreenEdit(ctx context.Context, tx *gorm.DB, roomID, participantID uint, content string) (*ContentFilterResult, error) {
+	return c.screen(ctx, tx, roomID, participantID, content, false, false)
+}
+
+func (c *ContentFilterUseCase) screen(ctx context.Context, tx *gorm.DB, roomID, participantID uint, content string, canQueue, trackActivity bool) (*ContentFilterResult, error) {
+	allowed := &ContentFilterResult{Action: ContentFilterAllow, Content: content}
+
+	filter, err := c.RoomContentFilterRepository.FindByRoomID(tx, roomID)
+	if err != nil {
+		c.Log.Errorf("Screen - RoomContentFilterRepository.FindByRoomID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if filter == nil || !filter.Enabled {
+		return allowed, nil
+	}
+
+	// owner room tidak difilter
+	exempt, err := c.isRoomOwner(tx, roomID, participantID)
+	if err != nil {
+		c.Log.Errorf("Screen - isRoomOwner error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if exempt {
+		return allowed, nil
+	}
+
+	if trackActivity && c.isFlooding(ctx, filter, participantID) {
+		c.Log.Warnf("Screen - Participant %d is flooding room %d", participantID, roomID)
+		return nil, fiber.NewError(fiber.StatusTooManyRequests, "You are sending too fast, slow down")
+	}
+
+	result := FilterContent(filter, content)
+	if trackActivity && c.isRepeat(ctx, filter, participantID, content) {
+		result.escalate(filter.RepeatAction, "repeats a recent message")
+	}
+
+	if result.Action == ContentFilterReject || (result.Action == ContentFilterModerate && !canQueue) {
+		c.Log.Warnf("Screen - Content from participant %d rejected: %s", participantID, result.Reason)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Content blocked by filter: "+result.Reason)
+	}
+
+	return result, nil
+}
+
+// isRoomOwner cek apakah participant adalah presenter room
+func (c *ContentFilterUseCase) isRoomOwner(tx *gorm.DB, roomID, participantID uint) (bool, error) {
+	participant, err := c.ParticipantRepository.FindParticipantInRoom(tx, roomID, participantID)
+	if err != nil || participant == nil || participant.UserID == nil {
+		return false, err
+	}
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, roomID, *participant.UserID)
+	if err != nil {
+		return false, err
+	}
+	return room != nil, nil
+}
+
+// isFlooding hitung konten participant dalam window di Redis. Redis error = fail open.
+func (c *ContentFilterUseCase) isFlooding(ctx context.Context, filter *entity.RoomContentFilter, participantID uint) bool {
+	if c.Redis == nil || filter.FloodMaxMessages <= 0 {
+		return false
+	}
+
+	key := fmt.Sprintf("content_filter:flood:%d", participantID)
+	count, err := c.Redis.Incr(ctx, key).Result()
+	if err != nil {
+		c.Log.Warnf("Screen - Flood counter unavailable: %v", err)
+		return false
+	}
+	if count == 1 {
+		c.Redis.Expire(ctx, key, time.Duration(filter.FloodWindowSeconds)*time.Second)
+	}
+	return count > int64(filter.FloodMaxMessages)
+}
+
+// isRepeat cek apakah participant mengirim konten yang sama dalam window. Redis error = fail open.
+func (c *ContentFilterUseCase) isRepeat(ctx context.Context, filter *entity.RoomContentFilter, participantID uint, content string) bool {
+	if c.Redis == nil || filter.RepeatWindowSeconds <= 0 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(normalizeContent(content)))
+	key := fmt.Sprintf("content_filter:repeat:%d:%s", participantID, hex.EncodeToString(sum[:8]))
+	stored, err := c.Redis.SetNX(ctx, key, 1, time.Duration(filter.RepeatWindowSeconds)*time.Second).Result()
+	if err != nil {
+		c.Log.Warnf("Screen - Repeat tracker unavailable: %v", err)
+		return false
+	}
+	return !stored
+}
+
+// FilterContent jalankan aturan link dan word list terhadap konten. Aksi terberat yang menang,
+// masking tetap diterapkan ke konten.
+func FilterContent(filter *entity.RoomContentFilter, content string) *ContentFilterResult {
+	result := &ContentFilterResult{Action: ContentFilterAllow, Content: content}
+	if filter == nil || !filter.Enabled {
+		return result
+	}
+
+	if filter.BlockLinks && linkPattern.MatchString(result.Content) {
+		if filter.LinkAction == ContentFilterMask {
+			result.Content = linkPattern.ReplaceAllString(result.Content, "[link]")
+		}
+		result.escalate(filter.LinkAction, "contains a link")
+	}
+
+	if pattern := blockedWordsPattern(filter.BlockedWords); pattern != nil && pattern.MatchString(result.Content) {
+		if filter.WordAction == ContentFilterMask {
+			result.Content = pattern.ReplaceAllStringFunc(result.Content, func(word string) string {
+				return strings.Repeat("*", utf8.RuneCountInString(word))
+			})
+		}
+		result.escalate(filter.WordAction, "contains a blocked word")
+	}
+
+	return result
+}
+
+// blockedWordsPattern compile word list menjadi satu regexp case-insensitive yang match kata utuh
+func blockedWordsPattern(words []string) *regexp.Regexp {
+	alternatives := make([]string, 0, len(words))
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		alt := regexp.QuoteMeta(word)
+		first, _ := utf8.DecodeRuneInString(word)
+		last, _ := utf8.DecodeLastRuneInString(word)
+		if isWordRune(first) {
+			alt = `\b` + alt
+		}
+		if isWordRune(last) {
+			alt = alt + `\b`
+		}
+		alternatives = append(alternatives, alt)
+	}
+	if len(alternatives) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(alternatives, "|") + `)`)
+}
+
+// isWordRune karakter yang dianggap bagian kata oleh \b
+func isWordRune(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+// normalizeBlockedWords trim, lowercase, dan hapus duplikat
+func normalizeBlockedWords(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+		normalized = append(normalized, word)
+	}
+	return normalized
+}
+
+// normalizeContent lowercase dan rapikan spasi untuk deteksi pesan berulang
+func normalizeContent(content string) string {
+	return strings.ToLower(strings.Join(strings.Fields(content), " "))
+}
+
+// defaultContentFilter konfigurasi untuk room yang belum pernah mengatur filter
+func defaultContentFilter(roomID uint) *entity.RoomContentFilter {
+	return &entity.RoomContentFilter{
+		RoomID:             roomID,
+		BlockedWords:       []string{},
+		WordAction:         ContentFilterMask,
+		LinkAction:         ContentFilterReject,
+		RepeatAction:       ContentFilterReject,
+		FloodWindowSeconds: 10,
+	}
+}
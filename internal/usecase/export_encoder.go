@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"reisify/internal/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeRoomExport menulis dokumen export ke format json, csv (zip berisi satu file per bagian) atau html
+func EncodeRoomExport(export *model.RoomExport, format string) (*model.ExportFile, error) {
+	filename := "room-" + export.Room.RoomCode + "-export"
+
+	switch format {
+	case model.ExportFormatJSON:
+		body, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &model.ExportFile{Filename: filename + ".json", ContentType: "application/json", Body: body}, nil
+	case model.ExportFormatCSV:
+		body, err := encodeExportCSV(export)
+		if err != nil {
+			return nil, err
+		}
+		return &model.ExportFile{Filename: filename + ".zip", ContentType: "application/zip", Body: body}, nil
+	case model.ExportFormatHTML:
+		var buf bytes.Buffer
+		if err := exportReportTemplate.Execute(&buf, export); err != nil {
+			return nil, err
+		}
+		return &model.ExportFile{Filename: filename + ".html", ContentType: "text/html; charset=utf-8", Body: buf.Bytes()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// encodeExportCSV membuat zip berisi room.csv, questions.csv, replies.csv, polls.csv, chat.csv dan leaderboard.csv
+func encodeExportCSV(export *model.RoomExport) ([]byte, error) {
+	closedAt := ""
+	if export.Room.ClosedAt != nil {
+		closedAt = formatExportTime(*export.Room.ClosedAt)
+	}
+
+	questions := [][]string{{"id", "participant_id", "participant", "content", "status", "upvotes", "validated", "replies", "created_at"}}
+	replies := [][]string{{"id", "question_id", "participant_id", "participant", "is_presenter", "content", "created_at"}}
+	for _, q := range export.Questions {
+		questions = append(questions, []string{
+			formatExportID(q.ID), formatExportID(q.Participant.ID), q.Participant.DisplayName, q.Content, q.Status,
+			strconv.Itoa(q.UpvoteCount), strconv.FormatBool(q.IsValidatedByPresenter), strconv.Itoa(len(q.Replies)), formatExportTime(q.CreatedAt),
+		})
+		for _, r := range q.Replies {
+			replies = append(replies, []string{
+				formatExportID(r.ID), formatExportID(r.QuestionID), formatExportID(r.Participant.ID), r.Participant.DisplayName,
+				strconv.FormatBool(r.IsPresenter), r.Content, formatExportTime(r.CreatedAt),
+			})
+		}
+	}
+
+	// satu baris per option, open-text poll satu baris per term
+	polls := [][]string{{"poll_id", "question", "type", "status", "total_votes", "option", "votes", "percentage", "created_at"}}
+	for _, p := range export.Polls {
+		for _, opt := range p.Options {
+			polls = append(polls, []string{
+				formatExportID(p.ID), p.Question, p.Type, p.Status, strconv.Itoa(p.TotalVotes),
+				opt.OptionText, strconv.Itoa(opt.VoteCount), strconv.FormatFloat(opt.Percentage, 'f', 2, 64), formatExportTime(p.CreatedAt),
+			})
+		}
+		for _, term := range p.Terms {
+			polls = append(polls, []string{
+				formatExportID(p.ID), p.Question, p.Type, p.Status, strconv.Itoa(p.TotalVotes),
+				term.Term, strconv.Itoa(term.Count), "", formatExportTime(p.CreatedAt),
+			})
+		}
+	}
+
+	chat := [][]string{{"id", "participant_id", "participant", "content", "created_at", "edited_at"}}
+	for _, m := range export.Messages {
+		editedAt := ""
+		if m.EditedAt != nil {
+			editedAt = formatExportTime(*m.EditedAt)
+		}
+		chat = append(chat, []string{formatExportID(m.ID), formatExportID(m.Participant.ID), m.Participant.DisplayName, m.Content, formatExportTime(m.CreatedAt), editedAt})
+	}
+
+	leaderboard := [][]string{{"rank", "participant_id", "participant", "xp_score", "is_anonymous"}}
+	for _, e := range export.Leaderboard {
+		leaderboard = append(leaderboard, []string{
+			strconv.Itoa(e.Rank), formatExportID(e.Participant.ID), e.Participant.DisplayName, strconv.Itoa(e.XPScore), strconv.FormatBool(e.IsAnonymous),
+		})
+	}
+
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{"room.csv", [][]string{
+			{"id", "room_code", "title", "status", "created_at", "closed_at", "participants", "questions", "polls", "messages", "exported_at"},
+			{formatExportID(export.Room.ID), export.Room.RoomCode, export.Room.Title, export.Room.Status, formatExportTime(export.Room.CreatedAt), closedAt,
+				strconv.Itoa(export.Room.TotalParticipants), strconv.Itoa(export.Room.TotalQuestions), strconv.Itoa(export.Room.TotalPolls),
+				strconv.Itoa(export.Room.TotalMessages), formatExportTime(export.ExportedAt)},
+		}},
+		{"questions.csv", questions},
+		{"replies.csv", replies},
+		{"polls.csv", polls},
+		{"chat.csv", chat},
+		{"leaderboard.csv", leaderboard},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		cw := csv.NewWriter(w)
+		for _, row := range f.rows {
+			for i := range row {
+				row[i] = escapeCSVCell(row[i])
+			}
+			if err := cw.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeCSVCell mencegah formula injection saat csv dibuka di spreadsheet
+func escapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatExportID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportReportTemplate laporan html ringkas, siap dicetak ke PDF dari browser
+var exportReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"datetime": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Room.Title}} — Session report</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; margin: 2rem; font-size: 12pt; }
+  h1 { margin-bottom: 0; }
+  h2 { border-bottom: 1px solid #ccc; padding-bottom: .25rem; margin-top: 2rem; }
+  .meta { color: #666; margin-top: .25rem; }
+  table { border-collapse: collapse; width: 100%; margin-top: .5rem; }
+  th, td { border: 1px solid #ddd; padding: .3rem .5rem; text-align: left; vertical-align: top; }
+  th { background: #f5f5f5; }
+  .num { text-align: right; white-space: nowrap; }
+  .reply { color: #555; padding-left: 1.5rem; }
+  .poll { page-break-inside: avoid; margin-bottom: 1rem; }
+  @media print {
+    body { margin: 0; }
+    h2 { page-break-after: avoid; }
+    tr { page-break-inside: avoid; }
+  }
+</style>
+</head>
+<body>
+<h1>{{.Room.Title}}</h1>
+<p class="meta">Room {{.Room.RoomCode}} · {{.Room.Status}} · created {{datetime .Room.CreatedAt}}{{with .Room.ClosedAt}} · closed {{datetime .}}{{end}} · exported {{datetime .ExportedAt}}</p>
+
+<h2>Summary</h2>
+<table>
+  <tr><th>Participants</th><td class="num">{{.Room.TotalParticipants}}</td></tr>
+  <tr><th>Questions</th><td class="num">{{.Room.TotalQuestions}}</td></tr>
+  <tr><th>Polls</th><td class="num">{{.Room.TotalPolls}}</td></tr>
+  <tr><th>Chat messages</th><td class="num">{{.Room.TotalMessages}}</td></tr>
+</table>
+
+<h2>Questions</h2>
+{{if .Questions}}<table>
+  <tr><th>Question</th><th>Asked by</th><th>Status</th><th class="num">Upvotes</th></tr>
+  {{range .Questions}}<tr><td>{{.Content}}</td><td>{{.Participant.DisplayName}}</td><td>{{.Status}}{{if .IsValidatedByPresenter}} ✓{{end}}</td><td class="num">{{.UpvoteCount}}</td></tr>
+  {{range .Replies}}<tr><td class="reply" colspan="4">↳ {{.Participant.DisplayName}}{{if .IsPresenter}} (presenter){{end}}: {{.Content}}</td></tr>
+  {{end}}{{end}}
+</table>{{else}}<p>No questions.</p>{{end}}
+
+<h2>Polls</h2>
+{{range .Polls}}<div class="poll">
+<h3>{{.Question}}</h3>
+<p class="meta">{{.Type}} · {{.Status}} · {{.TotalVotes}} votes</p>
+{{if .Terms}}<table>
+  <tr><th>Term</th><th class="num">Count</th></tr>
+  {{range .Terms}}<tr><td>{{.Term}}</td><td class="num">{{.Count}}</td></tr>
+  {{end}}
+</table>{{else if .Options}}<table>
+  <tr><th>Option</th><th class="num">Votes</th><th class="num">%</th></tr>
+  {{range .Options}}<tr><td>{{.OptionText}}</td><td class="num">{{.VoteCount}}</td><td class="num">{{printf "%.1f" .Percentage}}</td></tr>
+  {{end}}
+</table>{{end}}
+</div>{{else}}<p>No polls.</p>{{end}}
+
+<h2>Leaderboard</h2>
+{{if .Leaderboard}}<table>
+  <tr><th class="num">#</th><th>Participant</th><th class="num">XP</th></tr>
+  {{range .Leaderboard}}<tr><td class="num">{{.Rank}}</td><td>{{.Participant.DisplayName}}</td><td class="num">{{.XPScore}}</td></tr>
+  {{end}}
+</table>{{else}}<p>No participants.</p>{{end}}
+
+<h2>Chat</h2>
+{{if .Messages}}<table>
+  <tr><th>Time</th><th>Participant</th><th>Message</th></tr>
+  {{range .Messages}}<tr><td>{{datetime .CreatedAt}}</td><td>{{.Participant.DisplayName}}</td><td>{{.Content}}{{if .EditedAt}} <em>(edited)</em>{{end}}</td></tr>
+  {{end}}
+</table>{{else}}<p>No chat messages.</p>{{end}}
+</body>
+</html>
+`))
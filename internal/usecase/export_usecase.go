@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// exportMaxItems batas jumlah item timeline yang dibaca untuk export chat
+const exportMaxItems = 100000
+
+type ExportUseCase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	RoomRepository          *repository.RoomRepository
+	ActivityRepository      *repository.ActivityRepository
+	QuestionRepository      *repository.QuestionRepository
+	QuestionReplyRepository *repository.QuestionReplyRepository
+	PollRepository          *repository.PollRepository
+	ParticipantRepository   *repository.ParticipantRepository
+}
+
+// NewExportUseCase create new instance of ExportUseCase
+func NewExportUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, roomRepository *repository.RoomRepository,
+	activityRepository *repository.ActivityRepository, questionRepository *repository.QuestionRepository,
+	questionReplyRepository *repository.QuestionReplyRepository, pollRepository *repository.PollRepository,
+	participantRepository *repository.ParticipantRepository) *ExportUseCase {
+	return &ExportUseCase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		RoomRepository:          roomRepository,
+		ActivityRepository:      activityRepository,
+		QuestionRepository:      questionRepository,
+		QuestionReplyRepository: questionReplyRepository,
+		PollRepository:          pollRepository,
+		ParticipantRepository:   participantRepository,
+	}
+}
+
+// Export usecase untuk export data sesi room (owner only) dalam format json, csv (zip) atau html
+func (c *ExportUseCase) Export(ctx context.Context, request *model.ExportRoomRequest) (*model.ExportFile, error) {
+	export, err := c.Build(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := EncodeRoomExport(export, request.Format)
+	if err != nil {
+		c.Log.Errorf("Export - EncodeRoomExport error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return file, nil
+}
+
+// Build mengumpulkan seluruh data room: question + reply, hasil poll, chat dan leaderboard lengkap
+func (c *ExportUseCase) Build(ctx context.Context, request *model.ExportRoomRequest) (*model.RoomExport, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Export - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// hanya owner room yang boleh export
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, request.RoomID, request.PresenterID)
+	if err != nil {
+		c.Log.Errorf("Export - RoomRepository.FindByIdAndPresenterId error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	questions, err := c.exportQuestions(tx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	polls, err := c.exportPolls(tx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := c.exportMessages(tx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := c.ParticipantRepository.ListRanking(tx, room.ID)
+	if err != nil {
+		c.Log.Errorf("Export - ParticipantRepository.ListRanking error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	leaderboard := make([]model.LeaderboardEntry, len(participants))
+	for i := range participants {
+		leaderboard[i] = *converter.ParticipantToLeaderboardEntry(&participants[i], i+1)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Export - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.RoomExport{
+		Room: model.RoomExportInfo{
+			ID:                room.ID,
+			RoomCode:          room.RoomCode,
+			Title:             room.Title,
+			Status:            room.Status,
+			CreatedAt:         room.CreatedAt,
+			ClosedAt:          room.ClosedAt,
+			TotalParticipants: len(leaderboard),
+			TotalQuestions:    len(questions),
+			TotalPolls:        len(polls),
+			TotalMessages:     len(messages),
+		},
+		ExportedAt:  time.Now(),
+		Questions:   questions,
+		Polls:       polls,
+		Messages:    messages,
+		Leaderboard: leaderboard,
+	}, nil
+}
+
+// exportQuestions mengambil question approved beserta reply, urut kronologis
+func (c *ExportUseCase) exportQuestions(tx *gorm.DB, roomID uint) ([]model.QuestionResponse, error) {
+	questions, err := c.QuestionRepository.List(tx, roomID, 0, "", "recent", 0, 0)
+	if err != nil {
+		c.Log.Errorf("Export - QuestionRepository.List error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	questionIDs := make([]uint, len(questions))
+	for i, q := range questions {
+		questionIDs[i] = q.ID
+	}
+
+	repliesMap := make(map[uint][]model.ReplyResponse)
+	if len(questionIDs) > 0 {
+		replies, err := c.QuestionReplyRepository.ListByQuestionIDs(tx, questionIDs)
+		if err != nil {
+			c.Log.Errorf("Export - QuestionReplyRepository.ListByQuestionIDs error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		for i := range replies {
+			repliesMap[replies[i].QuestionID] = append(repliesMap[replies[i].QuestionID], *converter.QuestionReplyToResponse(&replies[i]))
+		}
+	}
+
+	// List mengurutkan terbaru dulu, export ditulis kronologis
+	responses := make([]model.QuestionResponse, len(questions))
+	for i := range questions {
+		q := &questions[len(questions)-1-i]
+		responses[i] = *converter.QuestionToResponseWithParticipant(q, false)
+		responses[i].Replies = repliesMap[q.ID]
+	}
+	return responses, nil
+}
+
+// exportPolls mengambil hasil akhir semua poll non-draft, urut kronologis
+func (c *ExportUseCase) exportPolls(tx *gorm.DB, roomID uint) ([]model.PollExport, error) {
+	polls, _, err := c.PollRepository.GetPollsByRoomID(tx, roomID, "all", -1, false)
+	if err != nil {
+		c.Log.Errorf("Export - PollRepository.GetPollsByRoomID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	pollIDs := make([]uint, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+	totalVotes, err := c.PollRepository.GetTotalVotesByPollIDs(tx, pollIDs)
+	if err != nil {
+		c.Log.Errorf("Export - PollRepository.GetTotalVotesByPollIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	exports := make([]model.PollExport, len(polls))
+	for i := range polls {
+		poll := &polls[len(polls)-1-i]
+		exports[i] = model.PollExport{PollResponse: *converter.PollToResponseWithOptions(poll, totalVotes[poll.ID], nil)}
+
+		if poll.Type == model.PollTypeOpenText {
+			terms, err := c.PollRepository.GetTermFrequencies(tx, poll.ID, MaxWordCloudTerms)
+			if err != nil {
+				c.Log.Errorf("Export - PollRepository.GetTermFrequencies error: %v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+			exports[i].Terms = terms
+		}
+	}
+	return exports, nil
+}
+
+// exportMessages mengambil chat room dari timeline, urut kronologis
+func (c *ExportUseCase) exportMessages(tx *gorm.DB, roomID uint) ([]model.MessageExport, error) {
+	rawItems, err := c.ActivityRepository.GetTimelineRaw(tx, roomID, nil, nil, exportMaxItems)
+	if err != nil {
+		c.Log.Errorf("Export - ActivityRepository.GetTimelineRaw error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	messageIDs := make([]uint, 0)
+	for _, item := range rawItems {
+		if item.Type == model.ActivityTypeMessage {
+			messageIDs = append(messageIDs, item.ID)
+		}
+	}
+
+	messages := make([]model.MessageExport, 0, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return messages, nil
+	}
+
+	messagesMap, err := c.ActivityRepository.GetMessagesByIDs(tx, messageIDs)
+	if err != nil {
+		c.Log.Errorf("Export - ActivityRepository.GetMessagesByIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	for _, msg := range messagesMap {
+		messages = append(messages, model.MessageExport{
+			ID:          msg.ID,
+			Participant: *converter.ParticipantToInfo(&msg.Participant),
+			Content:     msg.Content,
+			CreatedAt:   msg.CreatedAt,
+			EditedAt:    msg.EditedAt,
+		})
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+			return messages[i].ID < messages[j].ID
+		}
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages, nil
+}
@@ -6,6 +6,7 @@ import (
 	"reisify/internal/model"
 	"reisify/internal/model/converter"
 	"reisify/internal/repository"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +14,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// MessageEditWindow batas waktu author boleh edit / hapus message miliknya
+const MessageEditWindow = 15 * time.Minute
+
 type MessageUseCase struct {
 	DB                    *gorm.DB
 	Validate              *validator.Validate
@@ -20,10 +24,12 @@ type MessageUseCase struct {
 	MessageRepository     *repository.MessageRepository
 	RoomRepository        *repository.RoomRepository
 	ParticipantRepository *repository.ParticipantRepository
+	RoomRoleRepository    *repository.RoomRoleRepository
 	XPTransactionUseCase  *XPTransactionUseCase
+	ContentFilterUseCase  *ContentFilterUseCase
 }
 
-func NewMessageUseCase(db *gorm.DB, validate *validator.Validate, log *logrus.Logger, messageRepository *repository.MessageRepository, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository, xpTransactionUseCase *XPTransactionUseCase) *MessageUseCase {
+func NewMessageUseCase(db *gorm.DB, validate *validator.Validate, log *logrus.Logger, messageRepository *repository.MessageRepository, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository, roomRoleRepository *repository.RoomRoleRepository, xpTransactionUseCase *XPTransactionUseCase, contentFilterUseCase *ContentFilterUseCase) *MessageUseCase {
 	return &MessageUseCase{
 		DB:                    db,
 		Validate:              validate,
@@ -31,7 +37,9 @@ func NewMessageUseCase(db *gorm.DB, validate *validator.Validate, log *logrus.Lo
 		MessageRepository:     messageRepository,
 		RoomRepository:        roomRepository,
 		ParticipantRepository: participantRepository,
+		RoomRoleRepository:    roomRoleRepository,
 		XPTransactionUseCase:  xpTransactionUseCase,
+		ContentFilterUseCase:  contentFilterUseCase,
 	}
 }
 
@@ -71,11 +79,28 @@ func (c *MessageUseCase) Send(ctx context.Context, request *model.SendMessageReq
 		return nil, fiber.ErrNotFound
 	}
 
+	// participant yang di-mute presenter tidak bisa chat
+	muted, err := c.ParticipantRepository.IsMuted(tx, request.ParticipantID)
+	if err != nil {
+		c.Log.Errorf("Send - ParticipantRepository.IsMuted Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if muted {
+		c.Log.Warnf("Send - Participant %d is muted", request.ParticipantID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are muted")
+	}
+
+	// filter konten room; chat tidak punya antrian moderasi
+	filtered, err := c.ContentFilterUseCase.Screen(ctx, tx, request.RoomID, request.ParticipantID, request.Content, false)
+	if err != nil {
+		return nil, err
+	}
+
 	// create message in repository
 	message := &entity.Message{
 		RoomID:        request.RoomID,
 		ParticipantID: request.ParticipantID,
-		Content:       request.Content,
+		Content:       filtered.Content,
 	}
 
 	err = c.MessageRepository.Create(tx, message)
@@ -152,3 +177,144 @@ func (c *MessageUseCase) List(ctx context.Context, request *model.GetMessagesReq
 	// return response
 	return converter.MessagesToMessageListResponse(messages, hasMore), nil
 }
+
+// Update usecase untuk edit message oleh author dalam MessageEditWindow
+func (c *MessageUseCase) Update(ctx context.Context, request *model.UpdateMessageRequest) (*model.MessageResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Errorf("Update - Validate Struct Error: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	message, err := c.MessageRepository.FindByIdWithParticipant(tx, request.MessageID)
+	if err != nil {
+		c.Log.Errorf("Update - MessageRepository.FindByIdWithParticipant Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if message == nil || message.RoomID != request.RoomID {
+		return nil, fiber.ErrNotFound
+	}
+
+	if message.ParticipantID != request.ParticipantID {
+		c.Log.Warnf("Update - Participant %d is not the author of message %d", request.ParticipantID, message.ID)
+		return nil, fiber.ErrForbidden
+	}
+	if time.Since(message.CreatedAt) > MessageEditWindow {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Edit window has expired")
+	}
+
+	filtered, err := c.ContentFilterUseCase.ScreenEdit(ctx, tx, request.RoomID, request.ParticipantID, request.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	message.Content = filtered.Content
+	message.EditedAt = &now
+	if err = c.MessageRepository.UpdateContent(tx, message); err != nil {
+		c.Log.Errorf("Update - MessageRepository.UpdateContent Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Update - Transaction Commit Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.MessageToResponse(message), nil
+}
+
+// Delete usecase untuk soft delete message.
+// Author boleh menghapus message miliknya dalam MessageEditWindow; owner, co-host dan moderator boleh menghapus
+// message apa pun dan XP message milik participant lain dibatalkan.
+func (c *MessageUseCase) Delete(ctx context.Context, request *model.DeleteMessageRequest) (*model.DeleteMessageResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Errorf("Delete - Validate Struct Error: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	message, err := c.MessageRepository.FindByIdWithParticipant(tx, request.MessageID)
+	if err != nil {
+		c.Log.Errorf("Delete - MessageRepository.FindByIdWithParticipant Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if message == nil || message.RoomID != request.RoomID {
+		return nil, fiber.ErrNotFound
+	}
+
+	isModerator, err := c.canModerate(tx, request.RoomID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	isAuthor := message.ParticipantID == request.ParticipantID
+	if !isAuthor && !isModerator {
+		c.Log.Warnf("Delete - Participant %d cannot delete message %d", request.ParticipantID, message.ID)
+		return nil, fiber.ErrForbidden
+	}
+	if !isModerator && time.Since(message.CreatedAt) > MessageEditWindow {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Delete window has expired")
+	}
+
+	deleted, err := c.MessageRepository.SoftDelete(tx, message, request.ParticipantID)
+	if err != nil {
+		c.Log.Errorf("Delete - MessageRepository.SoftDelete Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if deleted == 0 {
+		// sudah dihapus request lain sejak message dibaca, XP tidak dicabut dua kali
+		return nil, fiber.ErrNotFound
+	}
+
+	// hapus oleh moderator: XP yang didapat author dari message ini dibatalkan
+	byModerator := !isAuthor
+	xpRevoked := 0
+	if byModerator {
+		xpRevoked, err = c.XPTransactionUseCase.RevokeXPForMessage(tx, message.RoomID, message.ParticipantID, message.ID)
+		if err != nil {
+			c.Log.Errorf("Delete - RevokeXPForMessage Error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Delete - Transaction Commit Error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.MessageToDeleteResponse(message, request.ParticipantID, byModerator, xpRevoked), nil
+}
+
+// canModerate cek apakah participant adalah owner, co-host atau moderator room (role dibaca dari database)
+func (c *MessageUseCase) canModerate(tx *gorm.DB, roomID, participantID uint) (bool, error) {
+	participant, err := c.ParticipantRepository.FindParticipantInRoom(tx, roomID, participantID)
+	if err != nil {
+		c.Log.Errorf("canModerate - ParticipantRepository.FindParticipantInRoom Error: %v", err)
+		return false, fiber.ErrInternalServerError
+	}
+	if participant == nil {
+		return false, fiber.ErrForbidden
+	}
+	if participant.UserID == nil {
+		// anonymous tidak bisa punya role
+		return false, nil
+	}
+
+	var room entity.Room
+	if err = c.RoomRepository.FindById(tx, &room, roomID); err != nil {
+		c.Log.Errorf("canModerate - RoomRepository.FindById Error: %v", err)
+		return false, fiber.ErrInternalServerError
+	}
+
+	role, err := c.RoomRoleRepository.GetRole(tx, &room, *participant.UserID)
+	if err != nil {
+		c.Log.Errorf("canModerate - RoomRoleRepository.GetRole Error: %v", err)
+		return false, fiber.ErrInternalServerError
+	}
+	return model.RoomRoleCanModerate(role), nil
+}
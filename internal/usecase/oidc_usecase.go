@@ -0,0 +1,366 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/oidc"
+	"reisify/internal/repository"
+	"reisify/internal/util"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// OIDCStateTTL batas waktu antara redirect ke provider dan callback
+	OIDCStateTTL = 10 * time.Minute
+
+	maxUsernameAttempts = 20
+)
+
+// oidcLoginState data login yang disimpan di Redis per state sampai callback
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	Redirect     string `json:"redirect"`
+}
+
+type OIDCUseCase struct {
+	DB                     *gorm.DB
+	Log                    *logrus.Logger
+	Validate               *validator.Validate
+	Redis                  *redis.Client
+	Provider               *oidc.Provider // nil jika SSO tidak dikonfigurasi
+	UserRepository         *repository.UserRepository
+	UserIdentityRepository *repository.UserIdentityRepository
+	TokenUtil              *util.TokenUtil
+	DefaultRole            string // role user baru hasil auto-provisioning
+}
+
+// NewOIDCUseCase create new instance of OIDCUseCase
+func NewOIDCUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, redis *redis.Client, provider *oidc.Provider, userRepository *repository.UserRepository, userIdentityRepository *repository.UserIdentityRepository, tokenUtil *util.TokenUtil, defaultRole string) *OIDCUseCase {
+	if defaultRole != "admin" {
+		defaultRole = "presenter"
+	}
+	return &OIDCUseCase{
+		DB:                     db,
+		Log:                    log,
+		Validate:               validate,
+		Redis:                  redis,
+		Provider:               provider,
+		UserRepository:         userRepository,
+		UserIdentityRepository: userIdentityRepository,
+		TokenUtil:              tokenUtil,
+		DefaultRole:            defaultRole,
+	}
+}
+
+// Login usecase untuk mulai login SSO: buat state, nonce dan PKCE code_verifier lalu return url provider
+func (c *OIDCUseCase) Login(ctx context.Context, request *model.OIDCLoginRequest) (*model.OIDCLoginResponse, error) {
+	if c.Provider == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "SSO is not configured")
+	}
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("OIDCLogin - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	loginState := &oidcLoginState{Redirect: SafeRedirectPath(request.Redirect)}
+	state, err := oidc.RandomString()
+	if err == nil {
+		loginState.Nonce, err = oidc.RandomString()
+	}
+	if err == nil {
+		loginState.CodeVerifier, err = oidc.RandomString()
+	}
+	if err != nil {
+		c.Log.Errorf("OIDCLogin - RandomString error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	authURL, err := c.Provider.AuthCodeURL(ctx, state, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		c.Log.Errorf("OIDCLogin - Provider.AuthCodeURL error: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadGateway, "SSO provider is unavailable")
+	}
+
+	payload, err := json.Marshal(loginState)
+	if err != nil {
+		c.Log.Errorf("OIDCLogin - Marshal state error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.Redis.Set(ctx, oidcStateKey(state), payload, OIDCStateTTL).Err(); err != nil {
+		c.Log.Errorf("OIDCLogin - Redis.Set error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.OIDCLoginResponse{AuthURL: authURL, State: state}, nil
+}
+
+// Callback usecase untuk callback SSO: tukar code (dengan code_verifier), verifikasi id_token,
+// hubungkan atau buat user, lalu terbitkan session seperti login biasa
+func (c *OIDCUseCase) Callback(ctx context.Context, request *model.OIDCCallbackRequest) (*model.OIDCCallbackResponse, error) {
+	if c.Provider == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "SSO is not configured")
+	}
+	if request.Error != "" {
+		c.Log.Warnf("OIDCCallback - Provider returned error: %s", request.Error)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "SSO login was cancelled or denied")
+	}
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("OIDCCallback - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// state harus sama dengan cookie browser yang memulai login (cegah login CSRF)
+	if subtle.ConstantTimeCompare([]byte(request.State), []byte(request.CookieState)) != 1 {
+		c.Log.Warn("OIDCCallback - State does not match cookie")
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid SSO state")
+	}
+
+	// state sekali pakai
+	payload, err := c.Redis.GetDel(ctx, oidcStateKey(request.State)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		c.Log.Warn("OIDCCallback - Unknown or expired state")
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "SSO login expired, please try again")
+	}
+	if err != nil {
+		c.Log.Errorf("OIDCCallback - Redis.GetDel error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	loginState := new(oidcLoginState)
+	if err := json.Unmarshal(payload, loginState); err != nil {
+		c.Log.Errorf("OIDCCallback - Unmarshal state error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, err := c.Provider.Exchange(ctx, request.Code, loginState.CodeVerifier)
+	if err != nil {
+		c.Log.Warnf("OIDCCallback - Provider.Exchange error: %v", err)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "SSO login failed")
+	}
+	claims, err := c.Provider.VerifyIDToken(ctx, token.IDToken, loginState.Nonce)
+	if err != nil {
+		c.Log.Warnf("OIDCCallback - Provider.VerifyIDToken error: %v", err)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "SSO login failed")
+	}
+
+	user, err := c.resolveUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+		UserID:      &user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		Role:        user.Role,
+		IsAnonymous: false,
+	}, request.Client)
+	if err != nil {
+		c.Log.Errorf("OIDCCallback - Failed to create token: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.OIDCCallbackResponse{
+		Auth:     converter.UserToAuthResponse(user, tokens),
+		Redirect: loginState.Redirect,
+	}, nil
+}
+
+// resolveUser cari user dari identity (issuer, subject). Identity baru dihubungkan ke user dengan email
+// yang sama jika provider sudah memverifikasi email tersebut, selain itu user baru dibuat
+func (c *OIDCUseCase) resolveUser(ctx context.Context, claims *oidc.Claims) (*entity.User, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+	issuer := c.Provider.Issuer()
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	identity, err := c.UserIdentityRepository.FindByIssuerAndSubject(tx, issuer, claims.Subject)
+	if err != nil {
+		c.Log.Errorf("resolveUser - UserIdentityRepository.FindByIssuerAndSubject error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user := new(entity.User)
+	if identity != nil {
+		if err := c.UserRepository.FindById(tx, user, identity.UserID); err != nil {
+			c.Log.Errorf("resolveUser - UserRepository.FindById error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		identity.Email = email
+		identity.LastLoginAt = &now
+		if err := c.UserIdentityRepository.Update(tx, identity); err != nil {
+			c.Log.Errorf("resolveUser - UserIdentityRepository.Update error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	} else {
+		if email == "" {
+			c.Log.Warnf("resolveUser - Identity %s has no email claim", claims.Subject)
+			return nil, fiber.NewError(fiber.StatusForbidden, "SSO account has no email address")
+		}
+
+		existing, err := c.UserRepository.FindByEmail(tx, email)
+		if err != nil {
+			c.Log.Errorf("resolveUser - UserRepository.FindByEmail error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		switch {
+		case existing != nil && !claims.EmailVerified:
+			// email belum diverifikasi provider, jangan ambil alih akun lokal
+			c.Log.Warnf("resolveUser - Unverified SSO email matches existing user %d", existing.ID)
+			return nil, fiber.NewError(fiber.StatusConflict, "An account with this email already exists")
+		case existing != nil && existing.EmailVerifiedAt == nil:
+			// email akun lokal belum diverifikasi, bisa jadi didaftarkan orang lain sebelum pemilik email login SSO
+			c.Log.Warnf("resolveUser - SSO email matches unverified user %d", existing.ID)
+			return nil, fiber.NewError(fiber.StatusConflict, "An account with this email already exists, verify its email before signing in with SSO")
+		case existing != nil:
+			user = existing
+		default:
+			user, err = c.provisionUser(tx, claims, email, now)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		identity = &entity.UserIdentity{
+			UserID:      user.ID,
+			Issuer:      issuer,
+			Subject:     claims.Subject,
+			Email:       email,
+			LastLoginAt: &now,
+		}
+		if err := c.UserIdentityRepository.Create(tx, identity); err != nil {
+			c.Log.Errorf("resolveUser - UserIdentityRepository.Create error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// email yang diverifikasi provider dan sama dengan email akun ikut menandai akun terverifikasi
+	if claims.EmailVerified && user.EmailVerifiedAt == nil && strings.EqualFold(user.Email, email) {
+		user.EmailVerifiedAt = &now
+		if err := c.UserRepository.Update(tx, user); err != nil {
+			c.Log.Errorf("resolveUser - UserRepository.Update error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("resolveUser - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return user, nil
+}
+
+// provisionUser buat user baru dari claim id_token. Password diisi acak, user bisa set password lewat reset password
+func (c *OIDCUseCase) provisionUser(tx *gorm.DB, claims *oidc.Claims, email string, now time.Time) (*entity.User, error) {
+	username, err := c.availableUsername(tx, usernameCandidate(claims, email))
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newUserToken()
+	if err != nil {
+		c.Log.Errorf("provisionUser - newUserToken error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	password, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Errorf("provisionUser - GenerateFromPassword error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user := &entity.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: string(password),
+		Role:         c.DefaultRole,
+	}
+	if claims.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+	if err := c.UserRepository.Create(tx, user); err != nil {
+		c.Log.Errorf("provisionUser - UserRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return user, nil
+}
+
+// availableUsername username kandidat, diberi akhiran angka jika sudah dipakai
+func (c *OIDCUseCase) availableUsername(tx *gorm.DB, base string) (string, error) {
+	for i := 0; i < maxUsernameAttempts; i++ {
+		candidate := base
+		if i > 0 {
+			suffix := fmt.Sprintf("%d", i+1)
+			if len(candidate)+len(suffix) > 30 {
+				candidate = candidate[:30-len(suffix)]
+			}
+			candidate += suffix
+		}
+
+		existing, err := c.UserRepository.FindByUsername(tx, candidate)
+		if err != nil {
+			c.Log.Errorf("availableUsername - UserRepository.FindByUsername error: %v", err)
+			return "", fiber.ErrInternalServerError
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+
+	c.Log.Warnf("availableUsername - No free username for %s", base)
+	return "", fiber.NewError(fiber.StatusConflict, "Could not pick a username for this account")
+}
+
+// usernameCandidate username alfanumerik 3-30 karakter dari preferred_username, bagian lokal email, atau name
+func usernameCandidate(claims *oidc.Claims, email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	for _, source := range []string{claims.PreferredUsername, local, claims.Name} {
+		var b strings.Builder
+		for _, r := range source {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				b.WriteRune(unicode.ToLower(r))
+			}
+		}
+		candidate := b.String()
+		if len(candidate) > 30 {
+			candidate = candidate[:30]
+		}
+		if len(candidate) >= 3 {
+			return candidate
+		}
+	}
+	return "user"
+}
+
+// SafeRedirectPath hanya path relatif ("/..."), selain itu "/" agar callback tidak bisa dipakai sebagai open redirect
+func SafeRedirectPath(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.ContainsAny(redirect, "\\\r\n") {
+		return "/"
+	}
+	return redirect
+}
+
+// oidcStateKey key Redis untuk state login SSO
+func oidcStateKey(state string) string {
+	return "oidc_state:" + state
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Kick usecase untuk mengeluarkan participant dari room (owner, co-host atau moderator).
+// Semua token room milik participant di-invalidate; participant terdaftar masih bisa join ulang.
+func (c *ParticipantUseCase) Kick(ctx context.Context, request *model.ModerateParticipantRequest) (*model.ParticipantModerationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Kick - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	participant, err := c.findModerationTarget(tx, request.RoomID, request.ActorID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Kick - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = c.TokenUtil.InvalidateParticipantTokens(ctx, participant.ID); err != nil {
+		c.Log.Warnf("Kick - Failed to invalidate participant tokens: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ParticipantToModerationResponse(participant, "kicked", request.Reason), nil
+}
+
+// Ban usecase untuk mengeluarkan participant dan memblokir join ulang (owner, co-host atau moderator).
+// Participant terdaftar diblokir berdasarkan user id, anonymous berdasarkan fingerprint device.
+func (c *ParticipantUseCase) Ban(ctx context.Context, request *model.ModerateParticipantRequest) (*model.ParticipantModerationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Ban - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	participant, err := c.findModerationTarget(tx, request.RoomID, request.ActorID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ban := &entity.RoomBan{
+		RoomID:        request.RoomID,
+		ParticipantID: &participant.ID,
+		DisplayName:   participant.DisplayName,
+		Reason:        request.Reason,
+		BannedBy:      &request.ActorID,
+	}
+
+	var banned bool
+	switch {
+	case participant.UserID != nil:
+		ban.UserID = participant.UserID
+		banned, err = c.RoomBanRepository.IsUserBanned(tx, request.RoomID, *participant.UserID)
+	case participant.Fingerprint != nil:
+		ban.Fingerprint = participant.Fingerprint
+		banned, err = c.RoomBanRepository.IsFingerprintBanned(tx, request.RoomID, *participant.Fingerprint)
+	default:
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Participant has no user or device to ban, kick instead")
+	}
+	if err != nil {
+		c.Log.Warnf("Ban - Failed to check existing ban: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if banned {
+		return nil, fiber.NewError(fiber.StatusConflict, "Participant already banned")
+	}
+
+	if err = c.RoomBanRepository.Create(tx, ban); err != nil {
+		c.Log.Warnf("Ban - Failed to create ban: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Ban - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = c.TokenUtil.InvalidateParticipantTokens(ctx, participant.ID); err != nil {
+		c.Log.Warnf("Ban - Failed to invalidate participant tokens: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.ParticipantToModerationResponse(participant, "banned", request.Reason)
+	response.BanID = ban.ID
+	return response, nil
+}
+
+// ListBans usecase untuk daftar ban di room (owner, co-host atau moderator)
+func (c *ParticipantUseCase) ListBans(ctx context.Context, request *model.ListBansRequest) (*model.BanListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListBans - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if _, _, err := c.checkModerator(tx, request.RoomID, request.ActorID); err != nil {
+		return nil, err
+	}
+
+	bans, err := c.RoomBanRepository.ListByRoomID(tx, request.RoomID)
+	if err != nil {
+		c.Log.Warnf("ListBans - Failed to list bans: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("ListBans - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RoomBansToListResponse(bans), nil
+}
+
+// Unban usecase untuk menghapus ban sehingga participant bisa join lagi (owner, co-host atau moderator)
+func (c *ParticipantUseCase) Unban(ctx context.Context, request *model.UnbanRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Unban - Invalid request: %+v", err)
+		return fiber.ErrBadRequest
+	}
+
+	if _, _, err := c.checkModerator(tx, request.RoomID, request.ActorID); err != nil {
+		return err
+	}
+
+	ban, err := c.RoomBanRepository.FindByIdAndRoomID(tx, request.BanID, request.RoomID)
+	if err != nil {
+		c.Log.Warnf("Unban - Failed to find ban: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if ban == nil {
+		return fiber.ErrNotFound
+	}
+
+	if err = c.RoomBanRepository.Delete(tx, ban); err != nil {
+		c.Log.Warnf("Unban - Failed to delete ban: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Unban - Failed to commit transaction: %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// Mute usecase untuk melarang participant chat dan bertanya selama durasi tertentu (owner, co-host atau moderator)
+func (c *ParticipantUseCase) Mute(ctx context.Context, request *model.MuteParticipantRequest) (*model.ParticipantModerationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Mute - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	participant, err := c.findModerationTarget(tx, request.RoomID, request.ActorID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutedUntil := time.Now().Add(time.Duration(request.DurationSeconds) * time.Second)
+	if err = c.ParticipantRepository.UpdateMutedUntil(tx, participant.ID, &mutedUntil); err != nil {
+		c.Log.Warnf("Mute - Failed to update participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Mute - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	participant.MutedUntil = &mutedUntil
+	return converter.ParticipantToModerationResponse(participant, "muted", ""), nil
+}
+
+// Unmute usecase untuk membatalkan mute participant (owner, co-host atau moderator)
+func (c *ParticipantUseCase) Unmute(ctx context.Context, request *model.UnmuteParticipantRequest) (*model.ParticipantModerationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Unmute - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	participant, err := c.findModerationTarget(tx, request.RoomID, request.ActorID, request.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.ParticipantRepository.UpdateMutedUntil(tx, participant.ID, nil); err != nil {
+		c.Log.Warnf("Unmute - Failed to update participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("Unmute - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	participant.MutedUntil = nil
+	return converter.ParticipantToModerationResponse(participant, "unmuted", ""), nil
+}
+
+// checkModerator pastikan room ada dan user adalah owner, co-host atau moderator room.
+// Role dibaca dari database supaya role yang dicabut langsung tidak berlaku
+func (c *ParticipantUseCase) checkModerator(tx *gorm.DB, roomID, userID uint) (*entity.Room, string, error) {
+	room := new(entity.Room)
+	if err := c.RoomRepository.FindById(tx, room, roomID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find room: %+v", err)
+		return nil, "", fiber.ErrInternalServerError
+	}
+
+	role, err := c.RoomRoleRepository.GetRole(tx, room, userID)
+	if err != nil {
+		c.Log.Warnf("Failed to get room role: %+v", err)
+		return nil, "", fiber.ErrInternalServerError
+	}
+	if !model.RoomRoleCanModerate(role) {
+		return nil, "", fiber.ErrForbidden
+	}
+	return room, role, nil
+}
+
+// findModerationTarget cari participant yang akan dimoderasi. Owner room tidak bisa dimoderasi,
+// co-host dan moderator hanya bisa dimoderasi oleh owner
+func (c *ParticipantUseCase) findModerationTarget(tx *gorm.DB, roomID, actorID, participantID uint) (*entity.Participant, error) {
+	room, role, err := c.checkModerator(tx, roomID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := c.ParticipantRepository.FindParticipantInRoom(tx, roomID, participantID)
+	if err != nil {
+		c.Log.Warnf("Failed to find participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if participant == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	if participant.UserID != nil && *participant.UserID == room.PresenterID {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Cannot moderate the room owner")
+	}
+
+	if participant.UserID != nil && role != model.RoomRoleOwner {
+		targetRole, err := c.RoomRoleRepository.GetRole(tx, room, *participant.UserID)
+		if err != nil {
+			c.Log.Warnf("Failed to get room role: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if model.RoomRoleCanModerate(targetRole) {
+			return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner can moderate a co-host or moderator")
+		}
+	}
+
+	return participant, nil
+}
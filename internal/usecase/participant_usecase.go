@@ -21,10 +21,12 @@ type ParticipantUseCase struct {
 	ParticipantRepository *repository.ParticipantRepository
 	RoomRepository        *repository.RoomRepository
 	UserRepository        *repository.UserRepository
+	RoomBanRepository     *repository.RoomBanRepository
+	RoomRoleRepository    *repository.RoomRoleRepository
 	TokenUtil             *util.TokenUtil
 }
 
-func NewParticipantUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, participantRepository *repository.ParticipantRepository, roomRepository *repository.RoomRepository, userRepository *repository.UserRepository, tokenUtil *util.TokenUtil) *ParticipantUseCase {
+func NewParticipantUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, participantRepository *repository.ParticipantRepository, roomRepository *repository.RoomRepository, userRepository *repository.UserRepository, roomBanRepository *repository.RoomBanRepository, roomRoleRepository *repository.RoomRoleRepository, tokenUtil *util.TokenUtil) *ParticipantUseCase {
 	return &ParticipantUseCase{
 		DB:                    db,
 		Log:                   log,
@@ -32,6 +34,8 @@ func NewParticipantUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.
 		ParticipantRepository: participantRepository,
 		RoomRepository:        roomRepository,
 		UserRepository:        userRepository,
+		RoomBanRepository:     roomBanRepository,
+		RoomRoleRepository:    roomRoleRepository,
 		TokenUtil:             tokenUtil,
 	}
 }
@@ -78,6 +82,24 @@ func (c *ParticipantUseCase) Join(ctx context.Context, request *model.JoinRoomRe
 		return nil, fiber.ErrUnauthorized
 	}
 
+	// user yang di-ban tidak bisa join ulang
+	banned, err := c.RoomBanRepository.IsUserBanned(tx, roomExisting.ID, userExisting.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to check room ban: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if banned {
+		c.Log.Warnf("User %d is banned from room %d", userExisting.ID, roomExisting.ID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are banned from this room")
+	}
+
+	// role user di room (owner, co_host, moderator, participant) untuk dimasukkan ke token
+	roomRole, err := c.RoomRoleRepository.GetRole(tx, roomExisting, userExisting.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to get room role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	// check participant already join room
 	participantExisting, err := c.ParticipantRepository.FindByRoomIDAndUserID(tx, roomExisting.ID, userExisting.ID)
 	if err != nil {
@@ -102,7 +124,7 @@ func (c *ParticipantUseCase) Join(ctx context.Context, request *model.JoinRoomRe
 		}
 
 		// Generate new token with room context
-		token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+		tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 			UserID:        &userExisting.ID,
 			ParticipantID: &participantExisting.ID,
 			RoomID:        &roomExisting.ID,
@@ -112,13 +134,17 @@ func (c *ParticipantUseCase) Join(ctx context.Context, request *model.JoinRoomRe
 			Role:          role,
 			IsAnonymous:   *participantExisting.IsAnonymous,
 			IsRoomOwner:   isRoomOwner,
-		})
+			RoomRole:      roomRole,
+			APIKeyID:      request.APIKeyID,
+			Scopes:        request.Scopes,
+			SessionID:     request.SessionID,
+		}, request.Client)
 		if err != nil {
 			c.Log.Warnf("Failed to create token: %+v", err)
 			return nil, fiber.ErrInternalServerError
 		}
 
-		return converter.ParticipantToJoinRoomResponseWithRole(participantExisting, token, isRoomOwner), nil
+		return converter.ParticipantToJoinRoomResponseWithRole(participantExisting, tokens, roomRole), nil
 	}
 
 	anon := false
@@ -155,7 +181,7 @@ func (c *ParticipantUseCase) Join(ctx context.Context, request *model.JoinRoomRe
 	}
 
 	// generate new token (update jwt token with room context)
-	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+	tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 		UserID:        &userExisting.ID,
 		ParticipantID: &participant.ID,
 		RoomID:        &roomExisting.ID,
@@ -165,14 +191,18 @@ func (c *ParticipantUseCase) Join(ctx context.Context, request *model.JoinRoomRe
 		Role:          role,
 		IsAnonymous:   *participant.IsAnonymous,
 		IsRoomOwner:   isRoomOwner,
-	})
+		RoomRole:      roomRole,
+		APIKeyID:      request.APIKeyID,
+		Scopes:        request.Scopes,
+		SessionID:     request.SessionID,
+	}, request.Client)
 	if err != nil {
 		c.Log.Warnf("Failed to create token: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	// return response with room role
-	return converter.ParticipantToJoinRoomResponseWithRole(participant, token, isRoomOwner), nil
+	return converter.ParticipantToJoinRoomResponseWithRole(participant, tokens, roomRole), nil
 }
 
 // List usecase digunakan untuk mencari participant dalam room
@@ -222,6 +252,34 @@ func (c *ParticipantUseCase) List(ctx context.Context, request *model.ListPartic
 	return converter.ParticipantsToListResponse(responses), total, nil
 }
 
+// ListOnline usecase untuk data participant yang sedang online, ID yang bukan participant room diabaikan
+func (c *ParticipantUseCase) ListOnline(ctx context.Context, request *model.ListOnlineParticipantsRequest) (*model.ParticipantListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListOnline - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	participants, err := c.ParticipantRepository.ListByIDsInRoom(tx, request.RoomID, request.ParticipantIDs)
+	if err != nil {
+		c.Log.Warnf("ListOnline - Failed to list participants: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("ListOnline - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.ParticipantListItem, len(participants))
+	for i, participant := range participants {
+		responses[i] = converter.ParticipantToListItem(&participant)
+	}
+	return converter.ParticipantsToListResponse(responses), nil
+}
+
 func (c *ParticipantUseCase) Leaderboard(ctx context.Context, request *model.GetLeaderboardRequest) (*model.LeaderboardResponse, error) {
 	// begin transaction
 	tx := c.DB.WithContext(ctx).Begin()
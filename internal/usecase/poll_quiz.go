@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"math"
+	"reisify/internal/entity"
+	"time"
+)
+
+// XP points configuration for quiz
+const (
+	XPQuizCorrect    = 10 // XP untuk jawaban benar
+	XPQuizSpeedBonus = 10 // bonus maksimal untuk jawaban benar yang langsung dijawab
+)
+
+// DefaultQuizTimeLimit countdown default pertanyaan quiz (detik)
+const DefaultQuizTimeLimit = 30
+
+// QuizVoteGracePeriod toleransi latency jaringan setelah countdown habis
+const QuizVoteGracePeriod = 2 * time.Second
+
+// QuizPoints menghitung XP jawaban quiz. Jawaban salah tidak dapat XP, jawaban benar dapat
+// XPQuizCorrect plus bonus kecepatan yang turun linear dari XPQuizSpeedBonus ke 0 sepanjang countdown.
+func QuizPoints(correct bool, elapsed, limit time.Duration) int {
+	if !correct {
+		return 0
+	}
+	if limit <= 0 {
+		return XPQuizCorrect
+	}
+
+	remaining := float64(limit-elapsed) / float64(limit)
+	remaining = math.Max(0, math.Min(1, remaining))
+	return XPQuizCorrect + int(math.Round(remaining*XPQuizSpeedBonus))
+}
+
+// isCorrectSelection jawaban benar jika himpunan option yang dipilih sama persis dengan option yang benar
+func isCorrectSelection(options []entity.PollOption, selected []uint) bool {
+	correct := make(map[uint]bool)
+	for _, opt := range options {
+		if opt.IsCorrect {
+			correct[opt.ID] = true
+		}
+	}
+	if len(correct) == 0 || len(correct) != len(selected) {
+		return false
+	}
+	for _, id := range selected {
+		if !correct[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"math"
+	"reisify/internal/model"
+)
+
+// TallyInstantRunoff menghitung hasil ranked choice poll dengan instant-runoff voting.
+// Setiap putaran, ballot dihitung ke pilihan tertinggi yang belum tereliminasi. Option dengan
+// suara > 50% menang; jika tidak ada, option dengan suara terendah dieliminasi. Jika semua option
+// tersisa seri, tidak ada pemenang tunggal dan option tersebut dikembalikan di Tied.
+func TallyInstantRunoff(optionIDs []uint, ballots [][]uint) *model.RankedChoiceResults {
+	results := &model.RankedChoiceResults{Rounds: []model.RankedChoiceRound{}}
+
+	remaining := make(map[uint]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		remaining[id] = true
+	}
+
+	for round := 1; len(remaining) > 0; round++ {
+		counts := make(map[uint]int, len(remaining))
+		exhausted := 0
+		for _, ballot := range ballots {
+			counted := false
+			for _, optionID := range ballot {
+				if remaining[optionID] {
+					counts[optionID]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				exhausted++
+			}
+		}
+
+		// tallies mengikuti urutan option supaya output stabil
+		current := model.RankedChoiceRound{Round: round, Exhausted: exhausted}
+		active := 0
+		lowest := math.MaxInt
+		for _, id := range optionIDs {
+			if !remaining[id] {
+				continue
+			}
+			current.Tallies = append(current.Tallies, model.RankedChoiceTally{OptionID: id, Votes: counts[id]})
+			active += counts[id]
+			if counts[id] < lowest {
+				lowest = counts[id]
+			}
+		}
+
+		// belum ada suara sama sekali
+		if active == 0 {
+			results.Rounds = append(results.Rounds, current)
+			return results
+		}
+
+		for _, tally := range current.Tallies {
+			if tally.Votes*2 > active {
+				winner := tally.OptionID
+				results.Rounds = append(results.Rounds, current)
+				results.WinnerOptionID = &winner
+				return results
+			}
+		}
+
+		var eliminated []uint
+		for _, tally := range current.Tallies {
+			if tally.Votes == lowest {
+				eliminated = append(eliminated, tally.OptionID)
+			}
+		}
+
+		// semua option tersisa seri, tidak bisa dieliminasi lagi
+		if len(eliminated) == len(current.Tallies) {
+			results.Rounds = append(results.Rounds, current)
+			results.Tied = eliminated
+			return results
+		}
+
+		current.Eliminated = eliminated
+		results.Rounds = append(results.Rounds, current)
+		for _, id := range eliminated {
+			delete(remaining, id)
+		}
+	}
+
+	return results
+}
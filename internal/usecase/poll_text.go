@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords kata umum (English + Indonesian) yang dibuang dari jawaban open-text
+var stopWords = map[string]bool{
+	// English
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "from": true, "has": true, "have": true, "i": true, "in": true, "is": true,
+	"it": true, "its": true, "me": true, "my": true, "of": true, "on": true, "or": true, "so": true,
+	"that": true, "the": true, "this": true, "to": true, "very": true, "was": true, "we": true,
+	"were": true, "with": true, "you": true, "your": true,
+	// Indonesian
+	"ada": true, "adalah": true, "akan": true, "aku": true, "dan": true, "dari": true, "dengan": true,
+	"di": true, "ini": true, "itu": true, "juga": true, "ke": true, "kami": true, "kita": true,
+	"pada": true, "saya": true, "sangat": true, "untuk": true, "yang": true,
+}
+
+// NormalizeAnswer normalisasi jawaban open-text untuk word cloud: case folding, buang tanda baca,
+// rapikan spasi dan buang stop word. Hasil kosong berarti jawaban tidak punya kata yang bermakna.
+func NormalizeAnswer(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	// ganti semua karakter selain huruf, angka, apostrof dan tanda hubung dengan spasi
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '-' {
+			return r
+		}
+		return ' '
+	}, text)
+
+	words := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		word = strings.Trim(word, "'-")
+		if word == "" || stopWords[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
@@ -2,10 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reisify/internal/entity"
 	"reisify/internal/model"
 	"reisify/internal/model/converter"
 	"reisify/internal/repository"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +23,12 @@ const (
 	XPPollVote = 5 // XP untuk submit vote pada poll
 )
 
+// MaxWordCloudTerms jumlah maksimal term yang dikembalikan untuk word cloud
+const MaxWordCloudTerms = 100
+
+// MaxScheduledActivations jumlah maksimal draft poll yang diaktifkan scheduler per tick
+const MaxScheduledActivations = 50
+
 // PollUseCase usecase untuk poll operations
 type PollUseCase struct {
 	DB                      *gorm.DB
@@ -27,6 +38,8 @@ type PollUseCase struct {
 	RoomRepository          *repository.RoomRepository
 	ParticipantRepository   *repository.ParticipantRepository
 	XPTransactionRepository *repository.XPTransactionRepository
+	QuizRepository          *repository.QuizRepository
+	RoomRoleRepository      *repository.RoomRoleRepository
 }
 
 // NewPollUseCase create new instance of PollUseCase
@@ -38,6 +51,8 @@ func NewPollUseCase(
 	roomRepository *repository.RoomRepository,
 	participantRepository *repository.ParticipantRepository,
 	xpTransactionRepository *repository.XPTransactionRepository,
+	quizRepository *repository.QuizRepository,
+	roomRoleRepository *repository.RoomRoleRepository,
 ) *PollUseCase {
 	return &PollUseCase{
 		DB:                      db,
@@ -47,10 +62,12 @@ func NewPollUseCase(
 		RoomRepository:          roomRepository,
 		ParticipantRepository:   participantRepository,
 		XPTransactionRepository: xpTransactionRepository,
+		QuizRepository:          quizRepository,
+		RoomRoleRepository:      roomRoleRepository,
 	}
 }
 
-// Create usecase untuk membuat poll baru (presenter only)
+// Create usecase untuk membuat poll baru (owner atau co-host)
 func (c *PollUseCase) Create(ctx context.Context, request *model.CreatePollRequest) (*model.CreatePollResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -72,10 +89,10 @@ func (c *PollUseCase) Create(ctx context.Context, request *model.CreatePollReque
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// check if presenter is the owner of the room
-	if room.PresenterID != request.PresenterID {
-		c.Log.Warnf("Create - User %d is not the presenter of room %d", request.PresenterID, request.RoomID)
-		return nil, fiber.ErrForbidden
+	// check if user is the owner or a co-host of the room
+	if err := c.checkRoomHost(tx, &room, request.PresenterID); err != nil {
+		c.Log.Warnf("Create - User %d cannot host room %d", request.PresenterID, request.RoomID)
+		return nil, err
 	}
 
 	// check room is active
@@ -84,22 +101,53 @@ func (c *PollUseCase) Create(ctx context.Context, request *model.CreatePollReque
 		return nil, fiber.NewError(fiber.StatusBadRequest, "Room is not active")
 	}
 
+	// validasi spesifik tipe poll
+	if request.Type == "" {
+		request.Type = model.PollTypeSingleChoice
+	}
+	optionTexts, maxSelections, err := c.buildPollOptions(request)
+	if err != nil {
+		c.Log.Warnf("Create - Invalid %s poll: %v", request.Type, err)
+		return nil, err
+	}
+
+	// validasi pertanyaan quiz
+	if err := c.validateQuizQuestion(tx, request, len(optionTexts)); err != nil {
+		c.Log.Warnf("Create - Invalid quiz question: %v", err)
+		return nil, err
+	}
+
+	// jadwal aktivasi otomatis menjadikan poll draft
+	if request.ScheduledAt != nil {
+		if !request.ScheduledAt.After(time.Now()) {
+			c.Log.Warnf("Create - Scheduled time %v is not in the future", request.ScheduledAt)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Scheduled time must be in the future")
+		}
+		request.Draft = true
+	}
+
 	// create poll entity
+	now := time.Now()
 	poll := &entity.Poll{
-		RoomID:   request.RoomID,
-		Question: request.Question,
-		Status:   "active", // langsung active saat dibuat
+		RoomID:           request.RoomID,
+		Question:         request.Question,
+		Type:             request.Type,
+		MaxSelections:    maxSelections,
+		TimeLimitSeconds: request.TimeLimitSeconds,
+		Status:           "active", // langsung active saat dibuat, kecuali draft
+		ActivatedAt:      &now,
+	}
+	if request.Draft {
+		poll.Status = "draft"
+		poll.ActivatedAt = nil
+		poll.ScheduledAt = request.ScheduledAt
+	}
+	if request.QuizID != 0 {
+		poll.QuizID = &request.QuizID
 	}
 
 	// create poll options
-	options := make([]entity.PollOption, len(request.Options))
-	for i, optText := range request.Options {
-		options[i] = entity.PollOption{
-			OptionText: optText,
-			VoteCount:  0,
-			Order:      i + 1,
-		}
-	}
+	options := newPollOptions(optionTexts, request.CorrectOptions)
 
 	// save poll with options
 	if err := c.PollRepository.CreatePollWithOptions(tx, poll, options); err != nil {
@@ -112,9 +160,388 @@ func (c *PollUseCase) Create(ctx context.Context, request *model.CreatePollReque
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// draft hanya dilihat presenter, boleh menyertakan jawaban benar quiz
+	if poll.Status == "draft" {
+		return &model.CreatePollResponse{Poll: *converter.PollToDraftResponse(poll)}, nil
+	}
 	return converter.PollToCreateResponse(poll), nil
 }
 
+// Update usecase untuk edit draft poll (presenter only)
+func (c *PollUseCase) Update(ctx context.Context, request *model.UpdatePollRequest) (*model.PollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Update - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.getDraftPollForPresenter(tx, request.PollID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("Update - %v", err)
+		return nil, err
+	}
+
+	// bangun ulang definisi poll dengan perubahan, lalu validasi dengan aturan yang sama seperti Create
+	merged := &model.CreatePollRequest{
+		RoomID:           poll.RoomID,
+		PresenterID:      request.PresenterID,
+		Question:         poll.Question,
+		Type:             poll.Type,
+		MaxSelections:    poll.MaxSelections,
+		TimeLimitSeconds: poll.TimeLimitSeconds,
+	}
+	if poll.QuizID != nil {
+		merged.QuizID = *poll.QuizID
+	}
+	for i, option := range poll.Options {
+		merged.Options = append(merged.Options, option.OptionText)
+		if option.IsCorrect {
+			merged.CorrectOptions = append(merged.CorrectOptions, i)
+		}
+	}
+	if poll.Type == model.PollTypeRankedChoice || poll.Type == model.PollTypeRating {
+		merged.MaxSelections = 0
+	}
+
+	if request.Question != nil {
+		merged.Question = *request.Question
+	}
+	if request.Options != nil {
+		// index correct_options lama tidak berlaku lagi untuk option baru
+		if poll.QuizID != nil && request.CorrectOptions == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "correct_options are required when replacing quiz options")
+		}
+		merged.Options = request.Options
+		if poll.Type == model.PollTypeMultipleChoice {
+			merged.MaxSelections = 0
+		}
+	}
+	if request.MaxSelections != nil {
+		merged.MaxSelections = *request.MaxSelections
+	}
+	if request.CorrectOptions != nil {
+		merged.CorrectOptions = request.CorrectOptions
+	}
+	if request.TimeLimitSeconds != nil {
+		merged.TimeLimitSeconds = *request.TimeLimitSeconds
+	}
+
+	if err := c.Validator.Struct(merged); err != nil {
+		c.Log.Warnf("Update - Invalid poll after update: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+	optionTexts, maxSelections, err := c.buildPollOptions(merged)
+	if err != nil {
+		c.Log.Warnf("Update - Invalid %s poll: %v", merged.Type, err)
+		return nil, err
+	}
+	if err := c.validateQuizQuestion(tx, merged, len(optionTexts)); err != nil {
+		c.Log.Warnf("Update - Invalid quiz question: %v", err)
+		return nil, err
+	}
+
+	poll.Question = merged.Question
+	poll.MaxSelections = maxSelections
+	poll.TimeLimitSeconds = merged.TimeLimitSeconds
+	if request.Unschedule {
+		poll.ScheduledAt = nil
+	} else if request.ScheduledAt != nil {
+		if !request.ScheduledAt.After(time.Now()) {
+			c.Log.Warnf("Update - Scheduled time %v is not in the future", request.ScheduledAt)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Scheduled time must be in the future")
+		}
+		poll.ScheduledAt = request.ScheduledAt
+	}
+
+	if err := c.PollRepository.UpdateDraftPoll(tx, poll); err != nil {
+		c.Log.Errorf("Update - UpdateDraftPoll error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// draft belum punya response, option aman dibuat ulang
+	if request.Options != nil || request.CorrectOptions != nil {
+		options := newPollOptions(optionTexts, merged.CorrectOptions)
+		if err := c.PollRepository.ReplacePollOptions(tx, poll, options); err != nil {
+			c.Log.Errorf("Update - ReplacePollOptions error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	poll, err = c.PollRepository.GetPollByIDWithOptions(tx, request.PollID)
+	if err != nil {
+		c.Log.Errorf("Update - GetPollByIDWithOptions after update error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Update - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PollToDraftResponse(poll), nil
+}
+
+// ReorderOptions usecase untuk mengubah urutan option draft poll (presenter only)
+func (c *PollUseCase) ReorderOptions(ctx context.Context, request *model.ReorderPollOptionsRequest) (*model.PollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("ReorderOptions - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.getDraftPollForPresenter(tx, request.PollID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("ReorderOptions - %v", err)
+		return nil, err
+	}
+
+	// urutan option rating poll adalah nilai skala
+	if poll.Type == model.PollTypeRating {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Rating scale options cannot be reordered")
+	}
+
+	// option_ids harus berisi semua option poll tepat sekali
+	if len(request.OptionIDs) != len(poll.Options) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "option_ids must contain every option of the poll")
+	}
+	existing := make(map[uint]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		existing[option.ID] = true
+	}
+	for _, optionID := range request.OptionIDs {
+		if !existing[optionID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Option %d does not belong to this poll", optionID))
+		}
+	}
+
+	for i, optionID := range request.OptionIDs {
+		if err := c.PollRepository.UpdateOptionOrder(tx, optionID, i+1); err != nil {
+			c.Log.Errorf("ReorderOptions - UpdateOptionOrder error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	poll, err = c.PollRepository.GetPollByIDWithOptions(tx, request.PollID)
+	if err != nil {
+		c.Log.Errorf("ReorderOptions - GetPollByIDWithOptions error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ReorderOptions - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PollToDraftResponse(poll), nil
+}
+
+// Activate usecase untuk mengaktifkan draft poll (presenter only)
+func (c *PollUseCase) Activate(ctx context.Context, request *model.ActivatePollRequest) (*model.CreatePollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Activate - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.getDraftPollForPresenter(tx, request.PollID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("Activate - %v", err)
+		return nil, err
+	}
+	if request.RoomID != 0 && poll.RoomID != request.RoomID {
+		c.Log.Warnf("Activate - Poll %d does not belong to room %d", request.PollID, request.RoomID)
+		return nil, fiber.ErrNotFound
+	}
+
+	// check room is active
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, poll.RoomID); err != nil {
+		c.Log.Errorf("Activate - RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room.Status != "active" {
+		c.Log.Warnf("Activate - Room %d is not active", poll.RoomID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Room is not active")
+	}
+
+	response, err := c.activate(tx, poll)
+	if err != nil {
+		c.Log.Warnf("Activate - %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Activate - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// ActivateScheduled aktifkan draft poll yang jadwalnya sudah lewat, dipanggil berkala oleh scheduler
+func (c *PollUseCase) ActivateScheduled(ctx context.Context, now time.Time) ([]model.CreatePollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	pollIDs, err := c.PollRepository.GetDueScheduledPollIDs(tx, now, MaxScheduledActivations)
+	if err != nil {
+		c.Log.Errorf("ActivateScheduled - GetDueScheduledPollIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	activated := make([]model.CreatePollResponse, 0, len(pollIDs))
+	for _, pollID := range pollIDs {
+		// savepoint per poll: error satu poll tidak membatalkan aktivasi poll lain di batch yang sama
+		savepoint := fmt.Sprintf("activate_poll_%d", pollID)
+		if err := tx.SavePoint(savepoint).Error; err != nil {
+			c.Log.Errorf("ActivateScheduled - SavePoint error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		response, err := c.activateScheduled(tx, pollID)
+		if err == nil {
+			activated = append(activated, *response)
+			continue
+		}
+
+		if err := tx.RollbackTo(savepoint).Error; err != nil {
+			c.Log.Errorf("ActivateScheduled - RollbackTo error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		// error database dicoba lagi di tick berikutnya, jadwal hanya dihapus jika poll memang
+		// tidak bisa diaktifkan (mis. quiz sudah selesai) supaya tidak dicoba terus
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) || fiberErr.Code < 400 || fiberErr.Code >= 500 {
+			c.Log.Errorf("ActivateScheduled - Poll %d failed: %v", pollID, err)
+			continue
+		}
+
+		c.Log.Warnf("ActivateScheduled - Poll %d skipped: %v", pollID, err)
+		if err := c.PollRepository.UnschedulePoll(tx, pollID); err != nil {
+			c.Log.Errorf("ActivateScheduled - UnschedulePoll error: %v", err)
+			if err := tx.RollbackTo(savepoint).Error; err != nil {
+				c.Log.Errorf("ActivateScheduled - RollbackTo error: %v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ActivateScheduled - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return activated, nil
+}
+
+// activateScheduled aktifkan satu draft poll dari batch scheduler
+func (c *PollUseCase) activateScheduled(tx *gorm.DB, pollID uint) (*model.CreatePollResponse, error) {
+	poll, err := c.PollRepository.GetPollByIDWithOptions(tx, pollID)
+	if err != nil {
+		c.Log.Errorf("GetPollByIDWithOptions error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if poll == nil {
+		return nil, fiber.ErrNotFound
+	}
+	return c.activate(tx, poll)
+}
+
+// activate ubah status draft poll menjadi active, countdown quiz mulai dari sini
+func (c *PollUseCase) activate(tx *gorm.DB, poll *entity.Poll) (*model.CreatePollResponse, error) {
+	if poll.QuizID != nil {
+		quiz, err := c.QuizRepository.GetQuizByID(tx, *poll.QuizID)
+		if err != nil {
+			c.Log.Errorf("QuizRepository.GetQuizByID error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if quiz != nil && quiz.Status != "active" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Quiz is already finished")
+		}
+	}
+
+	if err := c.PollRepository.ActivatePoll(tx, poll); err != nil {
+		c.Log.Errorf("ActivatePoll error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// reload poll to get activated_at
+	activated, err := c.PollRepository.GetPollByIDWithOptions(tx, poll.ID)
+	if err != nil {
+		c.Log.Errorf("GetPollByIDWithOptions after activate error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return converter.PollToCreateResponse(activated), nil
+}
+
+// checkRoomHost pastikan user adalah owner atau co-host room
+func (c *PollUseCase) checkRoomHost(tx *gorm.DB, room *entity.Room, userID uint) error {
+	role, err := c.RoomRoleRepository.GetRole(tx, room, userID)
+	if err != nil {
+		c.Log.Errorf("RoomRoleRepository.GetRole error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !model.RoomRoleCanHost(role) {
+		return fiber.ErrForbidden
+	}
+	return nil
+}
+
+// getDraftPollForPresenter load dan lock draft poll, pastikan caller adalah owner atau co-host room
+func (c *PollUseCase) getDraftPollForPresenter(tx *gorm.DB, pollID, presenterID uint) (*entity.Poll, error) {
+	poll, err := c.PollRepository.GetPollByIDForUpdate(tx, pollID)
+	if err != nil {
+		c.Log.Errorf("GetPollByIDForUpdate error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if poll == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, poll.RoomID); err != nil {
+		c.Log.Errorf("RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.checkRoomHost(tx, &room, presenterID); err != nil {
+		return nil, err
+	}
+
+	if poll.Status != "draft" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Poll is not a draft")
+	}
+	return poll, nil
+}
+
+// newPollOptions buat entity option dari teks option, correct berisi index option yang benar (quiz)
+func newPollOptions(texts []string, correct []int) []entity.PollOption {
+	isCorrect := make(map[int]bool, len(correct))
+	for _, index := range correct {
+		isCorrect[index] = true
+	}
+
+	options := make([]entity.PollOption, len(texts))
+	for i, text := range texts {
+		options[i] = entity.PollOption{
+			OptionText: text,
+			VoteCount:  0,
+			Order:      i + 1,
+			IsCorrect:  isCorrect[i],
+		}
+	}
+	return options
+}
+
 // GetActivePolls usecase untuk mendapatkan active polls di room
 func (c *PollUseCase) GetActivePolls(ctx context.Context, request *model.GetActivePollsRequest) (*model.GetActivePollsResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
@@ -143,29 +570,47 @@ func (c *PollUseCase) GetActivePolls(ctx context.Context, request *model.GetActi
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// calculate total votes (jumlah participant yang vote)
+	pollIDs := make([]uint, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+	totalVotes, err := c.PollRepository.GetTotalVotesByPollIDs(tx, pollIDs)
+	if err != nil {
+		c.Log.Errorf("GetActivePolls - GetTotalVotesByPollIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	// build response with participant vote info
 	result := make([]model.PollResponse, len(polls))
 	for i, poll := range polls {
-		// calculate total votes
-		totalVotes := 0
-		for _, opt := range poll.Options {
-			totalVotes += opt.VoteCount
-		}
-
 		// check if participant has voted
-		existingResponse, err := c.PollRepository.GetPollResponseByParticipant(tx, poll.ID, request.ParticipantID)
+		myResponses, err := c.PollRepository.GetPollResponsesByParticipant(tx, poll.ID, request.ParticipantID)
 		if err != nil {
-			c.Log.Errorf("GetActivePolls - GetPollResponseByParticipant error: %v", err)
+			c.Log.Errorf("GetActivePolls - GetPollResponsesByParticipant error: %v", err)
 			return nil, fiber.ErrInternalServerError
 		}
 
-		hasVoted := existingResponse != nil
-		var myVoteID *uint
-		if hasVoted {
-			myVoteID = &existingResponse.PollOptionID
+		myVoteIDs := make([]uint, len(myResponses))
+		for j, response := range myResponses {
+			myVoteIDs[j] = response.PollOptionID
+		}
+
+		pollResp := converter.PollToResponseWithOptions(&poll, totalVotes[poll.ID], myVoteIDs)
+
+		// open-text poll menyimpan jawaban di tabel terpisah
+		if poll.Type == model.PollTypeOpenText {
+			myAnswers, err := c.PollRepository.GetTextResponsesByParticipant(tx, poll.ID, request.ParticipantID)
+			if err != nil {
+				c.Log.Errorf("GetActivePolls - GetTextResponsesByParticipant error: %v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+			for _, answer := range myAnswers {
+				pollResp.MyAnswers = append(pollResp.MyAnswers, answer.Content)
+			}
+			pollResp.HasVoted = len(myAnswers) > 0
 		}
 
-		pollResp := converter.PollToResponseWithOptions(&poll, totalVotes, hasVoted, myVoteID)
 		result[i] = *pollResp
 	}
 
@@ -209,18 +654,28 @@ func (c *PollUseCase) GetHistory(ctx context.Context, request *model.GetPollHist
 	}
 
 	// get polls
-	polls, total, err := c.PollRepository.GetPollsByRoomID(tx, request.RoomID, request.Status, request.Limit)
+	polls, total, err := c.PollRepository.GetPollsByRoomID(tx, request.RoomID, request.Status, request.Limit, request.IncludeDrafts)
 	if err != nil {
 		c.Log.Errorf("GetHistory - GetPollsByRoomID error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	pollIDs := make([]uint, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+	totalVotes, err := c.PollRepository.GetTotalVotesByPollIDs(tx, pollIDs)
+	if err != nil {
+		c.Log.Errorf("GetHistory - GetTotalVotesByPollIDs error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Errorf("GetHistory - Commit error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.PollsToHistoryResponse(polls, total), nil
+	return converter.PollsToHistoryResponse(polls, totalVotes, total), nil
 }
 
 // Vote usecase untuk submit vote pada poll
@@ -256,61 +711,129 @@ func (c *PollUseCase) Vote(ctx context.Context, request *model.SubmitPollVoteReq
 		return nil, fiber.ErrForbidden
 	}
 
-	// check if option belongs to the poll
-	optionExists := false
-	for _, opt := range poll.Options {
-		if opt.ID == request.OptionID {
-			optionExists = true
-			break
-		}
+	// tolak vote setelah countdown habis
+	elapsed := time.Duration(0)
+	if poll.ActivatedAt != nil {
+		elapsed = time.Since(*poll.ActivatedAt)
 	}
-	if !optionExists {
-		c.Log.Warnf("Vote - Option %d does not belong to poll %d", request.OptionID, request.PollID)
-		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid option")
+	if endsAt := converter.PollEndsAt(poll); endsAt != nil && time.Now().After(endsAt.Add(QuizVoteGracePeriod)) {
+		c.Log.Warnf("Vote - Time limit of poll %d has expired", request.PollID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Time is up")
 	}
 
-	// check if participant already voted
-	existingResponse, err := c.PollRepository.GetPollResponseByParticipant(tx, request.PollID, request.ParticipantID)
-	if err != nil {
-		c.Log.Errorf("Vote - GetPollResponseByParticipant error: %v", err)
-		return nil, fiber.ErrInternalServerError
-	}
-	if existingResponse != nil {
-		c.Log.Warnf("Vote - Participant %d already voted on poll %d", request.ParticipantID, request.PollID)
-		return nil, fiber.NewError(fiber.StatusConflict, "Already voted")
-	}
+	var voteResponse model.PollResponseResponse
+	var quizResult *model.QuizAnswerResponse
+	xpPoints := XPPollVote
+	xpSourceType := "poll"
+	var xpSourceID uint
 
-	// create poll response
-	pollResponse := &entity.PollResponse{
-		PollID:        request.PollID,
-		ParticipantID: request.ParticipantID,
-		PollOptionID:  request.OptionID,
-	}
-	if err := c.PollRepository.CreatePollResponse(tx, pollResponse); err != nil {
-		c.Log.Errorf("Vote - CreatePollResponse error: %v", err)
-		return nil, fiber.ErrInternalServerError
-	}
+	if poll.Type == model.PollTypeOpenText {
+		// open-text: participant bisa kirim sampai max_selections jawaban, XP hanya untuk jawaban pertama
+		answer, firstAnswer, err := c.submitTextAnswer(tx, poll, request)
+		if err != nil {
+			return nil, err
+		}
+		voteResponse = converter.PollTextResponseToResponse(answer)
+		if !firstAnswer {
+			xpPoints = 0
+		}
+	} else {
+		// validasi pilihan sesuai tipe poll
+		selected, err := c.resolveSelection(poll, request)
+		if err != nil {
+			c.Log.Warnf("Vote - Invalid selection for poll %d: %v", request.PollID, err)
+			return nil, err
+		}
 
-	// NOTE: vote_count di-increment otomatis oleh database trigger (after_poll_response_insert)
-	// Jangan panggil IncrementOptionVoteCount manual karena akan double-count!
+		// kunci participant supaya dua vote bersamaan tidak sama-sama lolos cek "sudah vote"
+		if err := c.ParticipantRepository.LockForUpdate(tx, request.ParticipantID); err != nil {
+			c.Log.Errorf("Vote - ParticipantRepository.LockForUpdate error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
 
-	// add XP for voting
-	xpTx := &entity.XPTransaction{
-		ParticipantID: request.ParticipantID,
-		RoomID:        request.RoomID,
-		Points:        XPPollVote,
-		SourceType:    "poll",
-		SourceID:      pollResponse.ID,
+		// check if participant already voted
+		existingResponse, err := c.PollRepository.GetPollResponseByParticipant(tx, request.PollID, request.ParticipantID)
+		if err != nil {
+			c.Log.Errorf("Vote - GetPollResponseByParticipant error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if existingResponse != nil {
+			c.Log.Warnf("Vote - Participant %d already voted on poll %d", request.ParticipantID, request.PollID)
+			return nil, fiber.NewError(fiber.StatusConflict, "Already voted")
+		}
+
+		// create poll responses, satu row per option yang dipilih
+		pollResponses := make([]entity.PollResponse, len(selected))
+		for i, optionID := range selected {
+			pollResponses[i] = entity.PollResponse{
+				PollID:        request.PollID,
+				ParticipantID: request.ParticipantID,
+				PollOptionID:  optionID,
+			}
+			if poll.Type == model.PollTypeRankedChoice {
+				pollResponses[i].Rank = i + 1
+			}
+		}
+		if err := c.PollRepository.CreatePollResponses(tx, pollResponses); err != nil {
+			c.Log.Errorf("Vote - CreatePollResponses error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		// NOTE: vote_count di-increment otomatis oleh database trigger (after_poll_response_insert)
+		// Jangan panggil IncrementOptionVoteCount manual karena akan double-count!
+
+		voteResponse = converter.PollResponsesToResponse(pollResponses)
+
+		// quiz: XP berdasarkan jawaban benar dan kecepatan menjawab, bukan flat XPPollVote
+		if poll.QuizID != nil {
+			correct := isCorrectSelection(poll.Options, selected)
+			quizAnswer := &entity.QuizAnswer{
+				QuizID:        *poll.QuizID,
+				PollID:        poll.ID,
+				ParticipantID: request.ParticipantID,
+				IsCorrect:     correct,
+				ResponseMs:    int(elapsed.Milliseconds()),
+				Points:        QuizPoints(correct, elapsed, time.Duration(poll.TimeLimitSeconds)*time.Second),
+			}
+			if err := c.QuizRepository.CreateAnswer(tx, quizAnswer); err != nil {
+				c.Log.Errorf("Vote - QuizRepository.CreateAnswer error: %v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+
+			xpPoints = quizAnswer.Points
+			xpSourceType = "quiz_answer"
+			xpSourceID = quizAnswer.ID
+			quizResult = &model.QuizAnswerResponse{
+				IsCorrect:  quizAnswer.IsCorrect,
+				ResponseMs: quizAnswer.ResponseMs,
+				Points:     quizAnswer.Points,
+			}
+		}
 	}
-	if err := c.XPTransactionRepository.Create(tx, xpTx); err != nil {
-		c.Log.Errorf("Vote - XPTransactionRepository.Create error: %v", err)
-		return nil, fiber.ErrInternalServerError
+
+	if xpSourceID == 0 {
+		xpSourceID = voteResponse.ID
 	}
 
-	// update participant XP score
-	if err := c.XPTransactionRepository.AddXP(tx, request.ParticipantID, XPPollVote); err != nil {
-		c.Log.Errorf("Vote - AddXP error: %v", err)
-		return nil, fiber.ErrInternalServerError
+	if xpPoints > 0 {
+		// add XP for voting
+		xpTx := &entity.XPTransaction{
+			ParticipantID: request.ParticipantID,
+			RoomID:        request.RoomID,
+			Points:        xpPoints,
+			SourceType:    xpSourceType,
+			SourceID:      xpSourceID,
+		}
+		if err := c.XPTransactionRepository.Create(tx, xpTx); err != nil {
+			c.Log.Errorf("Vote - XPTransactionRepository.Create error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		// update participant XP score
+		if err := c.XPTransactionRepository.AddXP(tx, request.ParticipantID, xpPoints); err != nil {
+			c.Log.Errorf("Vote - AddXP error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
 	}
 
 	// get updated participant XP
@@ -327,10 +850,10 @@ func (c *PollUseCase) Vote(ctx context.Context, request *model.SubmitPollVoteReq
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// calculate total votes
-	totalVotes, err := c.PollRepository.GetTotalVotesByPollID(tx, request.PollID)
+	// calculate results
+	results, err := c.buildResults(tx, updatedPoll)
 	if err != nil {
-		c.Log.Errorf("Vote - GetTotalVotesByPollID error: %v", err)
+		c.Log.Errorf("Vote - buildResults error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -339,10 +862,12 @@ func (c *PollUseCase) Vote(ctx context.Context, request *model.SubmitPollVoteReq
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.PollToVoteResponse(pollResponse, updatedPoll, totalVotes, XPPollVote, participant.XPScore), nil
+	response := converter.PollToVoteResponse(voteResponse, *results, xpPoints, participant.XPScore)
+	response.Quiz = quizResult
+	return response, nil
 }
 
-// Close usecase untuk menutup poll (presenter only)
+// Close usecase untuk menutup poll (owner atau co-host)
 func (c *PollUseCase) Close(ctx context.Context, request *model.ClosePollRequest) (*model.ClosePollResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -359,7 +884,7 @@ func (c *PollUseCase) Close(ctx context.Context, request *model.ClosePollRequest
 		c.Log.Errorf("Close - GetPollByIDWithOptions error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
-	if poll == nil {
+	if poll == nil || (request.RoomID != 0 && poll.RoomID != request.RoomID) {
 		return nil, fiber.ErrNotFound
 	}
 
@@ -370,10 +895,10 @@ func (c *PollUseCase) Close(ctx context.Context, request *model.ClosePollRequest
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// check if user is presenter
-	if room.PresenterID != request.PresenterID {
-		c.Log.Warnf("Close - User %d is not the presenter of room %d", request.PresenterID, poll.RoomID)
-		return nil, fiber.ErrForbidden
+	// check if user is the owner or a co-host of the room
+	if err := c.checkRoomHost(tx, &room, request.PresenterID); err != nil {
+		c.Log.Warnf("Close - User %d cannot host room %d", request.PresenterID, poll.RoomID)
+		return nil, err
 	}
 
 	// check poll is active
@@ -395,10 +920,10 @@ func (c *PollUseCase) Close(ctx context.Context, request *model.ClosePollRequest
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// calculate total votes
-	totalVotes, err := c.PollRepository.GetTotalVotesByPollID(tx, request.PollID)
+	// calculate final results
+	results, err := c.buildResults(tx, poll)
 	if err != nil {
-		c.Log.Errorf("Close - GetTotalVotesByPollID error: %v", err)
+		c.Log.Errorf("Close - buildResults error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -407,5 +932,386 @@ func (c *PollUseCase) Close(ctx context.Context, request *model.ClosePollRequest
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.PollToCloseResponse(poll, totalVotes), nil
+	return converter.PollToCloseResponse(poll, *results), nil
+}
+
+// validateQuizQuestion validasi field quiz pada CreatePollRequest, set default countdown pertanyaan quiz
+func (c *PollUseCase) validateQuizQuestion(tx *gorm.DB, request *model.CreatePollRequest, optionCount int) error {
+	if request.QuizID == 0 {
+		if len(request.CorrectOptions) > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Correct options are only allowed for quiz questions")
+		}
+		return nil
+	}
+
+	quiz, err := c.QuizRepository.GetQuizByID(tx, request.QuizID)
+	if err != nil {
+		c.Log.Errorf("QuizRepository.GetQuizByID error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if quiz == nil || quiz.RoomID != request.RoomID {
+		return fiber.NewError(fiber.StatusNotFound, "Quiz not found")
+	}
+	if quiz.Status != "active" {
+		return fiber.NewError(fiber.StatusBadRequest, "Quiz is already finished")
+	}
+
+	// hanya tipe pilihan yang punya jawaban benar
+	if request.Type != model.PollTypeSingleChoice && request.Type != model.PollTypeMultipleChoice {
+		return fiber.NewError(fiber.StatusBadRequest, "Quiz questions must be single_choice or multiple_choice")
+	}
+	if len(request.CorrectOptions) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Quiz questions require correct_options")
+	}
+	if request.Type == model.PollTypeSingleChoice && len(request.CorrectOptions) != 1 {
+		return fiber.NewError(fiber.StatusBadRequest, "Single choice quiz questions must have exactly one correct option")
+	}
+	for _, index := range request.CorrectOptions {
+		if index >= optionCount {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Correct option %d is out of range", index))
+		}
+	}
+
+	if request.TimeLimitSeconds == 0 {
+		request.TimeLimitSeconds = DefaultQuizTimeLimit
+	}
+	return nil
+}
+
+// buildPollOptions menentukan option dan max_selections sesuai tipe poll
+func (c *PollUseCase) buildPollOptions(request *model.CreatePollRequest) ([]string, int, error) {
+	switch request.Type {
+	case model.PollTypeOpenText:
+		if len(request.Options) > 0 {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Open-text polls do not have options")
+		}
+		maxAnswers := request.MaxSelections
+		if maxAnswers == 0 {
+			maxAnswers = 1
+		}
+		return nil, maxAnswers, nil
+
+	case model.PollTypeMultipleChoice:
+		if len(request.Options) == 0 {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Options are required")
+		}
+		maxSelections := request.MaxSelections
+		if maxSelections == 0 {
+			maxSelections = len(request.Options)
+		}
+		if maxSelections > len(request.Options) {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "max_selections cannot exceed number of options")
+		}
+		return request.Options, maxSelections, nil
+
+	case model.PollTypeRankedChoice:
+		if len(request.Options) == 0 {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Options are required")
+		}
+		return request.Options, len(request.Options), nil
+
+	case model.PollTypeRating:
+		// options untuk rating poll adalah label titik skala, default "1".."rating_scale"
+		if len(request.Options) > 0 {
+			if request.RatingScale != 0 && request.RatingScale != len(request.Options) {
+				return nil, 0, fiber.NewError(fiber.StatusBadRequest, "rating_scale must match number of options")
+			}
+			return request.Options, 1, nil
+		}
+
+		scale := request.RatingScale
+		if scale == 0 {
+			scale = model.DefaultRatingScale
+		}
+		options := make([]string, scale)
+		for i := range options {
+			options[i] = strconv.Itoa(i + 1)
+		}
+		return options, 1, nil
+
+	default:
+		if len(request.Options) == 0 {
+			return nil, 0, fiber.NewError(fiber.StatusBadRequest, "Options are required")
+		}
+		return request.Options, 1, nil
+	}
+}
+
+// resolveSelection validasi vote sesuai tipe poll dan mengembalikan option yang dipilih (urut ranking)
+func (c *PollUseCase) resolveSelection(poll *entity.Poll, request *model.SubmitPollVoteRequest) ([]uint, error) {
+	selected := request.OptionIDs
+	if len(selected) == 0 && request.OptionID != 0 {
+		selected = []uint{request.OptionID}
+	}
+
+	// rating bisa dikirim sebagai nilai skala
+	if poll.Type == model.PollTypeRating && request.Rating != 0 {
+		if len(selected) > 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Send either rating or option_id")
+		}
+		for _, opt := range poll.Options {
+			if opt.Order == request.Rating {
+				return []uint{opt.ID}, nil
+			}
+		}
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Rating out of scale")
+	}
+
+	if len(selected) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "No option selected")
+	}
+
+	switch poll.Type {
+	case model.PollTypeMultipleChoice:
+		if len(selected) > poll.MaxSelections {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Select at most %d options", poll.MaxSelections))
+		}
+	case model.PollTypeRankedChoice:
+		if len(selected) > len(poll.Options) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Too many options ranked")
+		}
+	default:
+		if len(selected) != 1 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Select exactly one option")
+		}
+	}
+
+	// check if options belong to the poll and are not duplicated
+	validOptions := make(map[uint]bool, len(poll.Options))
+	for _, opt := range poll.Options {
+		validOptions[opt.ID] = true
+	}
+	seen := make(map[uint]bool, len(selected))
+	for _, optionID := range selected {
+		if !validOptions[optionID] || seen[optionID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid option")
+		}
+		seen[optionID] = true
+	}
+
+	return selected, nil
+}
+
+// submitTextAnswer simpan jawaban open-text, return true jika ini jawaban pertama participant
+func (c *PollUseCase) submitTextAnswer(tx *gorm.DB, poll *entity.Poll, request *model.SubmitPollVoteRequest) (*entity.PollTextResponse, bool, error) {
+	if request.Text == "" || request.OptionID != 0 || len(request.OptionIDs) > 0 || request.Rating != 0 {
+		c.Log.Warnf("Vote - Open-text poll %d requires text only", poll.ID)
+		return nil, false, fiber.NewError(fiber.StatusBadRequest, "Open-text polls require text")
+	}
+
+	normalized := NormalizeAnswer(request.Text)
+	if normalized == "" {
+		c.Log.Warnf("Vote - Answer for poll %d is empty after normalization", poll.ID)
+		return nil, false, fiber.NewError(fiber.StatusBadRequest, "Answer has no meaningful words")
+	}
+
+	// kunci participant supaya jawaban bersamaan tidak melewati max_selections atau sama-sama dapat XP jawaban pertama
+	if err := c.ParticipantRepository.LockForUpdate(tx, request.ParticipantID); err != nil {
+		c.Log.Errorf("Vote - ParticipantRepository.LockForUpdate error: %v", err)
+		return nil, false, fiber.ErrInternalServerError
+	}
+
+	existing, err := c.PollRepository.GetTextResponsesByParticipant(tx, poll.ID, request.ParticipantID)
+	if err != nil {
+		c.Log.Errorf("Vote - GetTextResponsesByParticipant error: %v", err)
+		return nil, false, fiber.ErrInternalServerError
+	}
+	if len(existing) >= poll.MaxSelections {
+		c.Log.Warnf("Vote - Participant %d reached answer limit on poll %d", request.ParticipantID, poll.ID)
+		return nil, false, fiber.NewError(fiber.StatusConflict, "Already voted")
+	}
+
+	answer := &entity.PollTextResponse{
+		PollID:        poll.ID,
+		ParticipantID: request.ParticipantID,
+		Content:       strings.TrimSpace(request.Text),
+		Normalized:    normalized,
+	}
+	if err := c.PollRepository.CreateTextResponse(tx, answer); err != nil {
+		c.Log.Errorf("Vote - CreateTextResponse error: %v", err)
+		return nil, false, fiber.ErrInternalServerError
+	}
+
+	return answer, len(existing) == 0, nil
+}
+
+// buildResults menghitung hasil poll sesuai tipe poll
+func (c *PollUseCase) buildResults(tx *gorm.DB, poll *entity.Poll) (*model.UpdatedPollResultsResponse, error) {
+	totalVotes, err := c.PollRepository.GetTotalVotesByPollID(tx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranked *model.RankedChoiceResults
+	var terms []model.PollTermResponse
+
+	switch poll.Type {
+	case model.PollTypeRankedChoice:
+		ballots, err := c.PollRepository.GetRankedBallots(tx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		optionIDs := make([]uint, len(poll.Options))
+		for i, opt := range poll.Options {
+			optionIDs[i] = opt.ID
+		}
+		ranked = TallyInstantRunoff(optionIDs, ballots)
+
+	case model.PollTypeOpenText:
+		terms, err = c.PollRepository.GetTermFrequencies(tx, poll.ID, MaxWordCloudTerms)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := converter.PollToResultsResponse(poll, totalVotes, ranked, terms)
+	return &results, nil
+}
+
+// GetResults usecase untuk mendapatkan hasil poll terkini
+func (c *PollUseCase) GetResults(ctx context.Context, request *model.GetPollResultsRequest) (*model.UpdatedPollResultsResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("GetResults - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.PollRepository.GetPollByIDWithOptions(tx, request.PollID)
+	if err != nil {
+		c.Log.Errorf("GetResults - GetPollByIDWithOptions error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	// draft belum dipublikasikan ke participant
+	if poll == nil || poll.Status == "draft" {
+		return nil, fiber.ErrNotFound
+	}
+
+	// caller harus berada di room yang sama dengan poll
+	if poll.RoomID != request.RoomID {
+		c.Log.Warnf("GetResults - Poll %d is not in room %d", request.PollID, request.RoomID)
+		return nil, fiber.ErrForbidden
+	}
+
+	results, err := c.buildResults(tx, poll)
+	if err != nil {
+		c.Log.Errorf("GetResults - buildResults error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("GetResults - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return results, nil
+}
+
+// ListAnswers usecase untuk list semua jawaban open-text poll termasuk yang di-hide (presenter only)
+func (c *PollUseCase) ListAnswers(ctx context.Context, request *model.ListPollAnswersRequest) (*model.ListPollAnswersResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("ListAnswers - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.getOpenTextPollForPresenter(tx, request.PollID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("ListAnswers - %v", err)
+		return nil, err
+	}
+
+	answers, err := c.PollRepository.GetTextResponsesByPollID(tx, poll.ID)
+	if err != nil {
+		c.Log.Errorf("ListAnswers - GetTextResponsesByPollID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ListAnswers - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PollTextResponsesToListResponse(answers), nil
+}
+
+// HideAnswer usecase untuk hide / unhide jawaban open-text poll (presenter only)
+func (c *PollUseCase) HideAnswer(ctx context.Context, request *model.HidePollAnswerRequest) (*model.HidePollAnswerResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("HideAnswer - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	poll, err := c.getOpenTextPollForPresenter(tx, request.PollID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("HideAnswer - %v", err)
+		return nil, err
+	}
+
+	answer, err := c.PollRepository.GetTextResponseByID(tx, request.AnswerID)
+	if err != nil {
+		c.Log.Errorf("HideAnswer - GetTextResponseByID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if answer == nil || answer.PollID != poll.ID {
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.PollRepository.SetTextResponseHidden(tx, answer, *request.Hidden); err != nil {
+		c.Log.Errorf("HideAnswer - SetTextResponseHidden error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	answer.IsHidden = *request.Hidden
+
+	results, err := c.buildResults(tx, poll)
+	if err != nil {
+		c.Log.Errorf("HideAnswer - buildResults error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("HideAnswer - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.HidePollAnswerResponse{
+		Answer:         converter.PollTextResponseToAnswerResponse(answer),
+		UpdatedResults: *results,
+	}, nil
+}
+
+// getOpenTextPollForPresenter load open-text poll dan pastikan caller adalah owner atau co-host room
+func (c *PollUseCase) getOpenTextPollForPresenter(tx *gorm.DB, pollID, presenterID uint) (*entity.Poll, error) {
+	poll, err := c.PollRepository.GetPollByIDWithOptions(tx, pollID)
+	if err != nil {
+		c.Log.Errorf("GetPollByIDWithOptions error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if poll == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, poll.RoomID); err != nil {
+		c.Log.Errorf("RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.checkRoomHost(tx, &room, presenterID); err != nil {
+		return nil, err
+	}
+
+	if poll.Type != model.PollTypeOpenText {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Poll is not an open-text poll")
+	}
+	return poll, nil
 }
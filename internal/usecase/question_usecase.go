@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"reisify/internal/entity"
 	"reisify/internal/model"
 	"reisify/internal/model/converter"
 	"reisify/internal/repository"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -30,6 +32,9 @@ type QuestionUseCase struct {
 	RoomRepository          *repository.RoomRepository
 	ParticipantRepository   *repository.ParticipantRepository
 	XPTransactionRepository *repository.XPTransactionRepository
+	RoomRoleRepository      *repository.RoomRoleRepository
+	QuestionReplyRepository *repository.QuestionReplyRepository
+	ContentFilterUseCase    *ContentFilterUseCase
 }
 
 // NewQuestionUseCase create new instance of QuestionUseCase
@@ -42,6 +47,9 @@ func NewQuestionUseCase(
 	roomRepository *repository.RoomRepository,
 	participantRepository *repository.ParticipantRepository,
 	xpTransactionRepository *repository.XPTransactionRepository,
+	roomRoleRepository *repository.RoomRoleRepository,
+	questionReplyRepository *repository.QuestionReplyRepository,
+	contentFilterUseCase *ContentFilterUseCase,
 ) *QuestionUseCase {
 	return &QuestionUseCase{
 		DB:                      db,
@@ -52,6 +60,9 @@ func NewQuestionUseCase(
 		RoomRepository:          roomRepository,
 		ParticipantRepository:   participantRepository,
 		XPTransactionRepository: xpTransactionRepository,
+		RoomRoleRepository:      roomRoleRepository,
+		QuestionReplyRepository: questionReplyRepository,
+		ContentFilterUseCase:    contentFilterUseCase,
 	}
 }
 
@@ -67,61 +78,97 @@ func (c *QuestionUseCase) Submit(ctx context.Context, request *model.SubmitQuest
 	}
 
 	// check room exists and active
-	roomCount, err := c.RoomRepository.CountById(tx, request.RoomID)
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, request.RoomID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Errorf("Submit - RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// participant yang di-mute presenter tidak bisa bertanya
+	muted, err := c.ParticipantRepository.IsMuted(tx, request.ParticipantID)
 	if err != nil {
-		c.Log.Errorf("Submit - RoomRepository.CountById error: %v", err)
+		c.Log.Errorf("Submit - ParticipantRepository.IsMuted error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
-	if roomCount == 0 {
-		return nil, fiber.ErrNotFound
+	if muted {
+		c.Log.Warnf("Submit - Participant %d is muted", request.ParticipantID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are muted")
+	}
+
+	// filter konten room, aksi moderate memasukkan question ke antrian moderasi
+	filtered, err := c.ContentFilterUseCase.Screen(ctx, tx, request.RoomID, request.ParticipantID, request.Content, true)
+	if err != nil {
+		return nil, err
 	}
 
 	// create question entity
 	question := &entity.Question{
-		RoomID:        request.RoomID,
-		ParticipantID: request.ParticipantID,
-		Content:       request.Content,
-		XPAwarded:     XPSubmitQuestion,
+		RoomID:           request.RoomID,
+		ParticipantID:    request.ParticipantID,
+		Content:          filtered.Content,
+		XPAwarded:        XPSubmitQuestion,
+		ModerationStatus: "approved",
+	}
+
+	// room dengan moderasi: question masuk antrian, XP diberikan saat approve
+	if room.QuestionModeration || filtered.Action == ContentFilterModerate {
+		question.XPAwarded = 0
+		question.ModerationStatus = "queued"
 	}
 
 	// save question
-	if err = c.QuestionRepository.Create(tx, question); err != nil {
+	if err := c.QuestionRepository.Create(tx, question); err != nil {
 		c.Log.Errorf("Submit - QuestionRepository.Create error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// add XP for submitting question
-	xpTx := &entity.XPTransaction{
-		ParticipantID: request.ParticipantID,
-		RoomID:        request.RoomID,
-		Points:        XPSubmitQuestion,
-		SourceType:    "question_created",
-		SourceID:      question.ID,
+	if question.ModerationStatus == "queued" {
+		if err := tx.Commit().Error; err != nil {
+			c.Log.Errorf("Submit - Commit error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		return converter.QuestionToQueuedResponse(question), nil
 	}
-	if err = c.XPTransactionRepository.Create(tx, xpTx); err != nil {
-		c.Log.Errorf("Submit - XPTransactionRepository.Create error: %v", err)
+
+	newTotal, err := c.awardSubmitXP(tx, question)
+	if err != nil {
+		c.Log.Errorf("Submit - awardSubmitXP error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// update participant XP score
-	if err = c.XPTransactionRepository.AddXP(tx, request.ParticipantID, XPSubmitQuestion); err != nil {
-		c.Log.Errorf("Submit - AddXP error: %v", err)
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Submit - Commit error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// get new total XP
-	var participant entity.Participant
-	if err = tx.Select("xp_score").Where("id = ?", request.ParticipantID).First(&participant).Error; err != nil {
-		c.Log.Errorf("Submit - Get participant error: %v", err)
-		return nil, fiber.ErrInternalServerError
+	return converter.QuestionToSubmitResponse(question, XPSubmitQuestion, newTotal), nil
+}
+
+// awardSubmitXP catat XP submit question untuk author, mengembalikan total XP terbaru
+func (c *QuestionUseCase) awardSubmitXP(tx *gorm.DB, question *entity.Question) (int, error) {
+	xpTx := &entity.XPTransaction{
+		ParticipantID: question.ParticipantID,
+		RoomID:        question.RoomID,
+		Points:        XPSubmitQuestion,
+		SourceType:    "question_created",
+		SourceID:      question.ID,
+	}
+	if err := c.XPTransactionRepository.Create(tx, xpTx); err != nil {
+		return 0, err
 	}
 
-	if err = tx.Commit().Error; err != nil {
-		c.Log.Errorf("Submit - Commit error: %v", err)
-		return nil, fiber.ErrInternalServerError
+	if err := c.XPTransactionRepository.AddXP(tx, question.ParticipantID, XPSubmitQuestion); err != nil {
+		return 0, err
 	}
 
-	return converter.QuestionToSubmitResponse(question, XPSubmitQuestion, participant.XPScore), nil
+	var participant entity.Participant
+	if err := tx.Select("xp_score").Where("id = ?", question.ParticipantID).First(&participant).Error; err != nil {
+		return 0, err
+	}
+	return participant.XPScore, nil
 }
 
 // List usecase untuk mendapatkan list questions
@@ -154,14 +201,14 @@ func (c *QuestionUseCase) List(ctx context.Context, request *model.GetQuestionsR
 	}
 
 	// get questions
-	questions, err := c.QuestionRepository.List(tx, request.RoomID, request.Status, request.SortBy, request.Limit, request.Offset)
+	questions, err := c.QuestionRepository.List(tx, request.RoomID, request.ParticipantID, request.Status, request.SortBy, request.Limit, request.Offset)
 	if err != nil {
 		c.Log.Errorf("List - QuestionRepository.List error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	// get total count
-	total, err := c.QuestionRepository.Count(tx, request.RoomID, request.Status)
+	total, err := c.QuestionRepository.Count(tx, request.RoomID, request.ParticipantID, request.Status)
 	if err != nil {
 		c.Log.Errorf("List - QuestionRepository.Count error: %v", err)
 		return nil, fiber.ErrInternalServerError
@@ -174,11 +221,22 @@ func (c *QuestionUseCase) List(ctx context.Context, request *model.GetQuestionsR
 	}
 
 	votedMap := make(map[uint]bool)
+	repliesMap := make(map[uint][]model.ReplyResponse)
 	if len(questionIDs) > 0 {
 		votedMap, err = c.VoteRepository.GetVotedQuestionIDs(tx, request.ParticipantID, questionIDs)
 		if err != nil {
 			c.Log.Warnf("List - VoteRepository.GetVotedQuestionIDs error: %v", err)
 		}
+
+		// batch load replies untuk semua question di halaman ini
+		replies, err := c.QuestionReplyRepository.ListByQuestionIDs(tx, questionIDs)
+		if err != nil {
+			c.Log.Errorf("List - QuestionReplyRepository.ListByQuestionIDs error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		for i := range replies {
+			repliesMap[replies[i].QuestionID] = append(repliesMap[replies[i].QuestionID], *converter.QuestionReplyToResponse(&replies[i]))
+		}
 	}
 
 	if err = tx.Commit().Error; err != nil {
@@ -190,6 +248,7 @@ func (c *QuestionUseCase) List(ctx context.Context, request *model.GetQuestionsR
 	responses := make([]model.QuestionResponse, len(questions))
 	for i, q := range questions {
 		responses[i] = *converter.QuestionToResponseWithParticipant(&q, votedMap[q.ID])
+		responses[i].Replies = repliesMap[q.ID]
 	}
 
 	return &model.QuestionListResponse{
@@ -202,6 +261,86 @@ func (c *QuestionUseCase) List(ctx context.Context, request *model.GetQuestionsR
 	}, nil
 }
 
+// Reply usecase untuk membalas question.
+// Presenter selalu boleh membalas; participant lain hanya jika room.ParticipantReplies aktif.
+func (c *QuestionUseCase) Reply(ctx context.Context, request *model.ReplyQuestionRequest) (*model.ReplyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Reply - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	question, err := c.QuestionRepository.FindByIdWithParticipant(tx, request.QuestionID)
+	if err != nil {
+		c.Log.Errorf("Reply - QuestionRepository.FindByIdWithParticipant error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if question == nil || question.RoomID != request.RoomID || question.ModerationStatus != "approved" {
+		return nil, fiber.ErrNotFound
+	}
+
+	participant, err := c.ParticipantRepository.FindParticipantInRoom(tx, request.RoomID, request.ParticipantID)
+	if err != nil {
+		c.Log.Errorf("Reply - ParticipantRepository.FindParticipantInRoom error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if participant == nil {
+		return nil, fiber.ErrForbidden
+	}
+	if participant.MutedUntil != nil && participant.MutedUntil.After(time.Now()) {
+		c.Log.Warnf("Reply - Participant %d is muted", request.ParticipantID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "You are muted")
+	}
+
+	var room entity.Room
+	if err = c.RoomRepository.FindById(tx, &room, request.RoomID); err != nil {
+		c.Log.Errorf("Reply - RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// owner dan co-host membalas sebagai presenter, anonymous tidak bisa punya role
+	isPresenter := false
+	if participant.UserID != nil {
+		role, err := c.RoomRoleRepository.GetRole(tx, &room, *participant.UserID)
+		if err != nil {
+			c.Log.Errorf("Reply - RoomRoleRepository.GetRole error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		isPresenter = model.RoomRoleCanHost(role)
+	}
+	if !isPresenter && !room.ParticipantReplies {
+		c.Log.Warnf("Reply - Participant %d cannot reply in room %d", request.ParticipantID, request.RoomID)
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the presenter can reply to questions")
+	}
+
+	filtered, err := c.ContentFilterUseCase.Screen(ctx, tx, request.RoomID, participant.ID, request.Content, false)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &entity.QuestionReply{
+		QuestionID:    question.ID,
+		ParticipantID: participant.ID,
+		Content:       filtered.Content,
+		IsPresenter:   isPresenter,
+	}
+	if err = c.QuestionReplyRepository.Create(tx, reply); err != nil {
+		c.Log.Errorf("Reply - QuestionReplyRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Reply - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	reply.Participant = *participant
+	return converter.QuestionReplyToResponse(reply), nil
+}
+
 // Upvote usecase untuk upvote question
 func (c *QuestionUseCase) Upvote(ctx context.Context, request *model.UpvoteRequest) (*model.UpvoteResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
@@ -219,7 +358,7 @@ func (c *QuestionUseCase) Upvote(ctx context.Context, request *model.UpvoteReque
 		c.Log.Errorf("Upvote - QuestionRepository.FindByIdWithParticipant error: %v", err)
 		return nil, fiber.ErrInternalServerError
 	}
-	if question == nil {
+	if question == nil || question.ModerationStatus != "approved" {
 		return nil, fiber.ErrNotFound
 	}
 
@@ -357,15 +496,17 @@ func (c *QuestionUseCase) Validate(ctx context.Context, request *model.ValidateQ
 		return nil, fiber.ErrNotFound
 	}
 
-	// verify caller is the presenter of the question's room
-	var room entity.Room
-	if err = c.RoomRepository.FindById(tx, &room, question.RoomID); err != nil {
-		c.Log.Errorf("Validate - RoomRepository.FindById error: %v", err)
-		return nil, fiber.ErrInternalServerError
+	// verify caller is the owner, co-host or moderator of the question's room
+	if err = c.checkModerator(tx, question.RoomID, request.PresenterID); err != nil {
+		if errors.Is(err, fiber.ErrForbidden) {
+			c.Log.Warnf("Validate - User %d cannot validate questions in room %d", request.PresenterID, question.RoomID)
+		}
+		return nil, err
 	}
-	if room.PresenterID != request.PresenterID {
-		c.Log.Warnf("Validate - User %d is not the presenter of room %d", request.PresenterID, question.RoomID)
-		return nil, fiber.ErrForbidden
+
+	// question di antrian moderasi harus di-approve dulu
+	if question.ModerationStatus != "approved" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Question has not been approved")
 	}
 
 	// check if already validated
@@ -417,6 +558,161 @@ func (c *QuestionUseCase) Validate(ctx context.Context, request *model.ValidateQ
 	return converter.QuestionToValidateResponse(question, XPPresenterValidate, participant.XPScore), nil
 }
 
+// Queue usecase untuk melihat antrian moderasi (owner dan moderator)
+func (c *QuestionUseCase) Queue(ctx context.Context, request *model.GetQuestionQueueRequest) (*model.QuestionQueueResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Queue - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	if err := c.checkModerator(tx, request.RoomID, request.UserID); err != nil {
+		c.Log.Warnf("Queue - checkModerator error: %v", err)
+		return nil, err
+	}
+
+	questions, total, err := c.QuestionRepository.ListQueued(tx, request.RoomID, request.Limit, request.Offset)
+	if err != nil {
+		c.Log.Errorf("Queue - QuestionRepository.ListQueued error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Queue - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.QuestionsToQueueResponse(questions, total, request.Limit, request.Offset), nil
+}
+
+// Moderate usecase untuk approve / reject / edit question di antrian moderasi.
+// Approve memberikan XPSubmitQuestion ke author; question hanya bisa dimoderasi selama masih queued.
+func (c *QuestionUseCase) Moderate(ctx context.Context, request *model.ModerateQuestionRequest) (*model.ModerateQuestionResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Moderate - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	question, err := c.QuestionRepository.FindByIdWithParticipant(tx, request.QuestionID)
+	if err != nil {
+		c.Log.Errorf("Moderate - QuestionRepository.FindByIdWithParticipant error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if question == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	if err = c.checkModerator(tx, question.RoomID, request.ModeratorID); err != nil {
+		c.Log.Warnf("Moderate - checkModerator error: %v", err)
+		return nil, err
+	}
+
+	if question.ModerationStatus != "queued" {
+		return nil, fiber.NewError(fiber.StatusConflict, "Question already moderated")
+	}
+
+	// moderator boleh merapikan isi question sebelum di-approve
+	if request.Content != "" && request.Action != "reject" {
+		question.Content = request.Content
+	}
+
+	var xpEarned *model.XPEarned
+	if request.Action != "edit" {
+		now := time.Now()
+		question.ModeratedBy = &request.ModeratorID
+		question.ModeratedAt = &now
+		question.ModerationStatus = "rejected"
+		if request.Action == "approve" {
+			question.ModerationStatus = "approved"
+			question.XPAwarded += XPSubmitQuestion
+		}
+	}
+
+	// status dicek ulang di UPDATE, moderator lain mungkin sudah memoderasi sejak question dibaca
+	updated, err := c.QuestionRepository.UpdateModeration(tx, question)
+	if err != nil {
+		c.Log.Errorf("Moderate - QuestionRepository.UpdateModeration error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !updated {
+		return nil, fiber.NewError(fiber.StatusConflict, "Question already moderated")
+	}
+
+	// XP submit question baru diberikan saat question di-approve
+	if request.Action == "approve" {
+		newTotal, err := c.awardSubmitXP(tx, question)
+		if err != nil {
+			c.Log.Errorf("Moderate - awardSubmitXP error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		xpEarned = &model.XPEarned{Points: XPSubmitQuestion, NewTotal: newTotal}
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Errorf("Moderate - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	reason := ""
+	if request.Action == "reject" {
+		reason = request.Reason
+	}
+
+	return converter.QuestionToModerateResponse(question, request.Action, reason, xpEarned), nil
+}
+
+// ModeratorUserIDs user id yang boleh melihat antrian moderasi room (owner + co-host + moderator)
+func (c *QuestionUseCase) ModeratorUserIDs(ctx context.Context, roomID uint) ([]uint, error) {
+	db := c.DB.WithContext(ctx)
+
+	var room entity.Room
+	if err := c.RoomRepository.FindById(db, &room, roomID); err != nil {
+		c.Log.Warnf("ModeratorUserIDs - RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	userIDs, err := c.RoomRoleRepository.GetUserIDsByRoomID(db, roomID)
+	if err != nil {
+		c.Log.Warnf("ModeratorUserIDs - GetUserIDsByRoomID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return append([]uint{room.PresenterID}, userIDs...), nil
+}
+
+// checkModerator pastikan user adalah owner, co-host atau moderator room
+func (c *QuestionUseCase) checkModerator(tx *gorm.DB, roomID, userID uint) error {
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, roomID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		c.Log.Errorf("RoomRepository.FindById error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	role, err := c.RoomRoleRepository.GetRole(tx, &room, userID)
+	if err != nil {
+		c.Log.Errorf("RoomRoleRepository.GetRole error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !model.RoomRoleCanModerate(role) {
+		return fiber.ErrForbidden
+	}
+	return nil
+}
+
 // GetRoomIDByQuestionID returns the room ID that owns the given question.
 func (c *QuestionUseCase) GetRoomIDByQuestionID(ctx context.Context, questionID uint) (uint, error) {
 	roomID, err := c.QuestionRepository.GetRoomIDByQuestionID(c.DB.WithContext(ctx), questionID)
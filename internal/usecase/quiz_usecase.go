@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// QuizUseCase usecase untuk quiz operations, pertanyaan quiz dibuat dan dijawab lewat PollUseCase
+type QuizUseCase struct {
+	DB                 *gorm.DB
+	Log                *logrus.Logger
+	Validator          *validator.Validate
+	QuizRepository     *repository.QuizRepository
+	PollRepository     *repository.PollRepository
+	RoomRepository     *repository.RoomRepository
+	RoomRoleRepository *repository.RoomRoleRepository
+	PollUseCase        *PollUseCase
+}
+
+// NewQuizUseCase create new instance of QuizUseCase
+func NewQuizUseCase(
+	db *gorm.DB,
+	log *logrus.Logger,
+	validate *validator.Validate,
+	quizRepository *repository.QuizRepository,
+	pollRepository *repository.PollRepository,
+	roomRepository *repository.RoomRepository,
+	roomRoleRepository *repository.RoomRoleRepository,
+	pollUseCase *PollUseCase,
+) *QuizUseCase {
+	return &QuizUseCase{
+		DB:                 db,
+		Log:                log,
+		Validator:          validate,
+		QuizRepository:     quizRepository,
+		PollRepository:     pollRepository,
+		RoomRepository:     roomRepository,
+		RoomRoleRepository: roomRoleRepository,
+		PollUseCase:        pollUseCase,
+	}
+}
+
+// Create usecase untuk membuat quiz baru (owner atau co-host)
+func (c *QuizUseCase) Create(ctx context.Context, request *model.CreateQuizRequest) (*model.QuizResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Create - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// check room exists
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, request.RoomID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.Log.Warnf("Create - Room not found: %d", request.RoomID)
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Errorf("Create - RoomRepository.FindById error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// check if user is the owner or a co-host of the room
+	if err := c.checkRoomHost(tx, &room, request.PresenterID); err != nil {
+		c.Log.Warnf("Create - User %d cannot host room %d", request.PresenterID, request.RoomID)
+		return nil, err
+	}
+
+	// check room is active
+	if room.Status != "active" {
+		c.Log.Warnf("Create - Room %d is not active", request.RoomID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Room is not active")
+	}
+
+	quiz := &entity.Quiz{
+		RoomID: request.RoomID,
+		Title:  request.Title,
+		Status: "active",
+	}
+	if err := c.QuizRepository.Create(tx, quiz); err != nil {
+		c.Log.Errorf("Create - QuizRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Create - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.QuizToResponse(quiz), nil
+}
+
+// Finish usecase untuk mengakhiri quiz (owner atau co-host), pertanyaan yang masih aktif ikut ditutup.
+// Hasil akhir pertanyaan yang ditutup ikut di-return untuk broadcast poll:closed
+func (c *QuizUseCase) Finish(ctx context.Context, request *model.FinishQuizRequest) (*model.QuizSummaryResponse, []*model.ClosePollResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("Finish - Invalid request: %v", err)
+		return nil, nil, fiber.ErrBadRequest
+	}
+
+	quiz, err := c.QuizRepository.GetQuizByID(tx, request.QuizID)
+	if err != nil {
+		c.Log.Errorf("Finish - GetQuizByID error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+	if quiz == nil {
+		return nil, nil, fiber.ErrNotFound
+	}
+
+	// get room to check presenter
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, quiz.RoomID); err != nil {
+		c.Log.Errorf("Finish - RoomRepository.FindById error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+	if err := c.checkRoomHost(tx, &room, request.PresenterID); err != nil {
+		c.Log.Warnf("Finish - User %d cannot host room %d", request.PresenterID, quiz.RoomID)
+		return nil, nil, err
+	}
+
+	if quiz.Status != "active" {
+		c.Log.Warnf("Finish - Quiz %d is already finished", request.QuizID)
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Quiz is already finished")
+	}
+
+	// tutup pertanyaan yang masih aktif
+	polls, err := c.QuizRepository.GetQuizPolls(tx, quiz.ID)
+	if err != nil {
+		c.Log.Errorf("Finish - GetQuizPolls error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+	closed := make([]*model.ClosePollResponse, 0)
+	for i := range polls {
+		if polls[i].Status != "active" {
+			continue
+		}
+		if err := c.PollRepository.ClosePoll(tx, &polls[i]); err != nil {
+			c.Log.Errorf("Finish - ClosePoll error: %v", err)
+			return nil, nil, fiber.ErrInternalServerError
+		}
+
+		// reload poll untuk closed_at dan option, hasil akhir sama dengan close poll biasa
+		poll, err := c.PollRepository.GetPollByIDWithOptions(tx, polls[i].ID)
+		if err != nil {
+			c.Log.Errorf("Finish - GetPollByIDWithOptions error: %v", err)
+			return nil, nil, fiber.ErrInternalServerError
+		}
+		results, err := c.PollUseCase.buildResults(tx, poll)
+		if err != nil {
+			c.Log.Errorf("Finish - buildResults error: %v", err)
+			return nil, nil, fiber.ErrInternalServerError
+		}
+		closed = append(closed, converter.PollToCloseResponse(poll, *results))
+	}
+
+	if err := c.QuizRepository.FinishQuiz(tx, quiz); err != nil {
+		c.Log.Errorf("Finish - FinishQuiz error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	summary, err := c.buildSummary(tx, quiz.ID)
+	if err != nil {
+		c.Log.Errorf("Finish - buildSummary error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("Finish - Commit error: %v", err)
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	return summary, closed, nil
+}
+
+// GetSummary usecase untuk mendapatkan ringkasan quiz (akurasi per pertanyaan dan ranking)
+func (c *QuizUseCase) GetSummary(ctx context.Context, request *model.GetQuizSummaryRequest) (*model.QuizSummaryResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validator.Struct(request); err != nil {
+		c.Log.Warnf("GetSummary - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	quiz, err := c.QuizRepository.GetQuizByID(tx, request.QuizID)
+	if err != nil {
+		c.Log.Errorf("GetSummary - GetQuizByID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if quiz == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	// caller harus berada di room yang sama dengan quiz
+	if quiz.RoomID != request.RoomID {
+		c.Log.Warnf("GetSummary - Quiz %d is not in room %d", request.QuizID, request.RoomID)
+		return nil, fiber.ErrForbidden
+	}
+
+	summary, err := c.buildSummary(tx, quiz.ID)
+	if err != nil {
+		c.Log.Errorf("GetSummary - buildSummary error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("GetSummary - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return summary, nil
+}
+
+// buildSummary load quiz beserta pertanyaan dan agregat jawaban
+func (c *QuizUseCase) buildSummary(tx *gorm.DB, quizID uint) (*model.QuizSummaryResponse, error) {
+	// reload supaya status dan finished_at terbaru
+	quiz, err := c.QuizRepository.GetQuizByID(tx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	polls, err := c.QuizRepository.GetQuizPolls(tx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	questionStats, err := c.QuizRepository.GetQuestionStats(tx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking, err := c.QuizRepository.GetRanking(tx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.QuizToSummaryResponse(quiz, polls, questionStats, ranking), nil
+}
+
+// checkRoomHost pastikan user adalah owner atau co-host room
+func (c *QuizUseCase) checkRoomHost(tx *gorm.DB, room *entity.Room, userID uint) error {
+	role, err := c.RoomRoleRepository.GetRole(tx, room, userID)
+	if err != nil {
+		c.Log.Errorf("RoomRoleRepository.GetRole error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !model.RoomRoleCanHost(role) {
+		return fiber.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// GrantRole usecase untuk memberi role co-host / moderator ke participant di room.
+// Owner boleh memberi semua role, co-host hanya boleh mengelola moderator.
+func (c *RoomUseCase) GrantRole(ctx context.Context, request *model.GrantRoomRoleRequest) (*model.RoomRoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("GrantRole - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, actorRole, err := c.findHostedRoom(tx, request.RoomID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := c.ParticipantRepository.FindParticipantInRoom(tx, request.RoomID, request.ParticipantID)
+	if err != nil {
+		c.Log.Warnf("GrantRole - Failed to find participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if participant == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Participant not found in room")
+	}
+	if participant.UserID == nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Anonymous participants cannot hold a room role")
+	}
+
+	role, err := c.upsertRole(tx, room, actorRole, request.ActorID, *participant.UserID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("GrantRole - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RoomRoleToResponse(role, &participant.ID), nil
+}
+
+// InviteRole usecase untuk memberi role ke user terdaftar berdasarkan username,
+// user belum perlu join room. Role berlaku saat user join.
+func (c *RoomUseCase) InviteRole(ctx context.Context, request *model.InviteRoomRoleRequest) (*model.RoomRoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("InviteRole - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, actorRole, err := c.findHostedRoom(tx, request.RoomID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.UserRepository.FindByUsername(tx, request.Username)
+	if err != nil {
+		c.Log.Warnf("InviteRole - Failed to find user: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if user == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+	}
+
+	role, err := c.upsertRole(tx, room, actorRole, request.ActorID, user.ID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := c.ParticipantRepository.FindByRoomIDAndUserID(tx, room.ID, user.ID)
+	if err != nil {
+		c.Log.Warnf("InviteRole - Failed to find participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("InviteRole - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var participantID *uint
+	if participant != nil {
+		participantID = &participant.ID
+	}
+	return converter.RoomRoleToResponse(role, participantID), nil
+}
+
+// RevokeRole usecase untuk mencabut role user di room.
+// Token room milik user di-invalidate supaya role lama tidak bisa dipakai lagi.
+func (c *RoomUseCase) RevokeRole(ctx context.Context, request *model.RevokeRoomRoleRequest) (*model.RoomRoleChangeResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("RevokeRole - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, actorRole, err := c.findHostedRoom(tx, request.RoomID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.RoomRoleRepository.FindByRoomIDAndUserID(tx, room.ID, request.UserID)
+	if err != nil {
+		c.Log.Warnf("RevokeRole - Failed to find role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if existing == nil || (request.Role != "" && existing.Role != request.Role) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Role not found")
+	}
+	if existing.Role == model.RoomRoleCoHost && actorRole != model.RoomRoleOwner {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner can manage co-hosts")
+	}
+
+	if _, err = c.RoomRoleRepository.DeleteByRoomIDAndUserID(tx, room.ID, request.UserID); err != nil {
+		c.Log.Warnf("RevokeRole - Failed to delete role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	participant, err := c.ParticipantRepository.FindByRoomIDAndUserID(tx, room.ID, request.UserID)
+	if err != nil {
+		c.Log.Warnf("RevokeRole - Failed to find participant: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("RevokeRole - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.RoomRoleChangeResponse{
+		RoomID: room.ID,
+		UserID: request.UserID,
+		Role:   model.RoomRoleParticipant,
+	}
+	if participant != nil {
+		if err = c.TokenUtil.InvalidateParticipantTokens(ctx, participant.ID); err != nil {
+			c.Log.Warnf("RevokeRole - Failed to invalidate participant tokens: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		response.ParticipantID = &participant.ID
+	}
+
+	return response, nil
+}
+
+// ListRoles usecase untuk daftar co-host dan moderator room (owner atau co-host)
+func (c *RoomUseCase) ListRoles(ctx context.Context, request *model.ListRoomRolesRequest) (*model.RoomRoleListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListRoles - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, _, err := c.findHostedRoom(tx, request.RoomID, request.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := c.RoomRoleRepository.ListByRoomID(tx, room.ID, request.Role)
+	if err != nil {
+		c.Log.Warnf("ListRoles - Failed to list roles: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.RoomRoleResponse, len(roles))
+	for i := range roles {
+		participant, err := c.ParticipantRepository.FindByRoomIDAndUserID(tx, room.ID, roles[i].UserID)
+		if err != nil {
+			c.Log.Warnf("ListRoles - Failed to find participant: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		var participantID *uint
+		if participant != nil {
+			participantID = &participant.ID
+		}
+		responses[i] = *converter.RoomRoleToResponse(&roles[i], participantID)
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("ListRoles - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.RoomRoleListResponse{Roles: responses}, nil
+}
+
+// AddModerator usecase untuk menunjuk participant sebagai moderator (owner atau co-host).
+// Participant harus user terdaftar karena role disimpan per user.
+func (c *RoomUseCase) AddModerator(ctx context.Context, request *model.AddModeratorRequest) (*model.ModeratorResponse, error) {
+	response, err := c.GrantRole(ctx, &model.GrantRoomRoleRequest{
+		ActorID:       request.PresenterID,
+		RoomID:        request.RoomID,
+		ParticipantID: request.ParticipantID,
+		Role:          model.RoomRoleModerator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return converter.RoomRoleToModeratorResponse(response), nil
+}
+
+// RemoveModerator usecase untuk mencabut moderator (owner atau co-host)
+func (c *RoomUseCase) RemoveModerator(ctx context.Context, request *model.RemoveModeratorRequest) (*model.RoomRoleChangeResponse, error) {
+	return c.RevokeRole(ctx, &model.RevokeRoomRoleRequest{
+		ActorID: request.PresenterID,
+		RoomID:  request.RoomID,
+		UserID:  request.UserID,
+		Role:    model.RoomRoleModerator,
+	})
+}
+
+// ListModerators usecase untuk daftar moderator room (owner atau co-host)
+func (c *RoomUseCase) ListModerators(ctx context.Context, request *model.ListModeratorsRequest) (*model.ModeratorListResponse, error) {
+	response, err := c.ListRoles(ctx, &model.ListRoomRolesRequest{
+		ActorID: request.PresenterID,
+		RoomID:  request.RoomID,
+		Role:    model.RoomRoleModerator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	moderators := make([]model.ModeratorResponse, len(response.Roles))
+	for i := range response.Roles {
+		moderators[i] = *converter.RoomRoleToModeratorResponse(&response.Roles[i])
+	}
+	return &model.ModeratorListResponse{Moderators: moderators}, nil
+}
+
+// findHostedRoom cari room dan pastikan actor adalah owner atau co-host room
+func (c *RoomUseCase) findHostedRoom(tx *gorm.DB, roomID, actorID uint) (*entity.Room, string, error) {
+	var room entity.Room
+	if err := c.RoomRepository.FindById(tx, &room, roomID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find room: %+v", err)
+		return nil, "", fiber.ErrInternalServerError
+	}
+
+	actorRole, err := c.RoomRoleRepository.GetRole(tx, &room, actorID)
+	if err != nil {
+		c.Log.Warnf("Failed to get room role: %+v", err)
+		return nil, "", fiber.ErrInternalServerError
+	}
+	if !model.RoomRoleCanHost(actorRole) {
+		return nil, "", fiber.ErrForbidden
+	}
+	return &room, actorRole, nil
+}
+
+// upsertRole simpan role user di room, co-host hanya bisa dikelola oleh owner
+func (c *RoomUseCase) upsertRole(tx *gorm.DB, room *entity.Room, actorRole string, actorID, userID uint, role string) (*entity.RoomRole, error) {
+	if role == model.RoomRoleCoHost && actorRole != model.RoomRoleOwner {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner can manage co-hosts")
+	}
+	if userID == room.PresenterID {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Room owner already has every permission")
+	}
+
+	existing, err := c.RoomRoleRepository.FindByRoomIDAndUserID(tx, room.ID, userID)
+	if err != nil {
+		c.Log.Warnf("Failed to find role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if existing != nil {
+		if existing.Role == role {
+			return nil, fiber.NewError(fiber.StatusConflict, "User already has this role")
+		}
+		// co-host tidak bisa menurunkan co-host lain menjadi moderator
+		if existing.Role == model.RoomRoleCoHost && actorRole != model.RoomRoleOwner {
+			return nil, fiber.NewError(fiber.StatusForbidden, "Only the room owner can manage co-hosts")
+		}
+		existing.Role = role
+		existing.GrantedBy = &actorID
+		if err = c.RoomRoleRepository.UpdateRole(tx, existing); err != nil {
+			c.Log.Warnf("Failed to update role: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		return existing, nil
+	}
+
+	roomRole := &entity.RoomRole{
+		RoomID:    room.ID,
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: &actorID,
+	}
+	if err = c.RoomRoleRepository.Create(tx, roomRole); err != nil {
+		c.Log.Warnf("Failed to create role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// reload supaya data user ikut di response
+	roomRole, err = c.RoomRoleRepository.FindByRoomIDAndUserID(tx, room.ID, userID)
+	if err != nil || roomRole == nil {
+		c.Log.Warnf("Failed to load role: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return roomRole, nil
+}
@@ -8,6 +8,7 @@ import (
 	"reisify/internal/model"
 	"reisify/internal/model/converter"
 	"reisify/internal/repository"
+	"reisify/internal/util"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -24,16 +25,22 @@ type RoomUseCase struct {
 	Validate              *validator.Validate
 	RoomRepository        *repository.RoomRepository
 	ParticipantRepository *repository.ParticipantRepository
+	RoomRoleRepository    *repository.RoomRoleRepository
+	UserRepository        *repository.UserRepository
+	TokenUtil             *util.TokenUtil
 }
 
 // NewRoomUseCase create new instance of RoomUseCase
-func NewRoomUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository) *RoomUseCase {
+func NewRoomUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, roomRepository *repository.RoomRepository, participantRepository *repository.ParticipantRepository, roomRoleRepository *repository.RoomRoleRepository, userRepository *repository.UserRepository, tokenUtil *util.TokenUtil) *RoomUseCase {
 	return &RoomUseCase{
 		DB:                    db,
 		Log:                   log,
 		Validate:              validate,
 		RoomRepository:        roomRepository,
 		ParticipantRepository: participantRepository,
+		RoomRoleRepository:    roomRoleRepository,
+		UserRepository:        userRepository,
+		TokenUtil:             tokenUtil,
 	}
 }
 
@@ -254,6 +261,48 @@ func (c *RoomUseCase) Delete(ctx context.Context, request *model.DeleteRoomReque
 	return nil
 }
 
+// UpdateSettings usecase untuk mengubah pengaturan room (presenter only)
+func (c *RoomUseCase) UpdateSettings(ctx context.Context, request *model.UpdateRoomSettingsRequest) (*model.RoomSettingsResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("UpdateSettings - Invalid request: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	room, err := c.RoomRepository.FindByIdAndPresenterId(tx, request.RoomID, request.PresenterID)
+	if err != nil {
+		c.Log.Warnf("UpdateSettings - Failed to find room: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if room == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	// question yang sudah di antrian tetap menunggu keputusan moderator walaupun moderasi dimatikan
+	updates := map[string]interface{}{}
+	if request.QuestionModeration != nil {
+		room.QuestionModeration = *request.QuestionModeration
+		updates["question_moderation"] = room.QuestionModeration
+	}
+	if request.ParticipantReplies != nil {
+		room.ParticipantReplies = *request.ParticipantReplies
+		updates["participant_replies"] = room.ParticipantReplies
+	}
+	if err = tx.Model(room).Updates(updates).Error; err != nil {
+		c.Log.Warnf("UpdateSettings - Failed to update room: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		c.Log.Warnf("UpdateSettings - Failed to commit transaction: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RoomToSettingsResponse(room), nil
+}
+
 // GenerateRoomCode generate with crypto/rand
 func GenerateRoomCode(n int) (string, error) {
 	result := make([]byte, n)
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/util"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionUseCase usecase untuk session login user (device yang sedang login)
+type SessionUseCase struct {
+	Log       *logrus.Logger
+	Validate  *validator.Validate
+	TokenUtil *util.TokenUtil
+}
+
+// NewSessionUseCase create new instance of SessionUseCase
+func NewSessionUseCase(log *logrus.Logger, validate *validator.Validate, tokenUtil *util.TokenUtil) *SessionUseCase {
+	return &SessionUseCase{
+		Log:       log,
+		Validate:  validate,
+		TokenUtil: tokenUtil,
+	}
+}
+
+// List daftar session aktif milik user, session saat ini ditandai current
+func (c *SessionUseCase) List(ctx context.Context, request *model.ListSessionsRequest) (*model.SessionListResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("List - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	sessions, err := c.TokenUtil.ListUserSessions(ctx, request.UserID)
+	if err != nil {
+		c.Log.Errorf("List - TokenUtil.ListUserSessions error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.SessionsToListResponse(sessions, request.SessionID), nil
+}
+
+// Revoke cabut satu session milik user, access token dan refresh token session itu langsung tidak berlaku
+func (c *SessionUseCase) Revoke(ctx context.Context, request *model.RevokeSessionRequest) error {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Revoke - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	session, err := c.TokenUtil.FindSession(ctx, request.SessionID)
+	if err != nil {
+		c.Log.Errorf("Revoke - TokenUtil.FindSession error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	// session user lain diperlakukan sama dengan session yang tidak ada
+	if session == nil || session.UserID == nil || *session.UserID != request.UserID {
+		c.Log.Warnf("Revoke - Session %s not found for user %d", request.SessionID, request.UserID)
+		return fiber.ErrNotFound
+	}
+
+	if err = c.TokenUtil.RevokeSession(ctx, request.SessionID); err != nil {
+		c.Log.Errorf("Revoke - TokenUtil.RevokeSession error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"reisify/internal/entity"
 	"reisify/internal/model"
 	"reisify/internal/model/converter"
@@ -22,11 +23,12 @@ type UserUseCase struct {
 	UserRepository        *repository.UserRepository
 	ParticipantRepository *repository.ParticipantRepository
 	RoomRepository        *repository.RoomRepository
+	RoomBanRepository     *repository.RoomBanRepository
 	TokenUtil             *util.TokenUtil
 }
 
 // NewUserUseCase create new instance of UserUseCase
-func NewUserUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, userRepository *repository.UserRepository, participantRepository *repository.ParticipantRepository, roomRepository *repository.RoomRepository, tokenUtil *util.TokenUtil) *UserUseCase {
+func NewUserUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, userRepository *repository.UserRepository, participantRepository *repository.ParticipantRepository, roomRepository *repository.RoomRepository, roomBanRepository *repository.RoomBanRepository, tokenUtil *util.TokenUtil) *UserUseCase {
 	return &UserUseCase{
 		DB:                    db,
 		Log:                   log,
@@ -34,6 +36,7 @@ func NewUserUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validat
 		UserRepository:        userRepository,
 		ParticipantRepository: participantRepository,
 		RoomRepository:        roomRepository,
+		RoomBanRepository:     roomBanRepository,
 		TokenUtil:             tokenUtil,
 	}
 }
@@ -98,20 +101,20 @@ func (c *UserUseCase) Create(ctx context.Context, request *model.RegisterUserReq
 	}
 
 	// create a token jwt
-	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+	tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 		UserID:      &user.ID,
 		Username:    user.Username,
 		Email:       user.Email,
 		Role:        user.Role,
 		IsAnonymous: false,
-	})
+	}, request.Client)
 	if err != nil {
 		c.Log.Warnf("Failed to create token: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	// return and convert to user response
-	return converter.UserToAuthResponse(user, token), nil
+	return converter.UserToAuthResponse(user, tokens), nil
 }
 
 // Login usecase untuk melakukan login user
@@ -153,20 +156,20 @@ func (c *UserUseCase) Login(ctx context.Context, request *model.LoginUserRequest
 	}
 
 	// create a token jwt
-	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+	tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 		UserID:      &existingUser.ID,
 		Email:       existingUser.Email,
 		Role:        existingUser.Role,
 		Username:    existingUser.Username,
 		IsAnonymous: false,
-	})
+	}, request.Client)
 	if err != nil {
 		c.Log.Warnf("Failed to create token: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	// return and convert to user response
-	return converter.UserToAuthResponse(existingUser, token), nil
+	return converter.UserToAuthResponse(existingUser, tokens), nil
 }
 
 // Anon usecase untuk membuat user anonymous
@@ -200,6 +203,19 @@ func (c *UserUseCase) Anon(ctx context.Context, request *model.AnonymousUserRequ
 		return nil, fiber.ErrBadRequest
 	}
 
+	// device yang di-ban tidak bisa join ulang sebagai anonymous
+	if request.Fingerprint != "" {
+		banned, err := c.RoomBanRepository.IsFingerprintBanned(tx, roomExisting.ID, request.Fingerprint)
+		if err != nil {
+			c.Log.Errorf("Failed to check room ban: %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if banned {
+			c.Log.Warnf("Anonymous device is banned from room %d", roomExisting.ID)
+			return nil, fiber.NewError(fiber.StatusForbidden, "You are banned from this room")
+		}
+	}
+
 	anon := true
 
 	// create participant entity
@@ -208,6 +224,9 @@ func (c *UserUseCase) Anon(ctx context.Context, request *model.AnonymousUserRequ
 		DisplayName: request.DisplayName,
 		IsAnonymous: &anon,
 	}
+	if request.Fingerprint != "" {
+		participant.Fingerprint = &request.Fingerprint
+	}
 
 	// create participant in repository
 	if err = c.ParticipantRepository.Create(tx, participant); err != nil {
@@ -221,33 +240,75 @@ func (c *UserUseCase) Anon(ctx context.Context, request *model.AnonymousUserRequ
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// Anonymous users are never room owners (always audience)
+	// Anonymous users are never room owners and cannot hold a room role
 	isRoomOwner := false
 
 	// create a token jwt for anonymous user
-	token, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
+	tokens, err := c.TokenUtil.CreateToken(ctx, &model.Auth{
 		ParticipantID: &participant.ID,
 		RoomID:        &roomExisting.ID,
 		DisplayName:   participant.DisplayName,
 		Role:          "anonymous",
 		IsAnonymous:   *participant.IsAnonymous,
 		IsRoomOwner:   isRoomOwner,
-	})
+		RoomRole:      model.RoomRoleParticipant,
+	}, request.Client)
 	if err != nil {
 		c.Log.Errorf("Failed to create token: %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
-	// return and convert to join room response with role (always audience for anonymous)
-	return converter.ParticipantToJoinRoomResponseWithRole(participant, token, isRoomOwner), nil
+	// return and convert to join room response with role (always participant for anonymous)
+	return converter.ParticipantToJoinRoomResponseWithRole(participant, tokens, model.RoomRoleParticipant), nil
 }
 
-// Logout usecase untuk logout user dengan invalidate token
-func (c *UserUseCase) Logout(ctx context.Context, tokenString string) error {
-	// invalidate token di redis
-	if err := c.TokenUtil.InvalidateToken(ctx, tokenString); err != nil {
-		c.Log.Errorf("Failed to invalidate token: %+v", err)
+// Refresh usecase untuk tukar refresh token dengan access token dan refresh token baru (rotasi)
+func (c *UserUseCase) Refresh(ctx context.Context, request *model.RefreshTokenRequest) (*model.TokenPair, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	tokens, err := c.TokenUtil.Refresh(ctx, request.RefreshToken, request.Client)
+	if err != nil {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			c.Log.Warnf("Failed to refresh token: %+v", err)
+			return nil, err
+		}
+		c.Log.Errorf("Failed to refresh token: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return tokens, nil
+}
+
+// Logout usecase untuk logout user dengan mencabut session saat ini
+func (c *UserUseCase) Logout(ctx context.Context, request *model.LogoutRequest) error {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return fiber.ErrUnauthorized
+	}
+
+	// hapus session di redis, access token dan refresh token session ini tidak berlaku lagi
+	if err := c.TokenUtil.RevokeSession(ctx, request.SessionID); err != nil {
+		c.Log.Errorf("Failed to revoke session: %+v", err)
 		return fiber.ErrInternalServerError
 	}
 	return nil
 }
+
+// LogoutAll usecase untuk logout dari semua session user di semua device, return id session yang dicabut
+func (c *UserUseCase) LogoutAll(ctx context.Context, request *model.LogoutAllRequest) ([]string, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %+v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	sessionIDs, err := c.TokenUtil.RevokeUserSessions(ctx, request.UserID)
+	if err != nil {
+		c.Log.Errorf("Failed to revoke user sessions: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	return sessionIDs, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrWebhookTargetBlocked alamat tujuan webhook ada di jaringan internal
+var ErrWebhookTargetBlocked = errors.New("webhook target address is not allowed")
+
+// range khusus yang tidak tercakup IsPrivate, IsLoopback, IsLinkLocal* dan IsMulticast
+var webhookBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved
+	netip.MustParsePrefix("100::/64"),       // discard-only
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, bisa menunjuk ke IPv4 internal
+	netip.MustParsePrefix("64:ff9b:1::/48"), // NAT64 lokal
+	netip.MustParsePrefix("2002::/16"),      // 6to4, bisa menunjuk ke IPv4 internal
+	netip.MustParsePrefix("2001:db8::/32"),  // dokumentasi
+	netip.MustParsePrefix("fec0::/10"),      // site-local (deprecated)
+}
+
+// NewWebhookHTTPClient http client untuk delivery webhook. Jika allowPrivate false, koneksi ke alamat
+// loopback, private, link-local, multicast dan range khusus lain ditolak. Pengecekan dilakukan saat dial
+// terhadap IP hasil resolve sehingga tidak bisa dilewati dengan DNS rebinding. Redirect tidak pernah diikuti.
+func NewWebhookHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = webhookDialControl
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// proxy dari environment akan membuat dial ke proxy, bukan ke target, sehingga pengecekan IP tidak berlaku
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// response 3xx dicatat apa adanya sebagai delivery gagal
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookDialControl tolak koneksi ke IP yang tidak boleh dituju webhook
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrWebhookTargetBlocked
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || IsWebhookAddrBlocked(addr) {
+		return ErrWebhookTargetBlocked
+	}
+	return nil
+}
+
+// IsWebhookAddrBlocked true jika alamat ada di jaringan internal atau range khusus
+func IsWebhookAddrBlocked(addr netip.Addr) bool {
+	addr = addr.WithZone("")
+	if addr.Is4In6() {
+		addr = addr.Unmap()
+	}
+	// IsGlobalUnicast false untuk loopback, link-local (169.254.0.0/16, metadata cloud), multicast dan unspecified
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return true
+	}
+	for _, prefix := range webhookBlockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"strconv"
+	"time"
+)
+
+const (
+	// webhookLease waktu sebuah delivery dipegang satu node sebelum boleh diambil worker lain
+	webhookLease = time.Minute
+	// webhookBatchSize jumlah delivery jatuh tempo yang diproses per tick worker
+	webhookBatchSize = 50
+	// webhookResponseLimit potongan body response yang disimpan di delivery log
+	webhookResponseLimit = 1024
+
+	webhookBackoffBase = 30 * time.Second
+	webhookBackoffMax  = time.Hour
+)
+
+// Header request webhook
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// Dispatch membuat delivery untuk setiap webhook aktif room yang memilih event ini lalu mengirimnya langsung.
+// Delivery yang gagal dicoba ulang oleh worker dengan backoff.
+func (c *WebhookUseCase) Dispatch(ctx context.Context, roomID uint, event string, data json.RawMessage) error {
+	if !model.IsWebhookEvent(event) {
+		return nil
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	room := new(entity.Room)
+	if err := c.RoomRepository.FindById(db, room, roomID); err != nil {
+		c.Log.Warnf("Dispatch - RoomRepository.FindById error: %v", err)
+		return err
+	}
+
+	webhooks, err := c.WebhookRepository.ListActiveForRoom(db, room.ID, room.PresenterID)
+	if err != nil {
+		c.Log.Errorf("Dispatch - WebhookRepository.ListActiveForRoom error: %v", err)
+		return err
+	}
+	targets := make([]entity.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		for _, e := range webhook.Events {
+			if e == event {
+				targets = append(targets, webhook)
+				break
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(model.WebhookPayload{
+		ID:        eventID,
+		Event:     event,
+		RoomID:    room.ID,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	// delivery langsung di-lease oleh node ini, worker hanya mengambil jika node ini gagal sebelum selesai
+	leaseUntil := now.Add(webhookLease)
+	deliveries := make([]entity.WebhookDelivery, len(targets))
+	for i := range targets {
+		deliveries[i] = entity.WebhookDelivery{
+			WebhookID:     targets[i].ID,
+			RoomID:        room.ID,
+			Event:         event,
+			Payload:       string(body),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: &leaseUntil,
+		}
+	}
+	if err := c.WebhookDeliveryRepository.CreateBatch(db, deliveries); err != nil {
+		c.Log.Errorf("Dispatch - WebhookDeliveryRepository.CreateBatch error: %v", err)
+		return err
+	}
+
+	for i := range deliveries {
+		c.attempt(ctx, &deliveries[i], &targets[i])
+	}
+	return nil
+}
+
+// DeliverDue kirim ulang delivery pending yang sudah jatuh tempo, dipanggil berkala oleh worker
+func (c *WebhookUseCase) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	deliveries, err := c.WebhookDeliveryRepository.ClaimDue(tx, now, now.Add(webhookLease), webhookBatchSize)
+	if err != nil {
+		c.Log.Errorf("DeliverDue - WebhookDeliveryRepository.ClaimDue error: %v", err)
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("DeliverDue - Commit error: %v", err)
+		return 0, err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		webhook := delivery.Webhook
+		c.attempt(ctx, delivery, &webhook)
+	}
+	return len(deliveries), nil
+}
+
+// attempt kirim satu delivery lalu simpan hasilnya
+func (c *WebhookUseCase) attempt(ctx context.Context, delivery *entity.WebhookDelivery, webhook *entity.Webhook) {
+	if webhook.IsActive {
+		status, body, sendErr := SendWebhook(ctx, c.HTTPClient, webhook.URL, webhook.Secret, delivery, time.Now())
+		ApplyWebhookAttempt(delivery, status, body, sendErr, time.Now(), c.MaxAttempts)
+	} else {
+		// webhook dinonaktifkan setelah delivery dibuat, tidak perlu dicoba ulang
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.LastError = "webhook is disabled"
+		delivery.NextAttemptAt = nil
+	}
+
+	if err := c.WebhookDeliveryRepository.UpdateResult(c.DB.WithContext(context.Background()), delivery); err != nil {
+		c.Log.Errorf("attempt - WebhookDeliveryRepository.UpdateResult error: %v", err)
+	}
+}
+
+// SendWebhook POST payload delivery ke url dengan header event, delivery id dan signature HMAC.
+// Mengembalikan status code dan potongan body response.
+func SendWebhook(ctx context.Context, client *http.Client, url, secret string, delivery *entity.WebhookDelivery, now time.Time) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "reisify-webhook/1.0")
+	req.Header.Set(WebhookHeaderEvent, delivery.Event)
+	req.Header.Set(WebhookHeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookHeaderSignature, fmt.Sprintf("t=%d,v1=%s", timestamp, SignWebhookPayload(secret, timestamp, body)))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	return resp.StatusCode, string(snippet), nil
+}
+
+// SignWebhookPayload HMAC-SHA256 hex dari "<timestamp>.<body>" dengan secret webhook
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ApplyWebhookAttempt update delivery dari hasil satu percobaan: 2xx = success,
+// selain itu dijadwalkan ulang dengan backoff sampai maxAttempts lalu failed
+func ApplyWebhookAttempt(delivery *entity.WebhookDelivery, status int, body string, sendErr error, now time.Time, maxAttempts int) {
+	delivery.Attempts++
+	delivery.ResponseBody = body
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case sendErr == nil && status >= 200 && status < 300:
+		delivery.Status = model.WebhookDeliverySuccess
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return
+	case sendErr != nil:
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.LastError = fmt.Sprintf("unexpected status %d", status)
+	}
+
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(WebhookBackoff(delivery.Attempts))
+	delivery.Status = model.WebhookDeliveryPending
+	delivery.NextAttemptAt = &next
+}
+
+// WebhookBackoff jeda sebelum percobaan berikutnya: 30s, 1m, 2m, 4m, ... maksimal 1 jam
+func WebhookBackoff(attempts int) time.Duration {
+	delay := webhookBackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookBackoffMax {
+			return webhookBackoffMax
+		}
+	}
+	return delay
+}
+
+// newWebhookEventID id unik event, dipakai receiver untuk deduplikasi
+func newWebhookEventID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultWebhookMaxAttempts jumlah percobaan sebelum delivery ditandai failed
+	DefaultWebhookMaxAttempts = 6
+	// DefaultWebhookTimeout timeout satu request ke webhook
+	DefaultWebhookTimeout = 10 * time.Second
+
+	maxWebhooksPerUser = 20
+)
+
+type WebhookUseCase struct {
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	HTTPClient                *http.Client
+	MaxAttempts               int
+	WebhookRepository         *repository.WebhookRepository
+	WebhookDeliveryRepository *repository.WebhookDeliveryRepository
+	RoomRepository            *repository.RoomRepository
+}
+
+// NewWebhookUseCase create new instance of WebhookUseCase.
+// allowPrivateTargets mengizinkan webhook ke alamat internal, hanya untuk development dan test
+func NewWebhookUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, timeout time.Duration, maxAttempts int, allowPrivateTargets bool,
+	webhookRepository *repository.WebhookRepository, webhookDeliveryRepository *repository.WebhookDeliveryRepository,
+	roomRepository *repository.RoomRepository) *WebhookUseCase {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookMaxAttempts
+	}
+	return &WebhookUseCase{
+		DB:                        db,
+		Log:                       log,
+		Validate:                  validate,
+		HTTPClient:                NewWebhookHTTPClient(timeout, allowPrivateTargets),
+		MaxAttempts:               maxAttempts,
+		WebhookRepository:         webhookRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		RoomRepository:            roomRepository,
+	}
+}
+
+// Create usecase untuk mendaftarkan webhook, secret hanya dikembalikan di response ini
+func (c *WebhookUseCase) Create(ctx context.Context, request *model.CreateWebhookRequest) (*model.WebhookResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("CreateWebhook - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// webhook per room hanya untuk room milik user
+	if request.RoomID != nil {
+		room, err := c.RoomRepository.FindByIdAndPresenterId(tx, *request.RoomID, request.UserID)
+		if err != nil {
+			c.Log.Errorf("CreateWebhook - RoomRepository.FindByIdAndPresenterId error: %v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if room == nil {
+			return nil, fiber.ErrNotFound
+		}
+	}
+
+	existing, err := c.WebhookRepository.ListByUserID(tx, request.UserID)
+	if err != nil {
+		c.Log.Errorf("CreateWebhook - WebhookRepository.ListByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Webhook limit reached")
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		c.Log.Errorf("CreateWebhook - newWebhookSecret error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	webhook := &entity.Webhook{
+		UserID:   request.UserID,
+		RoomID:   request.RoomID,
+		URL:      request.URL,
+		Secret:   secret,
+		Events:   uniqueStrings(request.Events),
+		IsActive: true,
+	}
+	if err := c.WebhookRepository.Create(tx, webhook); err != nil {
+		c.Log.Errorf("CreateWebhook - WebhookRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("CreateWebhook - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.WebhookToResponse(webhook)
+	response.Secret = webhook.Secret
+	return response, nil
+}
+
+// List usecase untuk daftar webhook milik user
+func (c *WebhookUseCase) List(ctx context.Context, request *model.ListWebhooksRequest) (*model.WebhookListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListWebhooks - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	webhooks, err := c.WebhookRepository.ListByUserID(tx, request.UserID)
+	if err != nil {
+		c.Log.Errorf("ListWebhooks - WebhookRepository.ListByUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ListWebhooks - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WebhooksToListResponse(webhooks), nil
+}
+
+// Get usecase untuk detail webhook
+func (c *WebhookUseCase) Get(ctx context.Context, request *model.GetWebhookRequest) (*model.WebhookResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("GetWebhook - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	webhook, err := c.findWebhook(tx, request.WebhookID, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("GetWebhook - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WebhookToResponse(webhook), nil
+}
+
+// Update usecase untuk mengubah url, event atau status aktif webhook
+func (c *WebhookUseCase) Update(ctx context.Context, request *model.UpdateWebhookRequest) (*model.WebhookResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("UpdateWebhook - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	webhook, err := c.findWebhook(tx, request.WebhookID, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.URL != nil {
+		webhook.URL = *request.URL
+	}
+	if request.Events != nil {
+		webhook.Events = uniqueStrings(request.Events)
+	}
+	if request.IsActive != nil {
+		webhook.IsActive = *request.IsActive
+	}
+
+	if err := c.WebhookRepository.Update(tx, webhook); err != nil {
+		c.Log.Errorf("UpdateWebhook - WebhookRepository.Update error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("UpdateWebhook - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WebhookToResponse(webhook), nil
+}
+
+// Delete usecase untuk menghapus webhook beserta delivery log-nya
+func (c *WebhookUseCase) Delete(ctx context.Context, request *model.GetWebhookRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("DeleteWebhook - Invalid request: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	webhook, err := c.findWebhook(tx, request.WebhookID, request.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.WebhookRepository.Delete(tx, webhook); err != nil {
+		c.Log.Errorf("DeleteWebhook - WebhookRepository.Delete error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("DeleteWebhook - Commit error: %v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// ListDeliveries usecase untuk delivery log webhook, terbaru dulu
+func (c *WebhookUseCase) ListDeliveries(ctx context.Context, request *model.ListWebhookDeliveriesRequest) (*model.WebhookDeliveryListResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ListWebhookDeliveries - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// set default limit
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	if _, err := c.findWebhook(tx, request.WebhookID, request.UserID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := c.WebhookDeliveryRepository.ListByWebhookID(tx, request.WebhookID, request.Status, request.Limit)
+	if err != nil {
+		c.Log.Errorf("ListWebhookDeliveries - WebhookDeliveryRepository.ListByWebhookID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ListWebhookDeliveries - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.WebhookDeliveriesToListResponse(deliveries), nil
+}
+
+// Replay usecase untuk mengirim ulang payload delivery lama sebagai delivery baru.
+// Percobaan pertama dijalankan langsung, retry berikutnya oleh worker.
+func (c *WebhookUseCase) Replay(ctx context.Context, request *model.ReplayWebhookDeliveryRequest) (*model.WebhookDeliveryResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// validate request
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("ReplayWebhookDelivery - Invalid request: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	webhook, err := c.findWebhook(tx, request.WebhookID, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := c.WebhookDeliveryRepository.FindByIdAndWebhookID(tx, request.DeliveryID, webhook.ID)
+	if err != nil {
+		c.Log.Errorf("ReplayWebhookDelivery - WebhookDeliveryRepository.FindByIdAndWebhookID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if original == nil {
+		return nil, fiber.ErrNotFound
+	}
+
+	leaseUntil := time.Now().Add(webhookLease)
+	delivery := &entity.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		RoomID:        original.RoomID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: &leaseUntil,
+		ReplayOf:      &original.ID,
+	}
+	if err := c.WebhookDeliveryRepository.Create(tx, delivery); err != nil {
+		c.Log.Errorf("ReplayWebhookDelivery - WebhookDeliveryRepository.Create error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Errorf("ReplayWebhookDelivery - Commit error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.attempt(ctx, delivery, webhook)
+	return converter.WebhookDeliveryToResponse(delivery), nil
+}
+
+// findWebhook cari webhook milik user, 404 jika tidak ada
+func (c *WebhookUseCase) findWebhook(tx *gorm.DB, webhookID, userID uint) (*entity.Webhook, error) {
+	webhook, err := c.WebhookRepository.FindByIdAndUserID(tx, webhookID, userID)
+	if err != nil {
+		c.Log.Errorf("findWebhook - WebhookRepository.FindByIdAndUserID error: %v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if webhook == nil {
+		return nil, fiber.ErrNotFound
+	}
+	return webhook, nil
+}
+
+// newWebhookSecret generate secret HMAC acak
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// uniqueStrings buang duplikat dengan urutan tetap
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -53,6 +53,36 @@ func (c *XPTransactionUseCase) AddXPForMessage(tx *gorm.DB, roomID, participantI
 	return nil
 }
 
+// RevokeXPForMessage membatalkan XP yang didapat dari message (dipakai saat message dihapus owner room).
+// Dicatat sebagai transaksi negatif dengan source yang sama sehingga riwayat tetap utuh.
+func (c *XPTransactionUseCase) RevokeXPForMessage(tx *gorm.DB, roomID, participantID, messageID uint) (int, error) {
+	granted, err := c.XPTransactionRepository.SumPointsBySource(tx, "message_created", messageID)
+	if err != nil {
+		return 0, err
+	}
+	if granted <= 0 {
+		return 0, nil
+	}
+
+	xp := &entity.XPTransaction{
+		ParticipantID: participantID,
+		RoomID:        roomID,
+		Points:        -granted,
+		SourceType:    "message_created",
+		SourceID:      messageID,
+	}
+
+	if err := c.XPTransactionRepository.Create(tx, xp); err != nil {
+		return 0, err
+	}
+
+	if err := c.XPTransactionRepository.AddXP(tx, participantID, -granted); err != nil {
+		return 0, err
+	}
+
+	return granted, nil
+}
+
 // GetTransactions usecase untuk mendapatkan XP transactions history
 func (c *XPTransactionUseCase) GetTransactions(ctx context.Context, request *model.GetXPTransactionsRequest) (*model.GetXPTransactionsResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
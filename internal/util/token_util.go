@@ -2,9 +2,17 @@ package util
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reisify/internal/model"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,48 +22,217 @@ import (
 
 const minJWTSecretLength = 32
 
+const (
+	// DefaultAccessTokenTTL masa berlaku access token (JWT)
+	DefaultAccessTokenTTL = 15 * time.Minute
+	// DefaultRefreshTokenTTL masa berlaku session / refresh token sejak refresh terakhir
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// RefreshReuseGrace refresh token yang baru saja dirotasi masih diterima selama ini (refresh bersamaan
+	// dari beberapa tab), bukan dianggap reuse
+	RefreshReuseGrace = 20 * time.Second
+)
+
+// ErrRefreshTokenReused refresh token lama dipakai lagi, session dicabut karena kemungkinan token bocor
+var ErrRefreshTokenReused = fiber.NewError(fiber.StatusUnauthorized, "Refresh token reuse detected, session revoked")
+
+// revokeParticipantScript cabut akses room participant dari satu session.
+// Session yang sedang memakai participant ini kembali ke claims akun (base_claims), session tanpa
+// base_claims (anonymous) dihapus. Return {0} jika session sudah pindah room, {1} jika kembali ke
+// claims akun, {2, user_id} jika session dihapus.
+var revokeParticipantScript = redis.NewScript(`
+local fields = redis.call('HMGET', KEYS[1], 'participant_id', 'base_claims', 'user_id')
+if fields[1] ~= ARGV[1] then
+	return {0}
+end
+if fields[2] then
+	redis.call('HSET', KEYS[1], 'claims', fields[2])
+	redis.call('HDEL', KEYS[1], 'participant_id')
+	return {1}
+end
+redis.call('DEL', KEYS[1])
+return {2, fields[3]}
+`)
+
+// updateClaimsScript tulis ulang field claims hanya jika session masih ada (tidak membuat hash tanpa TTL)
+var updateClaimsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV))
+return 1
+`)
+
+// refreshScript rotasi refresh token secara atomik.
+// Return {1, claims} jika berhasil, {0} jika session tidak ada, {-1} jika hash tidak cocok (reuse).
+// Pasangan hasil rotasi disimpan di KEYS[2] dengan TTL grace window (ARGV[6] detik): secret yang baru
+// saja dirotasi masih diterima dan mendapat pasangan yang sama, {2, claims, secret}. Hash session tetap
+// hanya menyimpan hash refresh token.
+var refreshScript = redis.NewScript(`
+local hash = redis.call('HGET', KEYS[1], 'refresh_hash')
+if not hash then
+	return {0}
+end
+if hash ~= ARGV[1] then
+	local grace = redis.call('HMGET', KEYS[2], 'from', 'secret')
+	if grace[1] == ARGV[1] and grace[2] then
+		return {2, redis.call('HGET', KEYS[1], 'claims'), grace[2]}
+	end
+	return {-1}
+end
+redis.call('HSET', KEYS[1], 'refresh_hash', ARGV[2], 'last_active_at', ARGV[3])
+if ARGV[5] ~= '' then
+	redis.call('HSET', KEYS[1], 'ip', ARGV[5])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('DEL', KEYS[2])
+redis.call('HSET', KEYS[2], 'from', ARGV[1], 'secret', ARGV[7])
+redis.call('EXPIRE', KEYS[2], ARGV[6])
+return {1, redis.call('HGET', KEYS[1], 'claims')}
+`)
+
 // TokenUtil provides methods for creating and parsing JWT tokens.
+//
+// Setiap login membuat session di Redis:
+//   - session:{sid}                  hash claims, user / participant, hash refresh token aktif, device / IP
+//   - user_sessions:{userID}         set sid milik user (logout semua session)
+//   - participant_sessions:{partID}  set sid yang memegang token room participant (kick / ban)
+//   - refresh_grace:{sid}            pasangan refresh token hasil rotasi terakhir, TTL RefreshReuseGrace
+//
+// Access token membawa sid dan hanya valid selama session masih ada. Token room juga harus masih
+// terdaftar di participant_sessions, sehingga kick hanya mencabut akses room, bukan login user.
 type TokenUtil struct {
-	SecretKey string
-	Redis     *redis.Client
+	SecretKey  string
+	Redis      *redis.Client
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
 }
 
 // NewTokenUtil creates a new instance of TokenUtil with the provided secret key.
 // It terminates the process if the secret key is shorter than the minimum required length.
-func NewTokenUtil(secretKey string, redisClient *redis.Client) *TokenUtil {
+// TTL <= 0 memakai default.
+func NewTokenUtil(secretKey string, redisClient *redis.Client, accessTTL, refreshTTL time.Duration) *TokenUtil {
 	if len(secretKey) < minJWTSecretLength {
 		log.Fatalf("JWT_SECRET must be at least %d characters long for HS256 security (current: %d)", minJWTSecretLength, len(secretKey))
 	}
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
 	return &TokenUtil{
-		SecretKey: secretKey,
-		Redis:     redisClient,
+		SecretKey:  secretKey,
+		Redis:      redisClient,
+		AccessTTL:  accessTTL,
+		RefreshTTL: refreshTTL,
 	}
 }
 
-// CreateToken generates a JWT token for the given Auth model.
-func (t *TokenUtil) CreateToken(ctx context.Context, auth *model.Auth) (string, error) {
-	now := time.Now()
-	expiryDuration := time.Hour * 24 * 30 // 30 days
-	// set expiration time for the token
-	auth.ExpiresAt = jwt.NewNumericDate(now.Add(expiryDuration))
-	auth.IssuedAt = jwt.NewNumericDate(now)
+// CreateToken generates an access token for the given Auth model.
+// Jika auth.SessionID menunjuk session yang masih aktif (misal join room setelah login), claims session
+// diganti dan refresh token lama tetap dipakai (RefreshToken kosong). Selain itu dibuat session baru
+// beserta refresh token. client (boleh nil) dicatat sebagai device session.
+func (t *TokenUtil) CreateToken(ctx context.Context, auth *model.Auth, client *model.SessionClient) (*model.TokenPair, error) {
+	if auth.SessionID != "" {
+		exists, err := t.Redis.Exists(ctx, sessionKey(auth.SessionID)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if exists == 1 {
+			if err = t.storeSession(ctx, auth, "", client); err != nil {
+				return nil, err
+			}
+			return t.signAccessToken(auth)
+		}
+	}
 
-	// create structure of the token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth)
+	sessionID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	auth.SessionID = sessionID
 
-	// sign the token with the secret key
-	jwtToken, err := token.SignedString([]byte(t.SecretKey))
+	if err = t.storeSession(ctx, auth, HashToken(secret), client); err != nil {
+		return nil, err
+	}
+
+	tokens, err := t.signAccessToken(auth)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	tokens.RefreshToken = sessionID + "." + secret
+	return tokens, nil
+}
+
+// Refresh tukar refresh token dengan access token dan refresh token baru.
+// Refresh token yang sudah pernah ditukar mencabut seluruh session (ErrRefreshTokenReused), kecuali token
+// yang baru saja dirotasi dalam RefreshReuseGrace: request itu mendapat refresh token yang sama dengan rotasi tadi.
+func (t *TokenUtil) Refresh(ctx context.Context, refreshToken string, client *model.SessionClient) (*model.TokenPair, error) {
+	sessionID, secret, ok := SplitRefreshToken(refreshToken)
+	if !ok {
+		return nil, fiber.ErrUnauthorized
 	}
 
-	// store token in redis with expiration same as token expiration
-	_, err = t.Redis.Set(ctx, jwtToken, "valid", expiryDuration).Result()
+	newSecret, err := randomHex(32)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	ip := ""
+	if client != nil {
+		ip = client.IP
+	}
+
+	result, err := refreshScript.Run(ctx, t.Redis, []string{sessionKey(sessionID), refreshGraceKey(sessionID)},
+		HashToken(secret), HashToken(newSecret), time.Now().Unix(), int(t.RefreshTTL.Seconds()), ip,
+		int(RefreshReuseGrace.Seconds()), newSecret).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	status, _ := result[0].(int64)
+	switch status {
+	case 0:
+		return nil, fiber.ErrUnauthorized
+	case -1:
+		if err = t.RevokeSession(ctx, sessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	case 2:
+		// refresh bersamaan dengan secret yang sama, pakai hasil rotasi sebelumnya
+		newSecret, _ = result[2].(string)
+	}
+
+	claims, _ := result[1].(string)
+	auth := new(model.Auth)
+	if err = json.Unmarshal([]byte(claims), auth); err != nil {
+		return nil, err
 	}
+	auth.SessionID = sessionID
 
-	return jwtToken, nil
+	// index ikut diperpanjang supaya logout all / kick tetap menemukan session ini
+	pipe := t.Redis.Pipeline()
+	if auth.UserID != nil {
+		pipe.Expire(ctx, userSessionsKey(*auth.UserID), t.RefreshTTL)
+	}
+	if auth.ParticipantID != nil {
+		pipe.Expire(ctx, participantSessionsKey(*auth.ParticipantID), t.RefreshTTL)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	tokens, err := t.signAccessToken(auth)
+	if err != nil {
+		return nil, err
+	}
+	tokens.RefreshToken = sessionID + "." + newSecret
+	return tokens, nil
 }
 
 // ParseToken validates and parses the JWT token string and returns the Auth model.
@@ -74,7 +251,7 @@ func (t *TokenUtil) ParseToken(ctx context.Context, tokenString string) (*model.
 
 	// extract claims from the token
 	claims, ok := token.Claims.(*model.Auth)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.SessionID == "" {
 		return nil, fiber.ErrUnauthorized
 	}
 
@@ -83,26 +260,370 @@ func (t *TokenUtil) ParseToken(ctx context.Context, tokenString string) (*model.
 		return nil, fiber.ErrUnauthorized
 	}
 
-	// check if token exists in redis
-	result, err := t.Redis.Exists(ctx, tokenString).Result()
-	if err != nil {
+	// session harus masih ada (belum logout / dicabut), token room juga belum di-kick / ban
+	pipe := t.Redis.Pipeline()
+	exists := pipe.Exists(ctx, sessionKey(claims.SessionID))
+	var member *redis.BoolCmd
+	if claims.ParticipantID != nil {
+		member = pipe.SIsMember(ctx, participantSessionsKey(*claims.ParticipantID), claims.SessionID)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
 		return nil, err
 	}
-
-	// if token not found in redis, return unauthorized
-	if result == 0 {
+	if exists.Val() == 0 || (member != nil && !member.Val()) {
 		return nil, fiber.ErrUnauthorized
 	}
 
 	return claims, nil
 }
 
-// InvalidateToken removes the token from Redis to effectively log out the user
-func (t *TokenUtil) InvalidateToken(ctx context.Context, tokenString string) error {
-	// delete token from redis
-	_, err := t.Redis.Del(ctx, tokenString).Result()
+// RevokeSession hapus session, semua access token dan refresh token session ini langsung tidak berlaku
+func (t *TokenUtil) RevokeSession(ctx context.Context, sessionID string) error {
+	key := sessionKey(sessionID)
+	fields, err := t.Redis.HMGet(ctx, key, "user_id", "participant_id").Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := t.Redis.TxPipeline()
+	pipe.Del(ctx, key, refreshGraceKey(sessionID))
+	if userID, ok := parseUintField(fields[0]); ok {
+		pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+	}
+	if participantID, ok := parseUintField(fields[1]); ok {
+		pipe.SRem(ctx, participantSessionsKey(participantID), sessionID)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUserSessions cabut semua session milik user (logout dari semua device), return id session yang dicabut
+func (t *TokenUtil) RevokeUserSessions(ctx context.Context, userID uint) ([]string, error) {
+	indexKey := userSessionsKey(userID)
+	sessionIDs, err := t.Redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err = t.RevokeSession(ctx, sessionID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = t.Redis.Del(ctx, indexKey).Err(); err != nil {
+		return nil, err
+	}
+	return sessionIDs, nil
+}
+
+// RevokeOtherUserSessions cabut semua session user kecuali keepSessionID (ganti password), return id session yang dicabut
+func (t *TokenUtil) RevokeOtherUserSessions(ctx context.Context, userID uint, keepSessionID string) ([]string, error) {
+	sessionIDs, err := t.Redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		if sessionID == keepSessionID {
+			continue
+		}
+		if err = t.RevokeSession(ctx, sessionID); err != nil {
+			return nil, err
+		}
+		revoked = append(revoked, sessionID)
+	}
+	return revoked, nil
+}
+
+// UpdateUserClaims ubah claims (dan base_claims) semua session user, misal setelah username atau email berubah.
+// Access token baru dari refresh memakai claims yang sudah diubah
+func (t *TokenUtil) UpdateUserClaims(ctx context.Context, userID uint, update func(auth *model.Auth)) error {
+	sessionIDs, err := t.Redis.SMembers(ctx, userSessionsKey(userID)).Result()
 	if err != nil {
 		return err
 	}
+
+	for _, sessionID := range sessionIDs {
+		key := sessionKey(sessionID)
+		fields, err := t.Redis.HMGet(ctx, key, "claims", "base_claims").Result()
+		if err != nil {
+			return err
+		}
+
+		args := make([]interface{}, 0, 4)
+		for i, name := range []string{"claims", "base_claims"} {
+			raw, ok := fields[i].(string)
+			if !ok {
+				continue
+			}
+			auth := new(model.Auth)
+			if err := json.Unmarshal([]byte(raw), auth); err != nil {
+				return err
+			}
+			update(auth)
+			data, err := json.Marshal(auth)
+			if err != nil {
+				return err
+			}
+			args = append(args, name, string(data))
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if err := updateClaimsScript.Run(ctx, t.Redis, []string{key}, args...).Err(); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+	}
 	return nil
 }
+
+// FindSession ambil data session, nil jika session tidak ada (logout / expired)
+func (t *TokenUtil) FindSession(ctx context.Context, sessionID string) (*model.SessionInfo, error) {
+	values, err := t.Redis.HGetAll(ctx, sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return ParseSessionHash(sessionID, values), nil
+}
+
+// ListUserSessions daftar session aktif milik user, urut dari yang terakhir aktif.
+// Session yang sudah expired dibersihkan dari index.
+func (t *TokenUtil) ListUserSessions(ctx context.Context, userID uint) ([]model.SessionInfo, error) {
+	indexKey := userSessionsKey(userID)
+	sessionIDs, err := t.Redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := t.Redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		cmds[i] = pipe.HGetAll(ctx, sessionKey(sessionID))
+	}
+	if _, err = pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	sessions := make([]model.SessionInfo, 0, len(sessionIDs))
+	stale := make([]interface{}, 0)
+	for i, sessionID := range sessionIDs {
+		session := ParseSessionHash(sessionID, cmds[i].Val())
+		if session == nil {
+			stale = append(stale, sessionID)
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+	if len(stale) > 0 {
+		if err = t.Redis.SRem(ctx, indexKey, stale...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt.After(sessions[j].LastActiveAt)
+	})
+	return sessions, nil
+}
+
+// InvalidateParticipantTokens invalidates every room-scoped token of the participant.
+// Login user tetap berlaku, session anonymous ikut dihapus.
+func (t *TokenUtil) InvalidateParticipantTokens(ctx context.Context, participantID uint) error {
+	indexKey := participantSessionsKey(participantID)
+	sessionIDs, err := t.Redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		result, err := revokeParticipantScript.Run(ctx, t.Redis, []string{sessionKey(sessionID)}, participantID).Slice()
+		if err != nil {
+			return err
+		}
+		if status, _ := result[0].(int64); status == 2 && len(result) > 1 {
+			if userID, ok := parseUintField(result[1]); ok {
+				if err = t.Redis.SRem(ctx, userSessionsKey(userID), sessionID).Err(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return t.Redis.Del(ctx, indexKey).Err()
+}
+
+// storeSession simpan claims session dan index-nya, refreshHash kosong berarti refresh token tidak diganti
+func (t *TokenUtil) storeSession(ctx context.Context, auth *model.Auth, refreshHash string, client *model.SessionClient) error {
+	claims := *auth
+	claims.RegisteredClaims = jwt.RegisteredClaims{}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	key := sessionKey(auth.SessionID)
+	values := map[string]interface{}{"claims": string(data), "last_active_at": now}
+	if client != nil && client.IP != "" {
+		values["ip"] = client.IP
+	}
+	if refreshHash != "" {
+		values["refresh_hash"] = refreshHash
+		values["created_at"] = now
+		// device dicatat sekali saat login, join room berikutnya hanya update IP
+		if client != nil {
+			values["user_agent"] = truncateUserAgent(client.UserAgent)
+			values["device"] = DeviceName(client.UserAgent)
+		}
+		// claims akun tanpa room, dipakai lagi saat participant di-kick / ban
+		if auth.ParticipantID == nil {
+			values["base_claims"] = string(data)
+		}
+	}
+	if auth.UserID != nil {
+		values["user_id"] = *auth.UserID
+	}
+	if auth.ParticipantID != nil {
+		values["participant_id"] = *auth.ParticipantID
+	}
+
+	pipe := t.Redis.TxPipeline()
+	pipe.HSet(ctx, key, values)
+	if refreshHash != "" {
+		pipe.Expire(ctx, key, t.RefreshTTL)
+	}
+	if auth.UserID != nil {
+		pipe.SAdd(ctx, userSessionsKey(*auth.UserID), auth.SessionID)
+		pipe.Expire(ctx, userSessionsKey(*auth.UserID), t.RefreshTTL)
+	}
+	// index sesi per participant supaya bisa dicabut saat kick / ban
+	if auth.ParticipantID != nil {
+		pipe.SAdd(ctx, participantSessionsKey(*auth.ParticipantID), auth.SessionID)
+		pipe.Expire(ctx, participantSessionsKey(*auth.ParticipantID), t.RefreshTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// signAccessToken sign JWT pendek untuk session auth
+func (t *TokenUtil) signAccessToken(auth *model.Auth) (*model.TokenPair, error) {
+	now := time.Now()
+	ttl := t.AccessTTL
+	if ttl <= 0 {
+		ttl = DefaultAccessTokenTTL
+	}
+	expiresAt := now.Add(ttl)
+
+	// set expiration time for the token
+	auth.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	auth.IssuedAt = jwt.NewNumericDate(now)
+
+	// create structure of the token
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth)
+
+	// sign the token with the secret key
+	jwtToken, err := token.SignedString([]byte(t.SecretKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		AccessToken:     jwtToken,
+		AccessExpiresAt: expiresAt,
+	}, nil
+}
+
+// ParseSessionHash baca hash Redis session:{sid} menjadi SessionInfo, nil jika hash kosong
+func ParseSessionHash(sessionID string, values map[string]string) *model.SessionInfo {
+	if len(values) == 0 || values["created_at"] == "" {
+		return nil
+	}
+
+	session := &model.SessionInfo{
+		ID:        sessionID,
+		Device:    values["device"],
+		UserAgent: values["user_agent"],
+		IP:        values["ip"],
+	}
+	if session.Device == "" {
+		session.Device = DeviceName(session.UserAgent)
+	}
+	if userID, ok := parseUintField(values["user_id"]); ok {
+		session.UserID = &userID
+	}
+	if participantID, ok := parseUintField(values["participant_id"]); ok {
+		session.ParticipantID = &participantID
+	}
+	if created, err := strconv.ParseInt(values["created_at"], 10, 64); err == nil {
+		session.CreatedAt = time.Unix(created, 0)
+	}
+	session.LastActiveAt = session.CreatedAt
+	if lastActive, err := strconv.ParseInt(values["last_active_at"], 10, 64); err == nil {
+		session.LastActiveAt = time.Unix(lastActive, 0)
+	}
+
+	claims := new(model.Auth)
+	if err := json.Unmarshal([]byte(values["claims"]), claims); err == nil {
+		session.RoomID = claims.RoomID
+	}
+	return session
+}
+
+// SplitRefreshToken pisahkan refresh token "<sid>.<secret>"
+func SplitRefreshToken(refreshToken string) (sessionID, secret string, ok bool) {
+	sessionID, secret, found := strings.Cut(refreshToken, ".")
+	if !found || len(sessionID) != 32 || len(secret) != 64 {
+		return "", "", false
+	}
+	return sessionID, secret, true
+}
+
+// HashToken SHA-256 hex dari token acak, yang disimpan di Redis hanya hash-nya
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex string hex acak dari n byte
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// parseUintField baca field hash Redis (string) sebagai uint
+func parseUintField(value interface{}) (uint, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// sessionKey redis key untuk session
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+// refreshGraceKey redis key untuk pasangan refresh token hasil rotasi terakhir, hanya hidup selama grace window
+func refreshGraceKey(sessionID string) string {
+	return "refresh_grace:" + sessionID
+}
+
+// userSessionsKey redis key for the set of sessions of a user
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+// participantSessionsKey redis key for the set of sessions issued to a participant
+func participantSessionsKey(participantID uint) string {
+	return fmt.Sprintf("participant_sessions:%d", participantID)
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mailTokenPattern = regexp.MustCompile(`token=([0-9a-f]{64})`)
+
+// waitForMailToken tunggu email terbaru ke alamat to dengan subject tertentu dan ambil token dari link-nya.
+// Email dikirim di background, jadi file mail di-poll sampai timeout.
+func waitForMailToken(t *testing.T, to, subject string) string {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if token := findMailToken(to, subject); token != "" {
+			return token
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("no %q email sent to %s", subject, to)
+	return ""
+}
+
+// findMailToken token dari email terakhir yang cocok di file mail, kosong jika belum ada
+func findMailToken(to, subject string) string {
+	file, err := os.Open(testMailPath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	token := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message struct {
+			To      string `json:"to"`
+			Subject string `json:"subject"`
+			Body    string `json:"body"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &message) != nil {
+			continue
+		}
+		if message.To != to || message.Subject != subject {
+			continue
+		}
+		if match := mailTokenPattern.FindStringSubmatch(message.Body); match != nil {
+			token = match[1]
+		}
+	}
+	return token
+}
+
+func TestVerifyEmail_Flow(t *testing.T) {
+	cleanDB(t)
+
+	token := registerUser(t, "verifyuser", "verifyuser@example.com", "password123", "presenter")
+	verifyToken := waitForMailToken(t, "verifyuser@example.com", "Verify your email address")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/email/verify", map[string]string{"token": verifyToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "verifyuser@example.com", data["email"])
+	assert.NotNil(t, data["email_verified_at"])
+
+	// token sekali pakai
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/email/verify", map[string]string{"token": verifyToken}, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// email sudah terverifikasi, tidak perlu kirim ulang
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/me/email/verification", nil, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestVerifyEmail_ResendRateLimited(t *testing.T) {
+	cleanDB(t)
+
+	// registrasi sudah mengirim satu email verifikasi
+	token := registerUser(t, "resenduser", "resenduser@example.com", "password123", "presenter")
+	firstToken := waitForMailToken(t, "resenduser@example.com", "Verify your email address")
+
+	for i := 0; i < 2; i++ {
+		resp := makeRequest(t, http.MethodPost, "/api/v1/users/me/email/verification", nil, token)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/me/email/verification", nil, token)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// token lama dibatalkan saat token baru dikirim
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/email/verify", map[string]string{"token": firstToken}, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestResetPassword_Flow(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "resetuser", "resetuser@example.com", "password123", "presenter")
+	oldToken, oldRefresh := loginUser(t, "resetuser", "password123")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/password/forgot", map[string]string{"email": "resetuser@example.com"}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resetToken := waitForMailToken(t, "resetuser@example.com", "Reset your password")
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/password/reset", map[string]string{
+		"token":    resetToken,
+		"password": "newpassword456",
+	}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// semua session lama dicabut
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, oldToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": oldRefresh}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// password lama tidak berlaku, password baru bisa login
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/login", map[string]string{"username": "resetuser", "password": "password123"}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	loginUser(t, "resetuser", "newpassword456")
+
+	// token sekali pakai
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/password/reset", map[string]string{
+		"token":    resetToken,
+		"password": "anotherpassword789",
+	}, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	cleanDB(t)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/password/forgot", map[string]string{"email": "nobody@example.com"}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/password/reset", map[string]string{
+		"token":    strings.Repeat("a", 64),
+		"password": "newpassword456",
+	}, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoomAnalytics(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "statsowner", "statsowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Stats Room")
+	roomID := room["id"].(float64)
+
+	userToken := registerUser(t, "statsuser", "statsuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, room["room_code"].(string))
+
+	question := submitQuestion(t, roomID, "How are the numbers?", userRoomToken)
+	resp := makeRequest(t, http.MethodPost, "/api/v1/questions/"+formatID(question["id"].(float64))+"/upvote", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	poll := createPoll(t, roomID, "Good session?", []string{"Yes", "No"}, ownerRoomToken)
+	options := poll["options"].([]interface{})
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(poll["id"].(float64))+"/vote",
+		map[string]interface{}{"option_id": options[0].(map[string]interface{})["id"]}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/messages",
+		map[string]string{"content": "Hi all"}, userRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/analytics?bucket_minutes=1", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+
+	assert.Equal(t, float64(1), data["bucket_minutes"])
+	assert.Equal(t, float64(2), data["participants"].(map[string]interface{})["total"])
+	assert.Equal(t, float64(1), data["messages"].(map[string]interface{})["total"])
+
+	questions := data["questions"].(map[string]interface{})
+	assert.Equal(t, float64(1), questions["total"])
+	assert.Equal(t, float64(1), questions["upvotes"])
+	assert.Equal(t, float64(1), questions["upvotes_per_question"])
+
+	polls := data["polls"].(map[string]interface{})
+	assert.Equal(t, float64(1), polls["total"])
+	assert.Equal(t, 0.5, polls["average_participation_rate"])
+
+	assert.NotEmpty(t, data["series"])
+	assert.NotEmpty(t, data["xp"].(map[string]interface{})["buckets"])
+
+	// bukan owner
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/analytics", nil, userRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeBearerRequest seperti makeRequest tetapi mengirim token lewat header Authorization: Bearer
+func makeBearerRequest(t *testing.T, method, path string, body interface{}, token string) *http.Response {
+	t.Helper()
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request body: %v", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, path, bodyReader)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := testApp.Test(req, -1)
+	if err != nil {
+		t.Fatalf("failed to execute test request: %v", err)
+	}
+	return resp
+}
+
+// createAPIKey buat API key dengan scope tertentu dan kembalikan response-nya
+func createAPIKey(t *testing.T, token, name string, scopes []string) map[string]interface{} {
+	t.Helper()
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/me/api-keys", map[string]interface{}{
+		"name":   name,
+		"scopes": scopes,
+	}, token)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create api key failed: status=%d body=%v", resp.StatusCode, readBody(t, resp))
+	}
+	return readBody(t, resp)["data"].(map[string]interface{})
+}
+
+func TestAPIKeyScopesAndUsage(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "keyowner", "keyowner@example.com", "password123", "presenter")
+	room, _ := createRoom(t, ownerToken, "Key Room")
+
+	apiKey := createAPIKey(t, ownerToken, "ci script", []string{"rooms:read", "questions:read"})
+	key := apiKey["key"].(string)
+	assert.True(t, strings.HasPrefix(key, "rsk_"))
+	assert.True(t, strings.HasPrefix(key, apiKey["prefix"].(string)))
+	assert.Nil(t, apiKey["last_used_at"])
+
+	// scope rooms:read
+	resp := makeBearerRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, key)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// scope rooms:write tidak diberikan
+	resp = makeBearerRequest(t, http.MethodPost, "/api/v1/rooms", map[string]string{"title": "From script"}, key)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// API key tidak bisa mengelola API key
+	resp = makeBearerRequest(t, http.MethodGet, "/api/v1/users/me/api-keys", nil, key)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// join lewat API key: token room membawa scope key
+	resp = makeBearerRequest(t, http.MethodPost, "/api/v1/rooms/"+room["room_code"].(string)+"/join", map[string]interface{}{}, key)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	roomToken := extractCookieToken(resp)
+	assert.NotEmpty(t, roomToken)
+
+	roomID := formatID(room["id"].(float64))
+	resp = makeBearerRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/questions", nil, roomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeBearerRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/questions", map[string]string{"content": "Hi"}, roomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// waktu pemakaian tercatat
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/api-keys", nil, ownerToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	keys := readBody(t, resp)["data"].(map[string]interface{})["api_keys"].([]interface{})
+	assert.Len(t, keys, 1)
+	listed := keys[0].(map[string]interface{})
+	assert.NotNil(t, listed["last_used_at"])
+	assert.Nil(t, listed["key"])
+
+	// revoke: key dan token room turunannya tidak berlaku lagi
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/users/me/api-keys/"+formatID(apiKey["id"].(float64)), nil, ownerToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeBearerRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, key)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeBearerRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/questions", nil, roomToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAPIKeyValidation(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "keyvalid", "keyvalid@example.com", "password123", "presenter")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/me/api-keys", map[string]interface{}{
+		"name":   "bad",
+		"scopes": []string{"rooms:admin"},
+	}, ownerToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeBearerRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, "rsk_doesnotexist")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// key user lain tidak bisa dicabut
+	apiKey := createAPIKey(t, ownerToken, "mine", []string{"polls:read"})
+	otherToken := registerUser(t, "keyother", "keyother@example.com", "password123", "presenter")
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/users/me/api-keys/"+formatID(apiKey["id"].(float64)), nil, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentFilter_Flow(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "filterhost", "filterhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Filtered Room")
+	roomCode := room["room_code"].(string)
+	roomID := formatID(room["id"].(float64))
+
+	userToken := registerUser(t, "filteruser", "filteruser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	// default config is disabled
+	resp := makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/content-filter", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, false, data["enabled"])
+
+	// only the owner can configure
+	config := map[string]interface{}{
+		"enabled":               true,
+		"blocked_words":         []string{"Darn"},
+		"word_action":           "mask",
+		"block_links":           true,
+		"link_action":           "moderate",
+		"repeat_window_seconds": 60,
+		"repeat_action":         "reject",
+		"flood_max_messages":    0,
+		"flood_window_seconds":  10,
+	}
+	resp = makeRequest(t, http.MethodPut, "/api/v1/rooms/"+roomID+"/content-filter", config, userRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPut, "/api/v1/rooms/"+roomID+"/content-filter", config, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"darn"}, data["blocked_words"])
+
+	// blocked words are masked
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]string{"content": "oh darn it"}, userRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "oh **** it", data["content"])
+
+	// repeating the same message is rejected
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]string{"content": "Oh  DARN it"}, userRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// chat has no queue, so a link marked for moderation is rejected
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]string{"content": "see https://example.com"}, userRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// a question with a link goes to the moderation queue
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/questions",
+		map[string]string{"content": "Is https://example.com legit?"}, userRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	question := data["question"].(map[string]interface{})
+	assert.Equal(t, "queued", question["moderation_status"])
+
+	// the owner is not filtered
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]string{"content": "slides: https://example.com/deck"}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
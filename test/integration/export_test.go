@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoomExport_Formats(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "exportowner", "exportowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Export Room")
+	roomID := room["id"].(float64)
+
+	userToken := registerUser(t, "exportuser", "exportuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, room["room_code"].(string))
+
+	submitQuestion(t, roomID, "What is on the roadmap?", userRoomToken)
+	createPoll(t, roomID, "Ship it?", []string{"Yes", "No"}, ownerRoomToken)
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/messages",
+		map[string]string{"content": "Hello <everyone>"}, userRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// json (default)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/export", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "export.json")
+	var export map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+	assert.Len(t, export["questions"], 1)
+	assert.Len(t, export["polls"], 1)
+	assert.Len(t, export["messages"], 1)
+	assert.Len(t, export["leaderboard"], 2)
+
+	// csv zip
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/export?format=csv", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+	assert.Len(t, archive.File, 6)
+
+	// html report
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/export?format=html", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), "Hello &lt;everyone&gt;"))
+
+	// format tidak dikenal
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/export?format=pdf", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// participant bukan owner
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/export", nil, userRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
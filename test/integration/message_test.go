@@ -43,3 +43,51 @@ func TestListMessages(t *testing.T) {
 	messages := data["messages"].([]interface{})
 	assert.GreaterOrEqual(t, len(messages), 2)
 }
+
+func TestEditAndDeleteMessage(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "editmsghost", "editmsghost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Edit Messages Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+	path := "/api/v1/rooms/" + formatID(roomID) + "/messages"
+
+	userToken := registerUser(t, "editmsguser", "editmsguser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	resp := makeRequest(t, http.MethodPost, path, map[string]string{"content": "Helo"}, userRoomToken)
+	first := readBody(t, resp)["data"].(map[string]interface{})
+	resp = makeRequest(t, http.MethodPost, path, map[string]string{"content": "Spam spam"}, userRoomToken)
+	second := readBody(t, resp)["data"].(map[string]interface{})
+
+	// hanya author yang boleh edit
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/messages/"+formatID(first["id"].(float64)),
+		map[string]string{"content": "Hello"}, presenterRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/messages/"+formatID(first["id"].(float64)),
+		map[string]string{"content": "Hello"}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	edited := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "Hello", edited["content"])
+	assert.NotNil(t, edited["edited_at"])
+
+	// owner menghapus message participant, XP message dibatalkan
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/messages/"+formatID(second["id"].(float64)), nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	deleted := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, true, deleted["by_moderator"])
+	assert.Equal(t, float64(1), deleted["xp_revoked"])
+
+	// author menghapus message sendiri
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/messages/"+formatID(first["id"].(float64)), nil, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/messages/"+formatID(first["id"].(float64)), nil, userRoomToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, path, nil, userRoomToken)
+	messages := readBody(t, resp)["data"].(map[string]interface{})["messages"].([]interface{})
+	assert.Len(t, messages, 0)
+}
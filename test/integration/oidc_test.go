@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOIDCRedirectURL = "http://localhost:3000/api/v1/users/oidc/callback"
+	testFrontendURL     = "http://frontend.test"
+)
+
+// startOIDCLogin buka /oidc/login, return url authorization provider dan cookie state
+func startOIDCLogin(t *testing.T, redirect string) (string, string) {
+	t.Helper()
+	resp := makeRequest(t, http.MethodGet, "/api/v1/users/oidc/login?redirect="+url.QueryEscape(redirect), nil, "")
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	state := extractCookie(resp, "oidc_state")
+	require.NotEmpty(t, state)
+	return resp.Header.Get("Location"), state
+}
+
+// oidcCallback buka url callback dari provider seperti browser, dengan cookie state
+func oidcCallback(t *testing.T, callbackURL, stateCookie string) *http.Response {
+	t.Helper()
+	parsed, err := url.Parse(callbackURL)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, parsed.RequestURI(), nil)
+	require.NoError(t, err)
+	if stateCookie != "" {
+		req.AddCookie(&http.Cookie{Name: "oidc_state", Value: stateCookie})
+	}
+	resp, err := testApp.Test(req, -1)
+	require.NoError(t, err)
+	return resp
+}
+
+// oidcLogin login SSO lengkap dengan claim yang disetujui provider
+func oidcLogin(t *testing.T, redirect string, claims map[string]interface{}) *http.Response {
+	t.Helper()
+	authURL, state := startOIDCLogin(t, redirect)
+
+	callbackURL, err := testOIDC.Authorize(authURL, claims)
+	require.NoError(t, err)
+	return oidcCallback(t, callbackURL, state)
+}
+
+func countUsers(t *testing.T) int64 {
+	t.Helper()
+	var total int64
+	require.NoError(t, testDB.Table("users").Count(&total).Error)
+	return total
+}
+
+func TestOIDCLogin_ProvisionsAndReusesUser(t *testing.T) {
+	cleanDB(t)
+
+	claims := map[string]interface{}{
+		"sub":                "sso-alice",
+		"email":              "alice@corp.example.com",
+		"email_verified":     true,
+		"preferred_username": "alice.smith",
+	}
+
+	resp := oidcLogin(t, "/rooms/ABC123", claims)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, testFrontendURL+"/rooms/ABC123", resp.Header.Get("Location"))
+	token := extractCookieToken(resp)
+	assert.NotEmpty(t, token)
+	assert.NotEmpty(t, extractCookie(resp, "refresh_token"))
+
+	// cookie dari SSO sama dengan login biasa
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/sessions", nil, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var user struct {
+		Username string
+		Role     string
+		Verified bool
+	}
+	require.NoError(t, testDB.Raw("SELECT username, role, email_verified_at IS NOT NULL AS verified FROM users WHERE email = ?", "alice@corp.example.com").Scan(&user).Error)
+	assert.Equal(t, "alicesmith", user.Username)
+	assert.Equal(t, "presenter", user.Role)
+	assert.True(t, user.Verified)
+
+	// login kedua dengan subject yang sama memakai user yang sama
+	resp = oidcLogin(t, "", claims)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, testFrontendURL+"/", resp.Header.Get("Location"))
+	assert.Equal(t, int64(1), countUsers(t))
+}
+
+func TestOIDCLogin_LinksVerifiedEmail(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "bob", "bob@corp.example.com", "password123", "presenter")
+	verifyToken := waitForMailToken(t, "bob@corp.example.com", "Verify your email address")
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/email/verify", map[string]string{"token": verifyToken}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = oidcLogin(t, "/", map[string]interface{}{
+		"sub":            "sso-bob",
+		"email":          "bob@corp.example.com",
+		"email_verified": true,
+	})
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, int64(1), countUsers(t))
+
+	var linked int64
+	require.NoError(t, testDB.Raw("SELECT COUNT(*) FROM user_identities i JOIN users u ON u.id = i.user_id WHERE u.username = ? AND i.subject = ?", "bob", "sso-bob").Scan(&linked).Error)
+	assert.Equal(t, int64(1), linked)
+
+	// password lokal tetap bisa dipakai
+	loginUser(t, "bob", "password123")
+}
+
+func TestOIDCLogin_UnverifiedEmailConflict(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "carol", "carol@corp.example.com", "password123", "presenter")
+
+	resp := oidcLogin(t, "/", map[string]interface{}{
+		"sub":            "sso-carol",
+		"email":          "carol@corp.example.com",
+		"email_verified": false,
+	})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Empty(t, extractCookieToken(resp))
+}
+
+func TestOIDCLogin_UnverifiedLocalAccountConflict(t *testing.T) {
+	cleanDB(t)
+
+	// akun lokal dengan email orang lain yang belum diverifikasi tidak ditautkan ke identity SSO
+	registerUser(t, "mallory", "erin@corp.example.com", "password123", "presenter")
+
+	resp := oidcLogin(t, "/", map[string]interface{}{
+		"sub":            "sso-erin",
+		"email":          "erin@corp.example.com",
+		"email_verified": true,
+	})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Empty(t, extractCookieToken(resp))
+
+	var identities int64
+	require.NoError(t, testDB.Table("user_identities").Count(&identities).Error)
+	assert.Equal(t, int64(0), identities)
+}
+
+func TestOIDCLogin_RejectsBadState(t *testing.T) {
+	cleanDB(t)
+
+	authURL, state := startOIDCLogin(t, "/")
+	callbackURL, err := testOIDC.Authorize(authURL, map[string]interface{}{"sub": "sso-dave", "email": "dave@corp.example.com"})
+	require.NoError(t, err)
+
+	// tanpa cookie state (login CSRF)
+	resp := oidcCallback(t, callbackURL, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// state sudah dipakai tidak bisa diulang
+	resp = oidcCallback(t, callbackURL, state)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	resp = oidcCallback(t, callbackURL, state)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
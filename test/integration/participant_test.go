@@ -76,3 +76,117 @@ func TestListParticipants(t *testing.T) {
 	// presenter is auto-enrolled + 1 more user
 	assert.GreaterOrEqual(t, len(participants), 2)
 }
+
+func TestRoomPresence(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "presencehost", "presencehost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Presence Room")
+	roomID := formatID(room["id"].(float64))
+
+	// tanpa koneksi websocket tidak ada participant online
+	resp := makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/presence", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := readBody(t, resp)
+	data := body["data"].(map[string]interface{})
+	assert.Empty(t, data["participants"])
+	assert.Equal(t, float64(0), data["online_count"])
+
+	// participant list menandai participant tanpa koneksi sebagai offline
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/participants?page=1&size=10", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = readBody(t, resp)
+	participants := body["data"].(map[string]interface{})["participants"].([]interface{})
+	for _, participant := range participants {
+		assert.Equal(t, "offline", participant.(map[string]interface{})["status"])
+	}
+
+	// room lain ditolak
+	otherToken := registerUser(t, "presenceother", "presenceother@example.com", "password123", "presenter")
+	_, otherRoomToken := createRoom(t, otherToken, "Other Presence Room")
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/presence", nil, otherRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestModerateParticipant_BanBlocksRejoin(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "banhost", "banhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Ban Room")
+	roomCode := room["room_code"].(string)
+	roomID := formatID(room["id"].(float64))
+
+	userToken := registerUser(t, "banneduser", "banneduser@example.com", "password123", "presenter")
+	participant, participantToken := joinRoom(t, userToken, roomCode)
+	participantID := formatID(participant["id"].(float64))
+
+	// only the owner can ban
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/ban",
+		map[string]interface{}{"reason": "spam"}, participantToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/ban",
+		map[string]interface{}{"reason": "spam"}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	body := readBody(t, resp)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, "banned", data["action"])
+	banID := formatID(data["ban_id"].(float64))
+
+	// the old room token is revoked
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/participants?page=1&size=10", nil, participantToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// rejoin is rejected while banned
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomCode+"/join", map[string]interface{}{}, userToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/bans", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = readBody(t, resp)
+	bans := body["data"].(map[string]interface{})["bans"].([]interface{})
+	assert.Len(t, bans, 1)
+
+	// unban allows joining again
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/rooms/"+roomID+"/bans/"+banID, nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	joinRoom(t, userToken, roomCode)
+}
+
+func TestModerateParticipant_KickAndMute(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "mutehost", "mutehost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Mute Room")
+	roomCode := room["room_code"].(string)
+	roomID := formatID(room["id"].(float64))
+
+	userToken := registerUser(t, "muteduser", "muteduser@example.com", "password123", "presenter")
+	participant, participantToken := joinRoom(t, userToken, roomCode)
+	participantID := formatID(participant["id"].(float64))
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/mute",
+		map[string]interface{}{"duration_seconds": 600}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// muted participants cannot chat or ask
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]interface{}{"content": "hello"}, participantToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/questions",
+		map[string]interface{}{"content": "Can I still ask?"}, participantToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/mute", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages",
+		map[string]interface{}{"content": "hello again"}, participantToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// kick revokes the token but the user may rejoin
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/kick", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/participants?page=1&size=10", nil, participantToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	joinRoom(t, userToken, roomCode)
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -119,6 +120,111 @@ func TestVotePoll_DuplicateVote(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, resp.StatusCode)
 }
 
+// TestVotePoll_ConcurrentVotes vote bersamaan dari participant yang sama hanya dihitung sekali
+func TestVotePoll_ConcurrentVotes(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "racevotehost", "racevotehost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Race Vote Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	poll := createPoll(t, roomID, "Race vote question", []string{"Yes", "No"}, presenterRoomToken)
+	pollID := poll["id"].(float64)
+	options := poll["options"].([]interface{})
+	optionID := options[0].(map[string]interface{})["id"].(float64)
+
+	userToken := registerUser(t, "racevoteuser", "racevoteuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	const attempts = 5
+	statuses := make(chan int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+				map[string]interface{}{"option_id": int(optionID)}, userRoomToken)
+			_ = resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	counts := make(map[int]int)
+	for status := range statuses {
+		counts[status]++
+	}
+	assert.Equal(t, 1, counts[http.StatusOK])
+	assert.Equal(t, attempts-1, counts[http.StatusConflict])
+
+	resp := makeRequest(t, http.MethodGet, "/api/v1/polls/"+formatID(pollID)+"/results", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, float64(1), results["total_votes"])
+}
+
+// TestVotePoll_ConcurrentTextAnswers jawaban open-text bersamaan tetap dibatasi max_selections
+func TestVotePoll_ConcurrentTextAnswers(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "racetexthost", "racetexthost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Race Text Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question":       "One word for today",
+		"type":           "open_text",
+		"max_selections": 2,
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	pollID := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})["id"].(float64)
+
+	userToken := registerUser(t, "racetextuser", "racetextuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	const attempts = 5
+	type result struct {
+		status int
+		xp     float64
+	}
+	results := make(chan result, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+				map[string]interface{}{"text": "focused"}, userRoomToken)
+			if resp.StatusCode != http.StatusOK {
+				_ = resp.Body.Close()
+				results <- result{status: resp.StatusCode}
+				return
+			}
+			data := readBody(t, resp)["data"].(map[string]interface{})
+			xp := data["xp_earned"].(map[string]interface{})["points"].(float64)
+			results <- result{status: resp.StatusCode, xp: xp}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	counts := make(map[int]int)
+	rewarded := 0
+	for r := range results {
+		counts[r.status]++
+		if r.xp > 0 {
+			rewarded++
+		}
+	}
+	assert.Equal(t, 2, counts[http.StatusOK])
+	assert.Equal(t, attempts-2, counts[http.StatusConflict])
+	assert.Equal(t, 1, rewarded)
+}
+
 func TestClosePoll_Success(t *testing.T) {
 	cleanDB(t)
 
@@ -173,3 +279,319 @@ func TestGetPollHistory(t *testing.T) {
 	polls := data["polls"].([]interface{})
 	assert.GreaterOrEqual(t, len(polls), 1)
 }
+
+func TestVotePoll_MultipleChoice(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "multihost", "multihost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Multiple Choice Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question":       "Which topics?",
+		"type":           "multiple_choice",
+		"options":        []string{"Go", "Rust", "Zig"},
+		"max_selections": 2,
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+	options := poll["options"].([]interface{})
+	optionA := int(options[0].(map[string]interface{})["id"].(float64))
+	optionB := int(options[1].(map[string]interface{})["id"].(float64))
+	optionC := int(options[2].(map[string]interface{})["id"].(float64))
+
+	userToken := registerUser(t, "multiuser", "multiuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	// lebih dari max_selections ditolak
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_ids": []int{optionA, optionB, optionC}}, userRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_ids": []int{optionA, optionB}}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results := readBody(t, resp)["data"].(map[string]interface{})["updated_results"].(map[string]interface{})
+	assert.Equal(t, "multiple_choice", results["type"])
+	assert.Equal(t, float64(1), results["total_votes"])
+}
+
+func TestVotePoll_Rating(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "ratinghost", "ratinghost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Rating Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question": "How was the talk?",
+		"type":     "rating",
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+	assert.Len(t, poll["options"].([]interface{}), 5)
+
+	userToken := registerUser(t, "ratinguser", "ratinguser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"rating": 4}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results := readBody(t, resp)["data"].(map[string]interface{})["updated_results"].(map[string]interface{})
+	rating := results["rating"].(map[string]interface{})
+	assert.Equal(t, float64(4), rating["average"])
+	assert.Equal(t, float64(1), rating["count"])
+}
+
+func TestVotePoll_RankedChoice(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "rankedhost", "rankedhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Ranked Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question": "Rank the venues",
+		"type":     "ranked_choice",
+		"options":  []string{"Jakarta", "Bandung", "Bali"},
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+	options := poll["options"].([]interface{})
+	optionA := int(options[0].(map[string]interface{})["id"].(float64))
+	optionC := int(options[2].(map[string]interface{})["id"].(float64))
+
+	userToken := registerUser(t, "rankeduser", "rankeduser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_ids": []int{optionC, optionA}}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results := readBody(t, resp)["data"].(map[string]interface{})["updated_results"].(map[string]interface{})
+	ranked := results["ranked"].(map[string]interface{})
+	assert.Equal(t, float64(optionC), ranked["winner_option_id"])
+}
+
+func TestVotePoll_OpenText(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "cloudhost", "cloudhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Word Cloud Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question":       "Describe the keynote in one word",
+		"type":           "open_text",
+		"max_selections": 2,
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+
+	userToken := registerUser(t, "clouduser", "clouduser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"text": "  Inspiring! "}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"text": "inspiring"}, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results := readBody(t, resp)["data"].(map[string]interface{})["updated_results"].(map[string]interface{})
+	terms := results["terms"].([]interface{})
+	assert.Len(t, terms, 1)
+	assert.Equal(t, "inspiring", terms[0].(map[string]interface{})["term"])
+	assert.Equal(t, float64(2), terms[0].(map[string]interface{})["count"])
+
+	// limit jawaban per participant
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"text": "boring"}, userRoomToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// presenter hide satu jawaban
+	resp = makeRequest(t, http.MethodGet, "/api/v1/polls/"+formatID(pollID)+"/answers", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	answers := readBody(t, resp)["data"].(map[string]interface{})["answers"].([]interface{})
+	assert.Len(t, answers, 2)
+	answerID := answers[0].(map[string]interface{})["id"].(float64)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID)+"/answers/"+formatID(answerID),
+		map[string]interface{}{"hidden": true}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/polls/"+formatID(pollID)+"/results", nil, userRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	results = readBody(t, resp)["data"].(map[string]interface{})
+	terms = results["terms"].([]interface{})
+	assert.Equal(t, float64(1), terms[0].(map[string]interface{})["count"])
+
+	// participant tidak boleh hide jawaban
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID)+"/answers/"+formatID(answerID),
+		map[string]interface{}{"hidden": false}, userRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestQuiz_Flow(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "quizhost", "quizhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Quiz Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/quizzes", map[string]interface{}{
+		"title": "Geography Trivia",
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	quizID := readBody(t, resp)["data"].(map[string]interface{})["id"].(float64)
+
+	// pertanyaan quiz wajib punya correct_options
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question": "Capital of Indonesia?",
+		"options":  []string{"Jakarta", "Bandung"},
+		"quiz_id":  quizID,
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question":        "Capital of Indonesia?",
+		"options":         []string{"Jakarta", "Bandung"},
+		"quiz_id":         quizID,
+		"correct_options": []int{0},
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+	assert.Equal(t, float64(30), poll["time_limit_seconds"])
+	assert.NotNil(t, poll["ends_at"])
+	options := poll["options"].([]interface{})
+	correctID := int(options[0].(map[string]interface{})["id"].(float64))
+	wrongID := int(options[1].(map[string]interface{})["id"].(float64))
+
+	fastToken := registerUser(t, "quizfast", "quizfast@example.com", "password123", "presenter")
+	_, fastRoomToken := joinRoom(t, fastToken, roomCode)
+	wrongToken := registerUser(t, "quizwrong", "quizwrong@example.com", "password123", "presenter")
+	_, wrongRoomToken := joinRoom(t, wrongToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_id": correctID}, fastRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	quiz := readBody(t, resp)["data"].(map[string]interface{})["quiz"].(map[string]interface{})
+	assert.Equal(t, true, quiz["is_correct"])
+	assert.Greater(t, quiz["points"].(float64), float64(10))
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_id": wrongID}, wrongRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	quiz = readBody(t, resp)["data"].(map[string]interface{})["quiz"].(map[string]interface{})
+	assert.Equal(t, false, quiz["is_correct"])
+	assert.Equal(t, float64(0), quiz["points"])
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/quizzes/"+formatID(quizID)+"/finish", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	summary := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "finished", summary["quiz"].(map[string]interface{})["status"])
+
+	questions := summary["questions"].([]interface{})
+	assert.Len(t, questions, 1)
+	assert.Equal(t, float64(50), questions[0].(map[string]interface{})["accuracy"])
+	assert.Equal(t, "closed", questions[0].(map[string]interface{})["status"])
+
+	ranking := summary["ranking"].([]interface{})
+	assert.Len(t, ranking, 2)
+	assert.Equal(t, "quizfast", ranking[0].(map[string]interface{})["display_name"])
+
+	// poll sudah ditutup oleh finish
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_id": correctID}, wrongRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDraftPoll_EditAndActivate(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "drafthost", "drafthost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Draft Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question": "Which topic first?",
+		"options":  []string{"Go", "Rust", "Zig"},
+		"draft":    true,
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	poll := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	pollID := poll["id"].(float64)
+	assert.Equal(t, "draft", poll["status"])
+
+	participantToken := registerUser(t, "draftvoter", "draftvoter@example.com", "password123", "presenter")
+	_, participantRoomToken := joinRoom(t, participantToken, roomCode)
+
+	options := poll["options"].([]interface{})
+	firstID := options[0].(map[string]interface{})["id"].(float64)
+
+	// draft belum bisa di-vote dan tidak terlihat oleh participant
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_id": firstID}, participantRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/polls", nil, participantRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	history := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, float64(0), history["total"])
+
+	// participant tidak boleh edit
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID),
+		map[string]interface{}{"question": "Hijacked?"}, participantRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID), map[string]interface{}{
+		"question": "Which topic should we start with?",
+		"options":  []string{"Go", "Rust", "Zig", "Odin"},
+	}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	poll = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "Which topic should we start with?", poll["question"])
+	options = poll["options"].([]interface{})
+	assert.Len(t, options, 4)
+
+	// balik urutan option
+	reversed := make([]float64, 0, len(options))
+	for i := len(options) - 1; i >= 0; i-- {
+		reversed = append(reversed, options[i].(map[string]interface{})["id"].(float64))
+	}
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID)+"/options/order",
+		map[string]interface{}{"option_ids": reversed}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	poll = readBody(t, resp)["data"].(map[string]interface{})
+	options = poll["options"].([]interface{})
+	assert.Equal(t, "Odin", options[0].(map[string]interface{})["option_text"])
+
+	// urutan harus berisi semua option
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID)+"/options/order",
+		map[string]interface{}{"option_ids": reversed[:2]}, presenterRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID)+"/activate", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	poll = readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	assert.Equal(t, "active", poll["status"])
+
+	// poll aktif tidak bisa diedit lagi
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(pollID),
+		map[string]interface{}{"question": "Too late to change?"}, presenterRoomToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/polls/"+formatID(pollID)+"/vote",
+		map[string]interface{}{"option_id": reversed[0]}, participantRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile_GetAndUpdateUsername(t *testing.T) {
+	cleanDB(t)
+
+	token := registerUser(t, "profileuser", "profileuser@example.com", "password123", "presenter")
+
+	resp := makeRequest(t, http.MethodGet, "/api/v1/users/me", nil, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "profileuser", data["username"])
+	assert.Equal(t, "profileuser@example.com", data["email"])
+
+	// username tidak butuh password saat ini
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{"username": "renameduser"}, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	newToken := extractCookieToken(resp)
+	assert.NotEmpty(t, newToken)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "renameduser", data["username"])
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me", nil, newToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "renameduser", data["username"])
+
+	loginUser(t, "renameduser", "password123")
+}
+
+func TestProfile_UsernameTaken(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "takenuser", "takenuser@example.com", "password123", "presenter")
+	token := registerUser(t, "otheruser", "otheruser@example.com", "password123", "presenter")
+
+	resp := makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{"username": "takenuser"}, token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestProfile_ChangeEmailRequiresCurrentPassword(t *testing.T) {
+	cleanDB(t)
+
+	token := registerUser(t, "emailuser", "emailuser@example.com", "password123", "presenter")
+
+	resp := makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{"email": "changed@example.com"}, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{
+		"email":            "changed@example.com",
+		"current_password": "wrongpassword",
+	}, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{
+		"email":            "changed@example.com",
+		"current_password": "password123",
+	}, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "changed@example.com", data["email"])
+	assert.Nil(t, data["email_verified_at"])
+
+	// email baru harus diverifikasi ulang
+	verifyToken := waitForMailToken(t, "changed@example.com", "Verify your email address")
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/email/verify", map[string]string{"token": verifyToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestProfile_ChangePasswordRevokesOtherSessions(t *testing.T) {
+	cleanDB(t)
+
+	token := registerUser(t, "pwuser", "pwuser@example.com", "password123", "presenter")
+	otherToken, otherRefresh := loginUser(t, "pwuser", "password123")
+
+	resp := makeRequest(t, http.MethodPatch, "/api/v1/users/me", map[string]string{
+		"new_password":     "newpassword456",
+		"current_password": "password123",
+	}, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// session saat ini tetap aktif, session lain dicabut
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me", nil, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me", nil, otherToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": otherRefresh}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/login", map[string]string{"username": "pwuser", "password": "password123"}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	loginUser(t, "pwuser", "newpassword456")
+}
+
+func TestDeleteAccount_AnonymizesParticipantsAndDeletesRooms(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "roomowner", "roomowner@example.com", "password123", "presenter")
+	room, _ := createRoom(t, ownerToken, "Owner Room")
+
+	token := registerUser(t, "leavinguser", "leavinguser@example.com", "password123", "presenter")
+	createRoom(t, token, "Leaving Room")
+	joinRoom(t, token, room["room_code"].(string))
+
+	resp := makeRequest(t, http.MethodDelete, "/api/v1/users/me", map[string]string{"password": "wrongpassword"}, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/users/me", map[string]string{"password": "password123"}, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// session dicabut dan akun tidak bisa login lagi
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me", nil, token)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/login", map[string]string{"username": "leavinguser", "password": "password123"}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// room milik user ikut terhapus, room lain tetap ada
+	var rooms int64
+	testDB.Table("rooms").Where("title = ?", "Leaving Room").Count(&rooms)
+	assert.Equal(t, int64(0), rooms)
+	testDB.Table("rooms").Where("title = ?", "Owner Room").Count(&rooms)
+	assert.Equal(t, int64(1), rooms)
+
+	// participant di room lain dianonimkan, bukan dihapus
+	var participant struct {
+		UserID      *uint
+		DisplayName string
+		IsAnonymous bool
+	}
+	testDB.Table("participants").Where("room_id = ? AND display_name = ?", uint(room["id"].(float64)), "Deleted user").Take(&participant)
+	assert.Nil(t, participant.UserID)
+	assert.Equal(t, "Deleted user", participant.DisplayName)
+	assert.True(t, participant.IsAnonymous)
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -140,3 +141,172 @@ func TestValidateQuestion_PresenterOnly(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, resp2.StatusCode)
 }
+
+func TestQuestionModeration_Flow(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "modhost", "modhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Town Hall")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPatch, "/api/v1/rooms/"+formatID(roomID)+"/settings",
+		map[string]interface{}{"question_moderation": true}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	modToken := registerUser(t, "modhelper", "modhelper@example.com", "password123", "presenter")
+	modParticipant, modRoomToken := joinRoom(t, modToken, roomCode)
+	askerToken := registerUser(t, "modasker", "modasker@example.com", "password123", "presenter")
+	_, askerRoomToken := joinRoom(t, askerToken, roomCode)
+
+	// belum jadi moderator
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions/queue", nil, modRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/moderators",
+		map[string]interface{}{"participant_id": modParticipant["id"]}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// question masuk antrian tanpa XP
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/questions",
+		map[string]string{"content": "When is the next release?"}, askerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	data := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Nil(t, data["xp_earned"])
+	approved := data["question"].(map[string]interface{})
+	assert.Equal(t, "queued", approved["moderation_status"])
+
+	rejected := submitQuestion(t, roomID, "Buy cheap watches", askerRoomToken)
+
+	// participant lain belum melihat question yang masih di antrian
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, readBody(t, resp)["data"].(map[string]interface{})["questions"].([]interface{}), 0)
+
+	// author tetap melihat question miliknya
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions", nil, askerRoomToken)
+	assert.Len(t, readBody(t, resp)["data"].(map[string]interface{})["questions"].([]interface{}), 2)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions/queue", nil, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	queue := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, float64(2), queue["paging"].(map[string]interface{})["total"])
+
+	// question di antrian belum bisa di-upvote
+	resp = makeRequest(t, http.MethodPost, "/api/v1/questions/"+formatID(approved["id"].(float64))+"/upvote", nil, modRoomToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// participant biasa tidak boleh moderasi
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(approved["id"].(float64))+"/moderate",
+		map[string]interface{}{"action": "approve"}, askerRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(approved["id"].(float64))+"/moderate",
+		map[string]interface{}{"action": "approve", "content": "When is the next major release?"}, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data = readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "approved", data["question"].(map[string]interface{})["moderation_status"])
+	assert.Equal(t, "When is the next major release?", data["question"].(map[string]interface{})["content"])
+	assert.Equal(t, float64(10), data["xp_earned"].(map[string]interface{})["points"])
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(rejected["id"].(float64))+"/moderate",
+		map[string]interface{}{"action": "reject", "reason": "Spam"}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Spam", readBody(t, resp)["data"].(map[string]interface{})["reason"])
+
+	// keputusan tidak bisa diubah lagi
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(rejected["id"].(float64))+"/moderate",
+		map[string]interface{}{"action": "approve"}, presenterRoomToken)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions", nil, presenterRoomToken)
+	questions := readBody(t, resp)["data"].(map[string]interface{})["questions"].([]interface{})
+	assert.Len(t, questions, 1)
+	assert.Equal(t, approved["id"], questions[0].(map[string]interface{})["id"])
+}
+
+func TestReplyQuestion_Flow(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "replyhost", "replyhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Reply Room")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	askerToken := registerUser(t, "replyasker", "replyasker@example.com", "password123", "presenter")
+	_, askerRoomToken := joinRoom(t, askerToken, roomCode)
+
+	question := submitQuestion(t, roomID, "Will slides be shared?", askerRoomToken)
+	repliesPath := "/api/v1/questions/" + formatID(question["id"].(float64)) + "/replies"
+
+	// participant belum boleh membalas secara default
+	resp := makeRequest(t, http.MethodPost, repliesPath, map[string]string{"content": "I hope so"}, askerRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, repliesPath, map[string]string{"content": "Yes, after the session."}, presenterRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	reply := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, true, reply["is_presenter"])
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/rooms/"+formatID(roomID)+"/settings",
+		map[string]interface{}{"participant_replies": true}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, repliesPath, map[string]string{"content": "Thanks!"}, askerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/questions", nil, askerRoomToken)
+	questions := readBody(t, resp)["data"].(map[string]interface{})["questions"].([]interface{})
+	assert.Len(t, questions, 1)
+	replies := questions[0].(map[string]interface{})["replies"].([]interface{})
+	assert.Len(t, replies, 2)
+	assert.Equal(t, "Yes, after the session.", replies[0].(map[string]interface{})["content"])
+	assert.Equal(t, false, replies[1].(map[string]interface{})["is_presenter"])
+}
+
+func TestQuestionModeration_ConcurrentApprove(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "racehost", "racehost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Race Hall")
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPatch, "/api/v1/rooms/"+formatID(roomID)+"/settings",
+		map[string]interface{}{"question_moderation": true}, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	askerToken := registerUser(t, "raceasker", "raceasker@example.com", "password123", "presenter")
+	_, askerRoomToken := joinRoom(t, askerToken, room["room_code"].(string))
+	question := submitQuestion(t, roomID, "Will this be approved twice?", askerRoomToken)
+
+	// approve bersamaan: hanya satu yang berhasil, XP submit hanya diberikan sekali
+	const attempts = 5
+	statuses := make([]int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp := makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(question["id"].(float64))+"/moderate",
+				map[string]interface{}{"action": "approve"}, presenterRoomToken)
+			statuses[i] = resp.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	approved := 0
+	for _, status := range statuses {
+		if status == http.StatusOK {
+			approved++
+		} else {
+			assert.Equal(t, http.StatusConflict, status)
+		}
+	}
+	assert.Equal(t, 1, approved)
+
+	var awarded int64
+	assert.NoError(t, testDB.Table("xp_transactions").
+		Where("source_type = ? AND source_id = ?", "question_created", question["id"]).
+		Count(&awarded).Error)
+	assert.Equal(t, int64(1), awarded)
+}
@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoomRoles_CoHostAndModerator(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "roleowner", "roleowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Organizers")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	cohostToken := registerUser(t, "rolecohost", "rolecohost@example.com", "password123", "presenter")
+	cohostParticipant, _ := joinRoom(t, cohostToken, roomCode)
+	modToken := registerUser(t, "rolemod", "rolemod@example.com", "password123", "presenter")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/roles",
+		map[string]interface{}{"participant_id": cohostParticipant["id"], "role": "co_host"}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "co_host", readBody(t, resp)["data"].(map[string]interface{})["role"])
+
+	// role baru masuk ke token saat join ulang
+	cohostParticipant, cohostRoomToken := joinRoom(t, cohostToken, roomCode)
+	assert.Equal(t, "co_host", cohostParticipant["room_role"])
+
+	// co-host bisa invite moderator sebelum user join, tapi tidak bisa membuat co-host lain
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/roles/invite",
+		map[string]interface{}{"username": "rolemod", "role": "co_host"}, cohostRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/roles/invite",
+		map[string]interface{}{"username": "rolemod", "role": "moderator"}, cohostRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	invited := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Nil(t, invited["participant_id"])
+
+	modParticipant, modRoomToken := joinRoom(t, modToken, roomCode)
+	assert.Equal(t, "moderator", modParticipant["room_role"])
+
+	// co-host menjalankan sesi: poll dan announcement
+	poll := createPoll(t, roomID, "Lunch?", []string{"Yes", "No"}, cohostRoomToken)
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/polls/"+formatID(poll["id"].(float64))+"/close", nil, cohostRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/announcement",
+		map[string]string{"message": "Break in five minutes"}, cohostRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// pengaturan room tetap milik owner
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/rooms/"+formatID(roomID)+"/settings",
+		map[string]interface{}{"question_moderation": true}, cohostRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// moderator hanya untuk Q&A
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls",
+		map[string]interface{}{"question": "Coffee?", "options": []string{"Yes", "No"}}, modRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	askerToken := registerUser(t, "roleasker", "roleasker@example.com", "password123", "presenter")
+	_, askerRoomToken := joinRoom(t, askerToken, roomCode)
+	question := submitQuestion(t, roomID, "Where are the slides?", askerRoomToken)
+
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/questions/"+formatID(question["id"].(float64))+"/validate",
+		map[string]string{"status": "answered"}, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(roomID)+"/roles", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	roles := readBody(t, resp)["data"].(map[string]interface{})["roles"].([]interface{})
+	assert.Len(t, roles, 2)
+	var cohostUserID float64
+	for _, r := range roles {
+		if role := r.(map[string]interface{}); role["username"] == "rolecohost" {
+			cohostUserID = role["user_id"].(float64)
+		}
+	}
+
+	// revoke co-host: token lama tidak berlaku lagi
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/rooms/"+formatID(roomID)+"/roles/"+formatID(cohostUserID), nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/announcement",
+		map[string]string{"message": "Still here?"}, cohostRoomToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	cohostParticipant, _ = joinRoom(t, cohostToken, roomCode)
+	assert.Equal(t, "participant", cohostParticipant["room_role"])
+}
+
+func TestRoomRoles_ModeratorModeratesChatAndParticipants(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "chatmodowner", "chatmodowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Moderated Chat")
+	roomCode := room["room_code"].(string)
+	roomID := formatID(room["id"].(float64))
+
+	modToken := registerUser(t, "chatmod", "chatmod@example.com", "password123", "presenter")
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/roles/invite",
+		map[string]interface{}{"username": "chatmod", "role": "moderator"}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	modParticipant, modRoomToken := joinRoom(t, modToken, roomCode)
+
+	userToken := registerUser(t, "chatmoduser", "chatmoduser@example.com", "password123", "presenter")
+	participant, userRoomToken := joinRoom(t, userToken, roomCode)
+	participantID := formatID(participant["id"].(float64))
+
+	otherToken := registerUser(t, "chatmodother", "chatmodother@example.com", "password123", "presenter")
+	_, otherRoomToken := joinRoom(t, otherToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/messages", map[string]string{"content": "Buy cheap stuff"}, userRoomToken)
+	message := readBody(t, resp)["data"].(map[string]interface{})
+
+	// participant biasa tidak bisa menghapus message orang lain atau memoderasi participant
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/messages/"+formatID(message["id"].(float64)), nil, otherRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/mute",
+		map[string]interface{}{"duration_seconds": 60}, otherRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// moderator menghapus message participant
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/messages/"+formatID(message["id"].(float64)), nil, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, readBody(t, resp)["data"].(map[string]interface{})["by_moderator"])
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/mute",
+		map[string]interface{}{"duration_seconds": 60}, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+participantID+"/kick", nil, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/bans", nil, modRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// moderator tidak bisa memoderasi sesama staff, owner bisa
+	modParticipantID := formatID(modParticipant["id"].(float64))
+	otherModToken := registerUser(t, "chatmod2", "chatmod2@example.com", "password123", "presenter")
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/roles/invite",
+		map[string]interface{}{"username": "chatmod2", "role": "moderator"}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	_, otherModRoomToken := joinRoom(t, otherModToken, roomCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+modParticipantID+"/kick", nil, otherModRoomToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+modParticipantID+"/mute",
+		map[string]interface{}{"duration_seconds": 60}, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRoomRoles_CoHostRepliesAsPresenter(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "replyowner", "replyowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Reply Organizers")
+	roomCode := room["room_code"].(string)
+	roomID := room["id"].(float64)
+
+	cohostToken := registerUser(t, "replycohost", "replycohost@example.com", "password123", "presenter")
+	cohostParticipant, _ := joinRoom(t, cohostToken, roomCode)
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/roles",
+		map[string]interface{}{"participant_id": cohostParticipant["id"], "role": "co_host"}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	_, cohostRoomToken := joinRoom(t, cohostToken, roomCode)
+
+	askerToken := registerUser(t, "replyguest", "replyguest@example.com", "password123", "presenter")
+	_, askerRoomToken := joinRoom(t, askerToken, roomCode)
+	question := submitQuestion(t, roomID, "Is there a recording?", askerRoomToken)
+
+	// participant_replies mati, co-host tetap boleh membalas dan ditandai presenter
+	resp = makeRequest(t, http.MethodPost, "/api/v1/questions/"+formatID(question["id"].(float64))+"/replies",
+		map[string]string{"content": "Yes, it will be shared tomorrow."}, cohostRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, true, readBody(t, resp)["data"].(map[string]interface{})["is_presenter"])
+}
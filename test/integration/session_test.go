@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// loginWithUserAgent logs in with the given User-Agent header and returns the access token.
+func loginWithUserAgent(t *testing.T, username, password, userAgent string) string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/users/login",
+		strings.NewReader(`{"username":"`+username+`","password":"`+password+`"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := testApp.Test(req, -1)
+	if err != nil {
+		t.Fatalf("failed to execute test request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("login failed: status=%d", resp.StatusCode)
+	}
+	return extractCookieToken(resp)
+}
+
+// listSessions returns the sessions of the caller.
+func listSessions(t *testing.T, token string) []interface{} {
+	t.Helper()
+	resp := makeRequest(t, http.MethodGet, "/api/v1/users/me/sessions", nil, token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("list sessions failed: status=%d", resp.StatusCode)
+	}
+	return readBody(t, resp)["data"].(map[string]interface{})["sessions"].([]interface{})
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "sessionuser", "sessionuser@example.com", "password123", "presenter")
+	laptop := loginWithUserAgent(t, "sessionuser", "password123",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	phone := loginWithUserAgent(t, "sessionuser", "password123",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1")
+
+	// register + two logins
+	sessions := listSessions(t, laptop)
+	assert.Len(t, sessions, 3)
+
+	var phoneID string
+	currentCount := 0
+	for _, s := range sessions {
+		session := s.(map[string]interface{})
+		if session["current"] == true {
+			currentCount++
+			assert.Equal(t, "Chrome on Windows", session["device"])
+		}
+		if session["device"] == "Safari on iOS" {
+			phoneID = session["id"].(string)
+		}
+		assert.NotEmpty(t, session["created_at"])
+	}
+	assert.Equal(t, 1, currentCount)
+	assert.NotEmpty(t, phoneID)
+
+	resp := makeRequest(t, http.MethodDelete, "/api/v1/users/me/sessions/"+phoneID, nil, laptop)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the phone is logged out, the laptop is not
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, phone)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, laptop)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, listSessions(t, laptop), 2)
+
+	// already revoked
+	resp = makeRequest(t, http.MethodDelete, "/api/v1/users/me/sessions/"+phoneID, nil, laptop)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSessions_CannotRevokeOtherUsersSession(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "sessionowner", "sessionowner@example.com", "password123", "presenter")
+	otherToken := registerUser(t, "sessionother", "sessionother@example.com", "password123", "presenter")
+
+	ownerSessions := listSessions(t, ownerToken)
+	ownerSessionID := ownerSessions[0].(map[string]interface{})["id"].(string)
+
+	resp := makeRequest(t, http.MethodDelete, "/api/v1/users/me/sessions/"+ownerSessionID, nil, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, ownerToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestSessions_AnonymousForbidden(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "sessionhost", "sessionhost@example.com", "password123", "presenter")
+	room, _ := createRoom(t, presenterToken, "Session Room")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/anonymous", map[string]string{
+		"room_code":    room["room_code"].(string),
+		"display_name": "Anonymous Guest",
+	}, "")
+	anonToken := extractCookieToken(resp)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/sessions", nil, anonToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -23,6 +24,7 @@ import (
 	"gorm.io/gorm"
 
 	"reisify/internal/config"
+	"reisify/test/mocks"
 )
 
 var (
@@ -30,6 +32,12 @@ var (
 	testDB    *gorm.DB
 	testRedis *redis.Client
 	testCfg   *viper.Viper
+
+	// testMailPath file tempat log mail driver menulis email selama test
+	testMailPath = filepath.Join(os.TempDir(), "reisify-test-mail.jsonl")
+
+	// testOIDC identity provider lokal untuk test login SSO
+	testOIDC *mocks.MockOIDCProvider
 )
 
 // migrationsPath is relative to the test/integration/ directory at test runtime
@@ -37,6 +45,16 @@ const migrationsPath = "../../db/migrations"
 
 func TestMain(m *testing.M) {
 	testCfg = loadTestConfig()
+	_ = os.Remove(testMailPath)
+	testCfg.Set("mail.driver", "log")
+	testCfg.Set("mail.file_path", testMailPath)
+
+	testOIDC = mocks.NewMockOIDCProvider("reisify-test", "test-client-secret")
+	testCfg.Set("oidc.issuer", testOIDC.Issuer())
+	testCfg.Set("oidc.client_id", testOIDC.ClientID)
+	testCfg.Set("OIDC_CLIENT_SECRET", testOIDC.ClientSecret)
+	testCfg.Set("oidc.redirect_url", testOIDCRedirectURL)
+	testCfg.Set("oidc.frontend_url", testFrontendURL)
 	testDB = connectTestDB(testCfg)
 	runMigrations(testCfg)
 
@@ -64,6 +82,7 @@ func TestMain(m *testing.M) {
 
 	code := m.Run()
 
+	testOIDC.Close()
 	teardown()
 	os.Exit(code)
 }
@@ -83,6 +102,9 @@ func loadTestConfig() *viper.Viper {
 		panic(fmt.Sprintf("failed to read .env.test: %v", err))
 	}
 
+	// receiver webhook di test berjalan di httptest server 127.0.0.1
+	v.Set("webhook.allow_private_targets", true)
+
 	return v
 }
 
@@ -137,13 +159,26 @@ func cleanDB(t *testing.T) {
 	t.Helper()
 	tables := []string{
 		"xp_transactions",
+		"quiz_answers",
+		"poll_text_responses",
 		"poll_responses",
 		"poll_options",
 		"polls",
+		"quizzes",
 		"votes",
+		"question_replies",
+		"room_bans",
+		"room_content_filters",
+		"room_roles",
+		"room_presence_events",
 		"questions",
 		"messages",
 		"participants",
+		"webhook_deliveries",
+		"webhooks",
+		"api_keys",
+		"user_tokens",
+		"user_identities",
 		"rooms",
 		"users",
 	}
@@ -191,8 +226,13 @@ func makeRequest(t *testing.T, method, path string, body interface{}, token stri
 // extractCookieToken returns the value of the "token" cookie from a response's
 // Set-Cookie header. Returns an empty string if the cookie is not present.
 func extractCookieToken(resp *http.Response) string {
+	return extractCookie(resp, "token")
+}
+
+// extractCookie returns the value of the named cookie from a response's Set-Cookie header.
+func extractCookie(resp *http.Response, name string) string {
 	for _, c := range resp.Cookies() {
-		if c.Name == "token" {
+		if c.Name == name {
 			return c.Value
 		}
 	}
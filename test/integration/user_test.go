@@ -1,8 +1,13 @@
 package integration
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"reisify/internal/util"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -118,3 +123,164 @@ func TestLogout_Success(t *testing.T) {
 	resp2 := makeRequest(t, http.MethodPost, "/api/v1/rooms", map[string]string{"title": "Test Room"}, token)
 	assert.Equal(t, http.StatusUnauthorized, resp2.StatusCode)
 }
+
+// loginUser logs in and returns the access token and refresh token cookies.
+func loginUser(t *testing.T, username, password string) (string, string) {
+	t.Helper()
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/login", map[string]string{
+		"username": username,
+		"password": password,
+	}, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("login failed: status=%d", resp.StatusCode)
+	}
+	return extractCookieToken(resp), extractCookie(resp, "refresh_token")
+}
+
+func TestRefresh_RotatesTokens(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "refreshuser", "refreshuser@example.com", "password123", "presenter")
+	_, refreshToken := loginUser(t, "refreshuser", "password123")
+	assert.NotEmpty(t, refreshToken)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	newAccess := extractCookieToken(resp)
+	newRefresh := extractCookie(resp, "refresh_token")
+	assert.NotEmpty(t, newAccess)
+	assert.NotEmpty(t, newRefresh)
+	assert.NotEqual(t, refreshToken, newRefresh)
+
+	// new access token works
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, newAccess)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "reuseuser", "reuseuser@example.com", "password123", "presenter")
+	_, refreshToken := loginUser(t, "reuseuser", "password123")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	secondRefresh := extractCookie(resp, "refresh_token")
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": secondRefresh}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	newAccess := extractCookieToken(resp)
+	newRefresh := extractCookie(resp, "refresh_token")
+
+	// replaying a token that was rotated twice is outside the grace window and treated as theft
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// the whole session is revoked, including the tokens issued by the legitimate refresh
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, newAccess)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": newRefresh}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRefresh_ConcurrentRefreshWithinGrace(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "gracetabs", "gracetabs@example.com", "password123", "presenter")
+	_, refreshToken := loginUser(t, "gracetabs", "password123")
+
+	// two tabs refresh with the same token: the second one gets the pair from the first rotation
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	firstRefresh := extractCookie(resp, "refresh_token")
+
+	// the session hash never holds a plaintext secret, the grace pair lives in its own short-lived key
+	sessionID, secret, _ := strings.Cut(firstRefresh, ".")
+	values, err := testRedis.HGetAll(context.Background(), "session:"+sessionID).Result()
+	assert.NoError(t, err)
+	for _, value := range values {
+		assert.NotContains(t, value, secret)
+	}
+	ttl, err := testRedis.TTL(context.Background(), "refresh_grace:"+sessionID).Result()
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, util.RefreshReuseGrace)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	secondAccess := extractCookieToken(resp)
+	assert.Equal(t, firstRefresh, extractCookie(resp, "refresh_token"))
+
+	// the session is still alive and the shared refresh token keeps rotating
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, secondAccess)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": firstRefresh}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRefresh_InvalidToken(t *testing.T) {
+	cleanDB(t)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": "invalid"}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRefresh_KeepsRoomContext(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "roomrefresh", "roomrefresh@example.com", "password123", "presenter")
+	token, refreshToken := loginUser(t, "roomrefresh", "password123")
+	room, _ := createRoom(t, token, "Refresh Room")
+
+	// room create reuses the login session, the refresh token stays valid
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// refreshed access token still carries the room context
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+formatID(room["id"].(float64))+"/participants?page=1&size=10", nil, extractCookieToken(resp))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLogoutAll_RevokesEverySession(t *testing.T) {
+	cleanDB(t)
+
+	registerUser(t, "alluser", "alluser@example.com", "password123", "presenter")
+	first, firstRefresh := loginUser(t, "alluser", "password123")
+	second, _ := loginUser(t, "alluser", "password123")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/users/logout-all", nil, second)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, first)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, second)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": firstRefresh}, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRefresh_AfterKickFallsBackToAccount(t *testing.T) {
+	cleanDB(t)
+
+	presenterToken := registerUser(t, "kickhost", "kickhost@example.com", "password123", "presenter")
+	room, presenterRoomToken := createRoom(t, presenterToken, "Kick Room")
+	roomCode := room["room_code"].(string)
+	roomID := formatID(room["id"].(float64))
+
+	registerUser(t, "kickeduser", "kickeduser@example.com", "password123", "presenter")
+	userToken, refreshToken := loginUser(t, "kickeduser", "password123")
+	participant, participantToken := joinRoom(t, userToken, roomCode)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/rooms/"+roomID+"/participants/"+formatID(participant["id"].(float64))+"/kick", nil, presenterRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the room token is revoked, the login session is not
+	resp = makeRequest(t, http.MethodGet, "/api/v1/rooms/"+roomID+"/participants?page=1&size=10", nil, participantToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/users/refresh", map[string]string{"refresh_token": refreshToken}, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, http.MethodGet, "/api/v1/users/me/rooms", nil, extractCookieToken(resp))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
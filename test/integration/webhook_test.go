@@ -0,0 +1,242 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"reisify/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookReceiver httptest server yang mencatat setiap request webhook
+type webhookReceiver struct {
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	statuses []int // status yang dikembalikan berurutan, default 200
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status = r.statuses[0]
+		r.statuses = r.statuses[1:]
+	}
+	r.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+// waitDeliveries tunggu sampai delivery log webhook berisi minimal n delivery dengan status tertentu yang sudah dicoba kirim
+func waitDeliveries(t *testing.T, webhookID float64, status string, n int, token string) []interface{} {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp := makeRequest(t, http.MethodGet, "/api/v1/webhooks/"+formatID(webhookID)+"/deliveries?status="+status, nil, token)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		deliveries := readBody(t, resp)["data"].(map[string]interface{})["deliveries"].([]interface{})
+		attempted := 0
+		for _, d := range deliveries {
+			if d.(map[string]interface{})["attempts"].(float64) > 0 {
+				attempted++
+			}
+		}
+		if attempted >= n || time.Now().After(deadline) {
+			return deliveries
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestWebhookDeliveryAndReplay(t *testing.T) {
+	cleanDB(t)
+
+	receiver := &webhookReceiver{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	ownerToken := registerUser(t, "hookowner", "hookowner@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Hook Room")
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+		"room_id": roomID,
+		"url":     server.URL + "/hooks",
+		"events":  []string{"question:created", "room:closed"},
+	}, ownerToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	webhook := readBody(t, resp)["data"].(map[string]interface{})
+	webhookID := webhook["id"].(float64)
+	secret := webhook["secret"].(string)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	// secret tidak ditampilkan lagi
+	resp = makeRequest(t, http.MethodGet, "/api/v1/webhooks/"+formatID(webhookID), nil, ownerToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Nil(t, readBody(t, resp)["data"].(map[string]interface{})["secret"])
+
+	userToken := registerUser(t, "hookuser", "hookuser@example.com", "password123", "presenter")
+	_, userRoomToken := joinRoom(t, userToken, room["room_code"].(string))
+	submitQuestion(t, roomID, "Will this reach the webhook?", userRoomToken)
+
+	// percobaan pertama dijawab 500, delivery tetap pending untuk retry
+	pending := waitDeliveries(t, webhookID, "pending", 1, ownerToken)
+	assert.Len(t, pending, 1)
+	if len(pending) == 0 {
+		return
+	}
+	first := pending[0].(map[string]interface{})
+	assert.Equal(t, "question:created", first["event"])
+	assert.Equal(t, float64(1), first["attempts"])
+	assert.Equal(t, float64(http.StatusInternalServerError), first["response_status"])
+	assert.NotNil(t, first["next_attempt_at"])
+
+	// signature bisa diverifikasi receiver dengan secret
+	assert.Equal(t, 1, receiver.count())
+	receiver.mu.Lock()
+	body, header := receiver.bodies[0], receiver.headers[0]
+	receiver.mu.Unlock()
+	assert.Equal(t, "question:created", header.Get(usecase.WebhookHeaderEvent))
+	signature := header.Get(usecase.WebhookHeaderSignature)
+	parts := strings.SplitN(signature, ",", 2)
+	assert.Len(t, parts, 2)
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1="+usecase.SignWebhookPayload(secret, timestamp, body), parts[1])
+
+	var payload map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "question:created", payload["event"])
+	assert.Equal(t, roomID, payload["room_id"])
+	assert.Equal(t, "Will this reach the webhook?", payload["data"].(map[string]interface{})["content"])
+
+	// replay mengirim payload yang sama sebagai delivery baru
+	resp = makeRequest(t, http.MethodPost, "/api/v1/webhooks/"+formatID(webhookID)+"/deliveries/"+formatID(first["id"].(float64))+"/replay", nil, ownerToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	replay := readBody(t, resp)["data"].(map[string]interface{})
+	assert.Equal(t, "success", replay["status"])
+	assert.Equal(t, first["id"], replay["replay_of"])
+	assert.Equal(t, 2, receiver.count())
+
+	// event yang tidak dipilih tidak dikirim, room:closed dikirim
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/rooms/"+formatID(roomID)+"/close", map[string]string{"status": "closed"}, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	success := waitDeliveries(t, webhookID, "success", 2, ownerToken)
+	assert.Len(t, success, 2)
+	if len(success) > 0 {
+		assert.Equal(t, "room:closed", success[0].(map[string]interface{})["event"])
+	}
+
+	// webhook milik user lain tidak terlihat
+	resp = makeRequest(t, http.MethodGet, "/api/v1/webhooks/"+formatID(webhookID)+"/deliveries", nil, userToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhookCreateValidation(t *testing.T) {
+	cleanDB(t)
+
+	ownerToken := registerUser(t, "hookvalid", "hookvalid@example.com", "password123", "presenter")
+	room, _ := createRoom(t, ownerToken, "Valid Room")
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+		"url":    "https://example.com/hook",
+		"events": []string{"poll:vote"},
+	}, ownerToken)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// room milik user lain
+	otherToken := registerUser(t, "hookother", "hookother@example.com", "password123", "presenter")
+	resp = makeRequest(t, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+		"room_id": room["id"],
+		"url":     "https://example.com/hook",
+		"events":  []string{"question:created"},
+	}, otherToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// webhook akun (tanpa room_id)
+	resp = makeRequest(t, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+		"url":    "https://example.com/hook",
+		"events": []string{"poll:closed"},
+	}, ownerToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = makeRequest(t, http.MethodGet, "/api/v1/webhooks", nil, ownerToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, readBody(t, resp)["data"].(map[string]interface{})["webhooks"], 1)
+}
+
+func TestWebhookPollClosedOnQuizFinish(t *testing.T) {
+	cleanDB(t)
+
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	ownerToken := registerUser(t, "hookquiz", "hookquiz@example.com", "password123", "presenter")
+	room, ownerRoomToken := createRoom(t, ownerToken, "Hook Quiz Room")
+	roomID := room["id"].(float64)
+
+	resp := makeRequest(t, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+		"room_id": roomID,
+		"url":     server.URL + "/hooks",
+		"events":  []string{"poll:closed"},
+	}, ownerToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	webhookID := readBody(t, resp)["data"].(map[string]interface{})["id"].(float64)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/quizzes", map[string]interface{}{
+		"title": "Hook Trivia",
+	}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	quizID := readBody(t, resp)["data"].(map[string]interface{})["id"].(float64)
+
+	resp = makeRequest(t, http.MethodPost, "/api/v1/rooms/"+formatID(roomID)+"/polls", map[string]interface{}{
+		"question":        "Capital of Indonesia?",
+		"options":         []string{"Jakarta", "Bandung"},
+		"quiz_id":         quizID,
+		"correct_options": []int{0},
+	}, ownerRoomToken)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	pollID := readBody(t, resp)["data"].(map[string]interface{})["poll"].(map[string]interface{})["id"].(float64)
+
+	// finish menutup pertanyaan yang masih aktif dan broadcast poll:closed untuk pertanyaan itu
+	resp = makeRequest(t, http.MethodPatch, "/api/v1/quizzes/"+formatID(quizID)+"/finish", nil, ownerRoomToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	success := waitDeliveries(t, webhookID, "success", 1, ownerToken)
+	assert.Len(t, success, 1)
+	if receiver.count() == 0 {
+		return
+	}
+
+	receiver.mu.Lock()
+	body := receiver.bodies[0]
+	receiver.mu.Unlock()
+
+	var payload map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "poll:closed", payload["event"])
+	poll := payload["data"].(map[string]interface{})["poll"].(map[string]interface{})
+	assert.Equal(t, pollID, poll["id"])
+	assert.Equal(t, "closed", poll["status"])
+	assert.Len(t, poll["final_results"].(map[string]interface{})["correct_option_ids"], 1)
+}
@@ -13,9 +13,12 @@ type MockTokenUtil struct {
 }
 
 // CreateToken mock implementation
-func (m *MockTokenUtil) CreateToken(ctx context.Context, auth *model.Auth) (string, error) {
-	args := m.Called(ctx, auth)
-	return args.String(0), args.Error(1)
+func (m *MockTokenUtil) CreateToken(ctx context.Context, auth *model.Auth, client *model.SessionClient) (*model.TokenPair, error) {
+	args := m.Called(ctx, auth, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TokenPair), args.Error(1)
 }
 
 // ParseToken mock implementation
@@ -0,0 +1,345 @@
+package unit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reisify/internal/mail"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"reisify/internal/util"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recordingSender mail.Sender yang menyimpan email terkirim
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []*mail.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, message *mail.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *recordingSender) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
+
+// setupAccountUseCaseTest setup test environment for AccountUseCase
+func setupAccountUseCaseTest(t *testing.T) (*usecase.AccountUseCase, sqlmock.Sqlmock, *recordingSender) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	sender := &recordingSender{}
+	uc := &usecase.AccountUseCase{
+		DB:                    gormDB,
+		Log:                   log,
+		Validate:              validator.New(),
+		UserRepository:        &repository.UserRepository{Log: log},
+		UserTokenRepository:   &repository.UserTokenRepository{Log: log},
+		RoomRepository:        &repository.RoomRepository{Log: log},
+		ParticipantRepository: &repository.ParticipantRepository{Log: log},
+		TokenUtil:             &util.TokenUtil{SecretKey: "test-secret"},
+		Mail:                  sender,
+		BaseURL:               "http://localhost:5173",
+	}
+
+	return uc, mockDB, sender
+}
+
+// TestAccountUseCase_ForgotPassword_InvalidEmail test forgot password with malformed email
+func TestAccountUseCase_ForgotPassword_InvalidEmail(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	err := uc.ForgotPassword(context.Background(), &model.ForgotPasswordRequest{Email: "not-an-email"})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// TestAccountUseCase_ForgotPassword_UnknownEmail email tidak terdaftar tetap sukses tanpa kirim email
+func TestAccountUseCase_ForgotPassword_UnknownEmail(t *testing.T) {
+	uc, mockDB, sender := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mockDB.ExpectRollback()
+
+	err := uc.ForgotPassword(context.Background(), &model.ForgotPasswordRequest{Email: "nobody@example.com"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+	assert.Equal(t, 0, sender.count())
+}
+
+// TestAccountUseCase_ForgotPassword_RateLimited limit terlampaui tetap sukses tanpa token baru
+func TestAccountUseCase_ForgotPassword_RateLimited(t *testing.T) {
+	uc, mockDB, sender := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email"}).AddRow(1, "alice", "alice@example.com"))
+	mockDB.ExpectQuery(`SELECT count\(\*\) FROM "user_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mockDB.ExpectRollback()
+
+	err := uc.ForgotPassword(context.Background(), &model.ForgotPasswordRequest{Email: "alice@example.com"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+	assert.Equal(t, 0, sender.count())
+}
+
+// TestAccountUseCase_ResetPassword_InvalidRequest test reset password with malformed token or weak password
+func TestAccountUseCase_ResetPassword_InvalidRequest(t *testing.T) {
+	tests := []*model.ResetPasswordRequest{
+		{Token: "abc", Password: "new-password"},
+		{Token: strings.Repeat("z", 64), Password: "new-password"},
+		{Token: strings.Repeat("a", 64), Password: "short"},
+	}
+	for _, request := range tests {
+		uc, mockDB, _ := setupAccountUseCaseTest(t)
+		mockDB.ExpectBegin()
+		mockDB.ExpectRollback()
+
+		sessionIDs, err := uc.ResetPassword(context.Background(), request)
+
+		assert.Nil(t, sessionIDs)
+		assert.Equal(t, fiber.ErrBadRequest, err)
+	}
+}
+
+// TestAccountUseCase_ResetPassword_UnknownToken token yang tidak ada ditolak
+func TestAccountUseCase_ResetPassword_UnknownToken(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "user_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mockDB.ExpectRollback()
+
+	_, err := uc.ResetPassword(context.Background(), &model.ResetPasswordRequest{
+		Token:    strings.Repeat("a", 64),
+		Password: "new-password",
+	})
+
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestAccountUseCase_VerifyEmail_UsedToken token yang sudah dipakai atau kedaluwarsa ditolak
+func TestAccountUseCase_VerifyEmail_UsedToken(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "user_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "purpose", "email"}).
+			AddRow(7, 1, "email_verification", "alice@example.com"))
+	mockDB.ExpectExec(`UPDATE "user_tokens" SET "used_at"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+
+	result, err := uc.VerifyEmail(context.Background(), &model.VerifyEmailRequest{Token: strings.Repeat("b", 64)})
+
+	assert.Nil(t, result)
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestAccountUseCase_SendVerificationEmail_InvalidRequest test send verification without user
+func TestAccountUseCase_SendVerificationEmail_InvalidRequest(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	err := uc.SendVerificationEmail(context.Background(), &model.SendVerificationEmailRequest{})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// accountUserRows row user dengan password "password123"
+func accountUserRows(t *testing.T) *sqlmock.Rows {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "role"}).
+		AddRow(1, "alice", "alice@example.com", string(hash), "presenter")
+}
+
+// TestAccountUseCase_UpdateProfile_InvalidRequest test update profile dengan role tidak dikenal
+func TestAccountUseCase_UpdateProfile_InvalidRequest(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	role := "superuser"
+	_, err := uc.UpdateProfile(context.Background(), &model.UpdateProfileRequest{UserID: 1, Role: &role})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// TestAccountUseCase_UpdateProfile_NoChanges nilai yang sama tidak butuh password dan tidak menyimpan apa pun
+func TestAccountUseCase_UpdateProfile_NoChanges(t *testing.T) {
+	uc, mockDB, sender := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(accountUserRows(t))
+	mockDB.ExpectRollback()
+
+	username := "alice"
+	email := "Alice@example.com"
+	response, err := uc.UpdateProfile(context.Background(), &model.UpdateProfileRequest{UserID: 1, Username: &username, Email: &email})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", response.User.Username)
+	assert.Empty(t, response.Token)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+	assert.Equal(t, 0, sender.count())
+}
+
+// TestAccountUseCase_UpdateProfile_WrongCurrentPassword ganti email, role atau password tanpa password yang benar ditolak
+func TestAccountUseCase_UpdateProfile_WrongCurrentPassword(t *testing.T) {
+	email := "new@example.com"
+	role := "admin"
+	password := "new-password-123"
+
+	tests := map[string]*model.UpdateProfileRequest{
+		"email":    {UserID: 1, Email: &email, CurrentPassword: "wrong-password"},
+		"role":     {UserID: 1, Role: &role},
+		"password": {UserID: 1, NewPassword: &password, CurrentPassword: "wrong-password"},
+	}
+	for name, request := range tests {
+		uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+		mockDB.ExpectBegin()
+		mockDB.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(accountUserRows(t))
+		mockDB.ExpectRollback()
+
+		_, err := uc.UpdateProfile(context.Background(), request)
+
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr, name)
+		assert.Equal(t, fiber.StatusForbidden, fiberErr.Code, name)
+		assert.NoError(t, mockDB.ExpectationsWereMet(), name)
+	}
+}
+
+// TestAccountUseCase_UpdateProfile_UsernameTaken username milik user lain ditolak
+func TestAccountUseCase_UpdateProfile_UsernameTaken(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(accountUserRows(t))
+	mockDB.ExpectQuery(`SELECT \* FROM "users" WHERE username`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "bob"))
+	mockDB.ExpectRollback()
+
+	username := "bob"
+	_, err := uc.UpdateProfile(context.Background(), &model.UpdateProfileRequest{UserID: 1, Username: &username})
+
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestAccountUseCase_DeleteAccount_WrongPassword hapus akun tanpa password yang benar ditolak sebelum data apa pun diubah
+func TestAccountUseCase_DeleteAccount_WrongPassword(t *testing.T) {
+	uc, mockDB, sender := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(accountUserRows(t))
+	mockDB.ExpectRollback()
+
+	_, err := uc.DeleteAccount(context.Background(), &model.DeleteAccountRequest{UserID: 1, Password: "wrong-password"})
+
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusForbidden, fiberErr.Code)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+	assert.Equal(t, 0, sender.count())
+}
+
+// TestAccountUseCase_DeleteAccount_InvalidRequest password wajib diisi
+func TestAccountUseCase_DeleteAccount_InvalidRequest(t *testing.T) {
+	uc, mockDB, _ := setupAccountUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	_, err := uc.DeleteAccount(context.Background(), &model.DeleteAccountRequest{UserID: 1})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// TestLogSender_WritesFile email ditambahkan ke file sebagai satu baris JSON
+func TestLogSender_WritesFile(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	path := filepath.Join(t.TempDir(), "mail.jsonl")
+	sender := mail.NewLogSender(log, path)
+
+	assert.NoError(t, sender.Send(context.Background(), &mail.Message{To: "a@example.com", Subject: "One", Body: "first"}))
+	assert.NoError(t, sender.Send(context.Background(), &mail.Message{To: "b@example.com", Subject: "Two", Body: "second"}))
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var messages []struct {
+		mail.Message
+		SentAt time.Time `json:"sent_at"`
+	}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry struct {
+			mail.Message
+			SentAt time.Time `json:"sent_at"`
+		}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		messages = append(messages, entry)
+	}
+
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "b@example.com", messages[1].To)
+	assert.Equal(t, "second", messages[1].Body)
+	assert.False(t, messages[0].SentAt.IsZero())
+}
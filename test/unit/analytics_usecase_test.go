@@ -0,0 +1,134 @@
+package unit
+
+import (
+	"context"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupAnalyticsUseCaseTest setup test environment for AnalyticsUseCase
+func setupAnalyticsUseCaseTest(t *testing.T) (*usecase.AnalyticsUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.AnalyticsUseCase{
+		DB:                          gormDB,
+		Log:                         log,
+		Validate:                    validator.New(),
+		RoomRepository:              &repository.RoomRepository{Log: log},
+		ParticipantRepository:       &repository.ParticipantRepository{Log: log},
+		PollRepository:              &repository.PollRepository{Log: log},
+		AnalyticsRepository:         &repository.AnalyticsRepository{Log: log},
+		RoomPresenceEventRepository: &repository.RoomPresenceEventRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// TestAnalyticsUseCase_Get_InvalidRequest test analytics with bucket_minutes out of range
+func TestAnalyticsUseCase_Get_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupAnalyticsUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.GetRoomAnalyticsRequest{
+		PresenterID:   1,
+		RoomID:        1,
+		BucketMinutes: 2000, // invalid: max 1440
+	}
+
+	result, err := uc.Get(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestAnalyticsUseCase_RecordPresence_InvalidRequest test record presence with unknown event
+func TestAnalyticsUseCase_RecordPresence_InvalidRequest(t *testing.T) {
+	uc, _ := setupAnalyticsUseCaseTest(t)
+
+	err := uc.RecordPresence(context.Background(), &model.RecordPresenceRequest{
+		RoomID:        1,
+		ParticipantID: 1,
+		Event:         "idle",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestChooseBucketMinutes(t *testing.T) {
+	assert.Equal(t, 1, usecase.ChooseBucketMinutes(30*time.Minute))
+	assert.Equal(t, 5, usecase.ChooseBucketMinutes(3*time.Hour))
+	assert.Equal(t, 15, usecase.ChooseBucketMinutes(24*time.Hour))
+	assert.Equal(t, 1440, usecase.ChooseBucketMinutes(5*365*24*time.Hour))
+}
+
+// TestFillPresenceSeries test joins / leaves per bucket, online terbawa ke bucket berikutnya dan peak
+func TestFillPresenceSeries(t *testing.T) {
+	start := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	series := make([]model.AnalyticsBucket, 4)
+	events := []entity.RoomPresenceEvent{
+		{Event: model.PresenceEventJoin, OnlineCount: 1, CreatedAt: start.Add(10 * time.Second)},
+		{Event: model.PresenceEventJoin, OnlineCount: 2, CreatedAt: start.Add(20 * time.Second)},
+		{Event: model.PresenceEventJoin, OnlineCount: 3, CreatedAt: start.Add(70 * time.Second)},
+		{Event: model.PresenceEventLeave, OnlineCount: 2, CreatedAt: start.Add(80 * time.Second)},
+		{Event: model.PresenceEventLeave, OnlineCount: 1, CreatedAt: start.Add(190 * time.Second)},
+	}
+
+	result := usecase.FillPresenceSeries(series, events, func(at time.Time) int {
+		return int(at.Sub(start) / time.Minute)
+	})
+
+	assert.Equal(t, 3, result.PeakConcurrent)
+	assert.Equal(t, start.Add(70*time.Second), *result.PeakAt)
+
+	assert.Equal(t, 2, series[0].Joins)
+	assert.Equal(t, 2, series[0].Online)
+	assert.Equal(t, 1, series[1].Joins)
+	assert.Equal(t, 1, series[1].Leaves)
+	assert.Equal(t, 3, series[1].Online)
+	assert.Equal(t, 2, series[2].Online) // tidak ada event, online dari bucket sebelumnya
+	assert.Equal(t, 1, series[3].Leaves)
+	assert.Equal(t, 2, series[3].Online)
+}
+
+func TestComputeXPDistribution(t *testing.T) {
+	distribution := usecase.ComputeXPDistribution([]int{0, 5, 20, 20, 120, 600})
+
+	assert.Equal(t, 127.5, distribution.Average)
+	assert.Equal(t, 20.0, distribution.Median)
+	assert.Equal(t, 600, distribution.Max)
+
+	counts := make([]int, len(distribution.Buckets))
+	for i, b := range distribution.Buckets {
+		counts[i] = b.Count
+	}
+	assert.Equal(t, []int{1, 1, 2, 0, 1, 0, 1}, counts)
+	assert.Nil(t, distribution.Buckets[len(distribution.Buckets)-1].Max)
+
+	empty := usecase.ComputeXPDistribution(nil)
+	assert.Len(t, empty.Buckets, 7)
+	assert.Equal(t, 0, empty.Max)
+}
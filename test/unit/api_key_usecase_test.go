@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"context"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupAPIKeyUseCaseTest setup test environment for APIKeyUseCase
+func setupAPIKeyUseCaseTest(t *testing.T) (*usecase.APIKeyUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.APIKeyUseCase{
+		DB:               gormDB,
+		Log:              log,
+		Validate:         validator.New(),
+		APIKeyRepository: &repository.APIKeyRepository{Log: log},
+		UserRepository:   &repository.UserRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// TestAPIKeyUseCase_Create_InvalidScope test create API key with unknown scope
+func TestAPIKeyUseCase_Create_InvalidScope(t *testing.T) {
+	uc, mockDB := setupAPIKeyUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.CreateAPIKeyRequest{
+		UserID: 1,
+		Name:   "script",
+		Scopes: []string{"rooms:admin"}, // invalid: scope tidak dikenal
+	}
+
+	result, err := uc.Create(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestAPIKeyUseCase_Authenticate_InvalidKey key tanpa prefix ditolak tanpa query database
+func TestAPIKeyUseCase_Authenticate_InvalidKey(t *testing.T) {
+	uc, mockDB := setupAPIKeyUseCaseTest(t)
+
+	result, err := uc.Authenticate(context.Background(), &model.AuthenticateAPIKeyRequest{Key: "not-a-key"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, fiber.ErrUnauthorized, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestHashAPIKey hash deterministik dan berbeda per key
+func TestHashAPIKey(t *testing.T) {
+	hash := usecase.HashAPIKey("rsk_abc")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, usecase.HashAPIKey("rsk_abc"))
+	assert.NotEqual(t, hash, usecase.HashAPIKey("rsk_abd"))
+}
+
+// TestIsAPIKeyUsable key dicabut atau kedaluwarsa tidak bisa dipakai
+func TestIsAPIKeyUsable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	assert.False(t, usecase.IsAPIKeyUsable(nil, now))
+	assert.True(t, usecase.IsAPIKeyUsable(&entity.APIKey{}, now))
+	assert.True(t, usecase.IsAPIKeyUsable(&entity.APIKey{ExpiresAt: &future}, now))
+	assert.False(t, usecase.IsAPIKeyUsable(&entity.APIKey{ExpiresAt: &past}, now))
+	assert.False(t, usecase.IsAPIKeyUsable(&entity.APIKey{RevokedAt: &past}, now))
+}
+
+// TestAuth_HasScope login biasa tidak dibatasi scope, API key hanya scope miliknya
+func TestAuth_HasScope(t *testing.T) {
+	keyID := uint(1)
+
+	session := &model.Auth{}
+	assert.True(t, session.HasScope(model.ScopeRoomsWrite))
+
+	apiKey := &model.Auth{APIKeyID: &keyID, Scopes: []string{model.ScopeRoomsRead}}
+	assert.True(t, apiKey.HasScope(model.ScopeRoomsRead))
+	assert.False(t, apiKey.HasScope(model.ScopeRoomsWrite))
+}
@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"context"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupContentFilterUseCaseTest setup test environment for ContentFilterUseCase
+func setupContentFilterUseCaseTest(t *testing.T) (*usecase.ContentFilterUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.ContentFilterUseCase{
+		DB:                          gormDB,
+		Log:                         log,
+		Validate:                    validator.New(),
+		RoomRepository:              &repository.RoomRepository{Log: log},
+		ParticipantRepository:       &repository.ParticipantRepository{Log: log},
+		RoomContentFilterRepository: &repository.RoomContentFilterRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// TestContentFilterUseCase_Update_InvalidRequest test update content filter with invalid action
+func TestContentFilterUseCase_Update_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupContentFilterUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.UpdateContentFilterRequest{
+		PresenterID:        1,
+		RoomID:             1,
+		Enabled:            true,
+		WordAction:         "delete", // invalid: mask, reject or moderate
+		LinkAction:         "reject",
+		RepeatAction:       "reject",
+		FloodWindowSeconds: 10,
+	}
+
+	result, err := uc.Update(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestFilterContent test link and word list rules
+func TestFilterContent(t *testing.T) {
+	filter := &entity.RoomContentFilter{
+		Enabled:      true,
+		BlockedWords: []string{"darn", "heck"},
+		WordAction:   usecase.ContentFilterMask,
+		BlockLinks:   true,
+		LinkAction:   usecase.ContentFilterReject,
+	}
+
+	tests := []struct {
+		name           string
+		filter         *entity.RoomContentFilter
+		content        string
+		expectedAction string
+		expectedText   string
+	}{
+		{
+			name:           "clean content",
+			filter:         filter,
+			content:        "What is the roadmap?",
+			expectedAction: usecase.ContentFilterAllow,
+			expectedText:   "What is the roadmap?",
+		},
+		{
+			name:           "blocked word is masked case-insensitively",
+			filter:         filter,
+			content:        "Oh DARN, that is late",
+			expectedAction: usecase.ContentFilterMask,
+			expectedText:   "Oh ****, that is late",
+		},
+		{
+			name:           "word inside another word is kept",
+			filter:         filter,
+			content:        "Check the darnell report",
+			expectedAction: usecase.ContentFilterAllow,
+			expectedText:   "Check the darnell report",
+		},
+		{
+			name:           "link is rejected",
+			filter:         filter,
+			content:        "visit https://spam.example now",
+			expectedAction: usecase.ContentFilterReject,
+		},
+		{
+			name:           "bare domain is detected",
+			filter:         filter,
+			content:        "go to bit.ly/free",
+			expectedAction: usecase.ContentFilterReject,
+		},
+		{
+			name: "link is masked",
+			filter: &entity.RoomContentFilter{
+				Enabled:    true,
+				BlockLinks: true,
+				LinkAction: usecase.ContentFilterMask,
+			},
+			content:        "slides at www.example.com/deck please",
+			expectedAction: usecase.ContentFilterMask,
+			expectedText:   "slides at [link] please",
+		},
+		{
+			name: "moderate wins over mask",
+			filter: &entity.RoomContentFilter{
+				Enabled:      true,
+				BlockedWords: []string{"heck"},
+				WordAction:   usecase.ContentFilterModerate,
+				BlockLinks:   true,
+				LinkAction:   usecase.ContentFilterMask,
+			},
+			content:        "heck see example.com",
+			expectedAction: usecase.ContentFilterModerate,
+			expectedText:   "heck see [link]",
+		},
+		{
+			name: "disabled filter allows everything",
+			filter: &entity.RoomContentFilter{
+				Enabled:      false,
+				BlockedWords: []string{"darn"},
+				WordAction:   usecase.ContentFilterReject,
+			},
+			content:        "darn",
+			expectedAction: usecase.ContentFilterAllow,
+			expectedText:   "darn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := usecase.FilterContent(tt.filter, tt.content)
+			assert.Equal(t, tt.expectedAction, result.Action)
+			if tt.expectedText != "" {
+				assert.Equal(t, tt.expectedText, result.Content)
+			}
+		})
+	}
+}
+
+// TestUpdateContentFilterRequest_Validation test content filter request validation
+func TestUpdateContentFilterRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	valid := model.UpdateContentFilterRequest{
+		PresenterID:        1,
+		RoomID:             1,
+		Enabled:            true,
+		BlockedWords:       []string{"spam"},
+		WordAction:         "mask",
+		LinkAction:         "moderate",
+		RepeatAction:       "reject",
+		FloodMaxMessages:   5,
+		FloodWindowSeconds: 10,
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(r *model.UpdateContentFilterRequest)
+		shouldError bool
+	}{
+		{name: "valid request", modify: func(r *model.UpdateContentFilterRequest) {}, shouldError: false},
+		{name: "mask not allowed for repeats", modify: func(r *model.UpdateContentFilterRequest) { r.RepeatAction = "mask" }, shouldError: true},
+		{name: "empty blocked word", modify: func(r *model.UpdateContentFilterRequest) { r.BlockedWords = []string{""} }, shouldError: true},
+		{name: "flood window required", modify: func(r *model.UpdateContentFilterRequest) { r.FloodWindowSeconds = 0 }, shouldError: true},
+		{name: "repeat window too long", modify: func(r *model.UpdateContentFilterRequest) { r.RepeatWindowSeconds = 7200 }, shouldError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid
+			tt.modify(&request)
+			err := validate.Struct(request)
+			if tt.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
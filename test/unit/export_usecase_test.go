@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupExportUseCaseTest setup test environment for ExportUseCase
+func setupExportUseCaseTest(t *testing.T) (*usecase.ExportUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.ExportUseCase{
+		DB:                      gormDB,
+		Log:                     log,
+		Validate:                validator.New(),
+		RoomRepository:          &repository.RoomRepository{Log: log},
+		ActivityRepository:      &repository.ActivityRepository{Log: log},
+		QuestionRepository:      &repository.QuestionRepository{Log: log},
+		QuestionReplyRepository: &repository.QuestionReplyRepository{Log: log},
+		PollRepository:          &repository.PollRepository{Log: log},
+		ParticipantRepository:   &repository.ParticipantRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// sampleRoomExport dokumen export kecil untuk test encoder
+func sampleRoomExport() *model.RoomExport {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	return &model.RoomExport{
+		Room:       model.RoomExportInfo{ID: 1, RoomCode: "ABC123", Title: "Town Hall", Status: "active", CreatedAt: now, TotalParticipants: 1, TotalQuestions: 1, TotalPolls: 1, TotalMessages: 1},
+		ExportedAt: now,
+		Questions: []model.QuestionResponse{{
+			ID: 1, Participant: model.ParticipantInfo{ID: 1, DisplayName: "Alice"}, Content: "=HYPERLINK(\"x\")", Status: "pending", CreatedAt: now,
+			Replies: []model.ReplyResponse{{ID: 1, QuestionID: 1, Participant: model.ParticipantInfo{ID: 2, DisplayName: "Host"}, Content: "Soon", IsPresenter: true, CreatedAt: now}},
+		}},
+		Polls: []model.PollExport{{PollResponse: model.PollResponse{
+			ID: 1, Question: "Lunch?", Type: model.PollTypeSingleChoice, Status: "closed", TotalVotes: 2, CreatedAt: now,
+			Options: []model.PollOptionResponse{{ID: 1, OptionText: "Yes", VoteCount: 2, Percentage: 100}, {ID: 2, OptionText: "No"}},
+		}}},
+		Messages:    []model.MessageExport{{ID: 1, Participant: model.ParticipantInfo{ID: 1, DisplayName: "Alice"}, Content: "<script>alert(1)</script>", CreatedAt: now}},
+		Leaderboard: []model.LeaderboardEntry{{Rank: 1, Participant: model.ParticipantInfo{ID: 1, DisplayName: "Alice"}, XPScore: 10}},
+	}
+}
+
+// TestExportUseCase_Export_InvalidRequest test export with unsupported format
+func TestExportUseCase_Export_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupExportUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.ExportRoomRequest{
+		PresenterID: 1,
+		RoomID:      1,
+		Format:      "pdf", // invalid: json, csv or html
+	}
+
+	result, err := uc.Export(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestEncodeRoomExport_JSON test json export round trip
+func TestEncodeRoomExport_JSON(t *testing.T) {
+	file, err := usecase.EncodeRoomExport(sampleRoomExport(), model.ExportFormatJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, "room-ABC123-export.json", file.Filename)
+	assert.Equal(t, "application/json", file.ContentType)
+
+	var decoded model.RoomExport
+	assert.NoError(t, json.Unmarshal(file.Body, &decoded))
+	assert.Equal(t, "Town Hall", decoded.Room.Title)
+	assert.Len(t, decoded.Questions[0].Replies, 1)
+	assert.Equal(t, 2, decoded.Polls[0].TotalVotes)
+}
+
+// TestEncodeRoomExport_CSV test csv zip berisi satu file per bagian dan cell formula di-escape
+func TestEncodeRoomExport_CSV(t *testing.T) {
+	file, err := usecase.EncodeRoomExport(sampleRoomExport(), model.ExportFormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, "room-ABC123-export.zip", file.Filename)
+
+	reader, err := zip.NewReader(bytes.NewReader(file.Body), int64(len(file.Body)))
+	assert.NoError(t, err)
+
+	files := make(map[string][][]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		rows, err := csv.NewReader(rc).ReadAll()
+		assert.NoError(t, err)
+		rc.Close()
+		files[f.Name] = rows
+	}
+
+	for _, name := range []string{"room.csv", "questions.csv", "replies.csv", "polls.csv", "chat.csv", "leaderboard.csv"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Equal(t, `'=HYPERLINK("x")`, files["questions.csv"][1][3])
+	assert.Len(t, files["polls.csv"], 3) // header + 2 option
+	assert.Equal(t, "Host", files["replies.csv"][1][3])
+}
+
+// TestEncodeRoomExport_HTML test html report meng-escape konten user
+func TestEncodeRoomExport_HTML(t *testing.T) {
+	file, err := usecase.EncodeRoomExport(sampleRoomExport(), model.ExportFormatHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, "room-ABC123-export.html", file.Filename)
+
+	body := string(file.Body)
+	assert.True(t, strings.Contains(body, "Town Hall"))
+	assert.False(t, strings.Contains(body, "<script>alert(1)</script>"))
+	assert.True(t, strings.Contains(body, "&lt;script&gt;"))
+}
@@ -8,9 +8,11 @@ import (
 	"reisify/internal/usecase"
 	"reisify/test/mocks"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -100,6 +102,74 @@ func TestMessageUseCase_List_InvalidRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestMessageUseCase_Update_InvalidRequest test edit message with empty content
+func TestMessageUseCase_Update_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupMessageUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.UpdateMessageRequest{
+		MessageID:     1,
+		RoomID:        1,
+		ParticipantID: 1,
+		Content:       "", // invalid: required
+	}
+
+	result, err := uc.Update(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestMessageUseCase_Delete_InvalidRequest test delete message without message id
+func TestMessageUseCase_Delete_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupMessageUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.DeleteMessageRequest{
+		RoomID:        1,
+		ParticipantID: 1,
+	}
+
+	result, err := uc.Delete(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestMessageUseCase_Delete_AlreadyDeleted message yang dihapus request lain sejak dibaca return 404
+func TestMessageUseCase_Delete_AlreadyDeleted(t *testing.T) {
+	uc, mockDB := setupMessageUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "messages"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "participant_id", "created_at"}).AddRow(5, 1, 1, time.Now()))
+	mockDB.ExpectQuery(`SELECT \* FROM "participants"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id"}).AddRow(1, 1))
+	mockDB.ExpectQuery(`SELECT \* FROM "participants"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id"}).AddRow(1, 1))
+	mockDB.ExpectExec(`UPDATE "messages" SET .* WHERE \(id = \$3 AND deleted_at IS NULL\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+
+	request := &model.DeleteMessageRequest{
+		MessageID:     5,
+		RoomID:        1,
+		ParticipantID: 1,
+	}
+
+	result, err := uc.Delete(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Equal(t, fiber.ErrNotFound, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
 // TestSendMessageRequest_Validation test send message request validation
 func TestSendMessageRequest_Validation(t *testing.T) {
 	validate := validator.New()
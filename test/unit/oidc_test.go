@@ -0,0 +1,200 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reisify/internal/model"
+	"reisify/internal/oidc"
+	"reisify/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"reisify/test/mocks"
+)
+
+const testOIDCRedirectURL = "http://localhost:3000/api/v1/users/oidc/callback"
+
+// setupOIDCProviderTest start mock provider dan client yang mengarah ke mock tersebut
+func setupOIDCProviderTest(t *testing.T) (*mocks.MockOIDCProvider, *oidc.Provider) {
+	mock := mocks.NewMockOIDCProvider("reisify", "client-secret")
+	t.Cleanup(mock.Close)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       mock.Issuer(),
+		ClientID:     "reisify",
+		ClientSecret: "client-secret",
+		RedirectURL:  testOIDCRedirectURL,
+	})
+	return mock, provider
+}
+
+// authorizeCode jalankan redirect ke provider dan return code dari url callback
+func authorizeCode(t *testing.T, mock *mocks.MockOIDCProvider, provider *oidc.Provider, nonce, verifier string, claims map[string]interface{}) string {
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", nonce, verifier)
+	require.NoError(t, err)
+
+	callback, err := mock.Authorize(authURL, claims)
+	require.NoError(t, err)
+	parsed, err := url.Parse(callback)
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", parsed.Query().Get("state"))
+	return parsed.Query().Get("code")
+}
+
+// TestOIDCProvider_AuthorizationCodeFlow test code exchange dengan PKCE dan verifikasi id_token
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	mock, provider := setupOIDCProviderTest(t)
+
+	code := authorizeCode(t, mock, provider, "nonce-1", "verifier-abcdefghijklmnopqrstuvwxyz-0123456789", map[string]interface{}{
+		"sub":            "user-123",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"name":           "Alice",
+	})
+
+	token, err := provider.Exchange(context.Background(), code, "verifier-abcdefghijklmnopqrstuvwxyz-0123456789")
+	require.NoError(t, err)
+
+	claims, err := provider.VerifyIDToken(context.Background(), token.IDToken, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, mock.Issuer(), provider.Issuer())
+}
+
+// TestOIDCProvider_Exchange_WrongVerifier code_verifier yang tidak cocok ditolak provider
+func TestOIDCProvider_Exchange_WrongVerifier(t *testing.T) {
+	mock, provider := setupOIDCProviderTest(t)
+
+	code := authorizeCode(t, mock, provider, "nonce-1", "verifier-one", map[string]interface{}{"sub": "user-123"})
+
+	_, err := provider.Exchange(context.Background(), code, "verifier-two")
+	assert.Error(t, err)
+}
+
+// TestOIDCProvider_VerifyIDToken_Rejects nonce, audience, issuer dan expiry yang salah ditolak
+func TestOIDCProvider_VerifyIDToken_Rejects(t *testing.T) {
+	mock, provider := setupOIDCProviderTest(t)
+	now := time.Now()
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   mock.Issuer(),
+			"aud":   "reisify",
+			"sub":   "user-123",
+			"nonce": "nonce-1",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Minute).Unix(),
+		}
+	}
+
+	token, err := mock.SignIDToken(valid())
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(context.Background(), token, "nonce-1")
+	assert.NoError(t, err)
+
+	tests := map[string]func(jwt.MapClaims){
+		"nonce":    func(c jwt.MapClaims) { c["nonce"] = "other" },
+		"audience": func(c jwt.MapClaims) { c["aud"] = "other-client" },
+		"issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":  func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Hour).Unix() },
+		"subject":  func(c jwt.MapClaims) { delete(c, "sub") },
+		"azp":      func(c jwt.MapClaims) { c["aud"] = []string{"reisify", "other-client"} },
+	}
+	for name, mutate := range tests {
+		claims := valid()
+		mutate(claims)
+		token, err := mock.SignIDToken(claims)
+		require.NoError(t, err)
+
+		_, err = provider.VerifyIDToken(context.Background(), token, "nonce-1")
+		assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken), name)
+	}
+
+	// token yang tidak ditandatangani provider
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+	raw, err := unsigned.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(context.Background(), raw, "nonce-1")
+	assert.True(t, errors.Is(err, oidc.ErrInvalidIDToken))
+}
+
+// TestCodeChallengeS256 contoh dari RFC 7636 Appendix B
+func TestCodeChallengeS256(t *testing.T) {
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oidc.CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+
+	value, err := oidc.RandomString()
+	assert.NoError(t, err)
+	assert.Len(t, value, 43)
+}
+
+// TestSafeRedirectPath hanya path relatif yang diterima sebagai tujuan redirect
+func TestSafeRedirectPath(t *testing.T) {
+	tests := map[string]string{
+		"":                         "/",
+		"/rooms/ABC123":            "/rooms/ABC123",
+		"//evil.example.com":       "/",
+		"https://evil.example.com": "/",
+		"/\\evil.example.com":      "/",
+		"rooms":                    "/",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, usecase.SafeRedirectPath(input), input)
+	}
+}
+
+// setupOIDCUseCaseTest usecase dengan provider yang tidak perlu dihubungi
+func setupOIDCUseCaseTest(provider *oidc.Provider) *usecase.OIDCUseCase {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	return &usecase.OIDCUseCase{
+		Log:      log,
+		Validate: validator.New(),
+		Provider: provider,
+	}
+}
+
+// TestOIDCUseCase_NotConfigured SSO tanpa issuer mengembalikan 404
+func TestOIDCUseCase_NotConfigured(t *testing.T) {
+	uc := setupOIDCUseCaseTest(nil)
+
+	_, err := uc.Login(context.Background(), &model.OIDCLoginRequest{})
+
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+}
+
+// TestOIDCUseCase_Callback_Rejects error provider, request tidak valid dan state yang tidak cocok dengan cookie
+func TestOIDCUseCase_Callback_Rejects(t *testing.T) {
+	uc := setupOIDCUseCaseTest(oidc.NewProvider(oidc.Config{Issuer: "http://127.0.0.1:0", ClientID: "reisify"}))
+
+	tests := []struct {
+		request *model.OIDCCallbackRequest
+		status  int
+	}{
+		{&model.OIDCCallbackRequest{Error: "access_denied"}, fiber.StatusUnauthorized},
+		{&model.OIDCCallbackRequest{State: "state-1"}, fiber.StatusBadRequest},
+		{&model.OIDCCallbackRequest{Code: "code", State: strings.Repeat("s", 200)}, fiber.StatusBadRequest},
+		{&model.OIDCCallbackRequest{Code: "code", State: "state-1", CookieState: "state-2"}, fiber.StatusUnauthorized},
+		{&model.OIDCCallbackRequest{Code: "code", State: "state-1"}, fiber.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		_, err := uc.Callback(context.Background(), tt.request)
+
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, tt.status, fiberErr.Code)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +48,8 @@ func setupParticipantUseCaseTest(t *testing.T) (*usecase.ParticipantUseCase, sql
 		Validate:              validate,
 		ParticipantRepository: &repository.ParticipantRepository{Log: log},
 		RoomRepository:        &repository.RoomRepository{Log: log},
+		RoomBanRepository:     &repository.RoomBanRepository{Log: log},
+		RoomRoleRepository:    &repository.RoomRoleRepository{Log: log},
 		UserRepository:        &repository.UserRepository{Log: log},
 		TokenUtil:             &util.TokenUtil{SecretKey: "test-secret"},
 	}
@@ -117,6 +120,165 @@ func TestParticipantUseCase_Leaderboard_InvalidRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestParticipantUseCase_ListOnline test data participant online dari ID hub
+func TestParticipantUseCase_ListOnline(t *testing.T) {
+	uc, mockDB := setupParticipantUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "participants" WHERE room_id = \$1 AND id IN \(\$2,\$3\) ORDER BY id ASC`).
+		WithArgs(1, 3, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "display_name", "xp_score", "is_anonymous"}).
+			AddRow(3, 1, "Alice", 20, false).
+			AddRow(5, 1, "Anonymous", 0, true))
+	mockDB.ExpectCommit()
+
+	result, err := uc.ListOnline(context.Background(), &model.ListOnlineParticipantsRequest{
+		RoomID:         1,
+		ParticipantIDs: []uint{3, 5},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Participants, 2)
+	assert.Equal(t, "Alice", result.Participants[0].DisplayName)
+	assert.True(t, result.Participants[1].IsAnonymous)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestParticipantUseCase_ListOnline_Empty test tanpa participant online tidak query database
+func TestParticipantUseCase_ListOnline_Empty(t *testing.T) {
+	uc, mockDB := setupParticipantUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectCommit()
+
+	result, err := uc.ListOnline(context.Background(), &model.ListOnlineParticipantsRequest{RoomID: 1})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Participants)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestParticipantUseCase_Mute_InvalidRequest test mute participant with invalid duration
+func TestParticipantUseCase_Mute_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupParticipantUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.MuteParticipantRequest{
+		ActorID:         1,
+		RoomID:          1,
+		ParticipantID:   2,
+		DurationSeconds: 0, // invalid: min 1
+	}
+
+	result, err := uc.Mute(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestParticipantUseCase_Mute_ForbiddenWithoutRole test participant tanpa role moderasi tidak bisa mute
+func TestParticipantUseCase_Mute_ForbiddenWithoutRole(t *testing.T) {
+	uc, mockDB := setupParticipantUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "rooms" WHERE id = \$1`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "presenter_id"}).AddRow(1, 10))
+	mockDB.ExpectQuery(`SELECT "role" FROM "room_roles" WHERE room_id = \$1 AND user_id = \$2`).
+		WithArgs(1, 5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}))
+	mockDB.ExpectRollback()
+
+	result, err := uc.Mute(context.Background(), &model.MuteParticipantRequest{
+		ActorID:         5,
+		RoomID:          1,
+		ParticipantID:   2,
+		DurationSeconds: 60,
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, fiber.ErrForbidden, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestParticipantUseCase_Kick_ModeratorCannotKickCoHost test moderator tidak bisa kick co-host
+func TestParticipantUseCase_Kick_ModeratorCannotKickCoHost(t *testing.T) {
+	uc, mockDB := setupParticipantUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "rooms" WHERE id = \$1`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "presenter_id"}).AddRow(1, 10))
+	mockDB.ExpectQuery(`SELECT "role" FROM "room_roles" WHERE room_id = \$1 AND user_id = \$2`).
+		WithArgs(1, 5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(model.RoomRoleModerator))
+	mockDB.ExpectQuery(`SELECT \* FROM "participants" WHERE room_id = \$1 AND id = \$2`).
+		WithArgs(1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "user_id"}).AddRow(2, 1, 7))
+	mockDB.ExpectQuery(`SELECT "role" FROM "room_roles" WHERE room_id = \$1 AND user_id = \$2`).
+		WithArgs(1, 7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(model.RoomRoleCoHost))
+	mockDB.ExpectRollback()
+
+	result, err := uc.Kick(context.Background(), &model.ModerateParticipantRequest{
+		ActorID:       5,
+		RoomID:        1,
+		ParticipantID: 2,
+	})
+
+	assert.Nil(t, result)
+	var fiberErr *fiber.Error
+	assert.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, fiber.StatusForbidden, fiberErr.Code)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestMuteParticipantRequest_Validation test mute participant request validation
+func TestMuteParticipantRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name        string
+		request     model.MuteParticipantRequest
+		shouldError bool
+	}{
+		{
+			name:        "valid request",
+			request:     model.MuteParticipantRequest{ActorID: 1, RoomID: 1, ParticipantID: 2, DurationSeconds: 300},
+			shouldError: false,
+		},
+		{
+			name:        "missing duration",
+			request:     model.MuteParticipantRequest{ActorID: 1, RoomID: 1, ParticipantID: 2},
+			shouldError: true,
+		},
+		{
+			name:        "duration longer than a day",
+			request:     model.MuteParticipantRequest{ActorID: 1, RoomID: 1, ParticipantID: 2, DurationSeconds: 86401},
+			shouldError: true,
+		},
+		{
+			name:        "missing participant",
+			request:     model.MuteParticipantRequest{ActorID: 1, RoomID: 1, DurationSeconds: 60},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if tt.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 // TestJoinRoomRequest_Validation test join room request validation
 func TestJoinRoomRequest_Validation(t *testing.T) {
 	validate := validator.New()
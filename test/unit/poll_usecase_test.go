@@ -1,11 +1,26 @@
 package unit
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reisify/internal/entity"
 	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
 	"reisify/test/mocks"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 // TestCreatePollRequest_Validation test validation untuk CreatePollRequest
@@ -67,6 +82,111 @@ func TestCreatePollRequest_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid multiple choice",
+			request: model.CreatePollRequest{
+				RoomID:        1,
+				PresenterID:   1,
+				Question:      "Which topics should we cover?",
+				Type:          model.PollTypeMultipleChoice,
+				Options:       []string{"Go", "Rust", "Zig"},
+				MaxSelections: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid rating without options",
+			request: model.CreatePollRequest{
+				RoomID:      1,
+				PresenterID: 1,
+				Question:    "How was the session?",
+				Type:        model.PollTypeRating,
+			},
+			wantErr: false,
+		},
+		{
+			name: "rating scale too large",
+			request: model.CreatePollRequest{
+				RoomID:      1,
+				PresenterID: 1,
+				Question:    "How was the session?",
+				Type:        model.PollTypeRating,
+				RatingScale: 11,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown poll type",
+			request: model.CreatePollRequest{
+				RoomID:      1,
+				PresenterID: 1,
+				Question:    "What is your favorite color?",
+				Type:        "yes_no",
+				Options:     []string{"Red", "Blue"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid quiz question",
+			request: model.CreatePollRequest{
+				RoomID:           1,
+				PresenterID:      1,
+				Question:         "What is the capital of Indonesia?",
+				Options:          []string{"Jakarta", "Bandung", "Surabaya"},
+				QuizID:           1,
+				CorrectOptions:   []int{0},
+				TimeLimitSeconds: 20,
+			},
+			wantErr: false,
+		},
+		{
+			name: "quiz time limit too short",
+			request: model.CreatePollRequest{
+				RoomID:           1,
+				PresenterID:      1,
+				Question:         "What is the capital of Indonesia?",
+				Options:          []string{"Jakarta", "Bandung"},
+				QuizID:           1,
+				CorrectOptions:   []int{0},
+				TimeLimitSeconds: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate correct option",
+			request: model.CreatePollRequest{
+				RoomID:         1,
+				PresenterID:    1,
+				Question:       "Which are prime numbers?",
+				Type:           model.PollTypeMultipleChoice,
+				Options:        []string{"2", "3", "4"},
+				QuizID:         1,
+				CorrectOptions: []int{0, 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid open text without options",
+			request: model.CreatePollRequest{
+				RoomID:        1,
+				PresenterID:   1,
+				Question:      "Describe the keynote in one word",
+				Type:          model.PollTypeOpenText,
+				MaxSelections: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "open text too many answers per participant",
+			request: model.CreatePollRequest{
+				RoomID:        1,
+				PresenterID:   1,
+				Question:      "Describe the keynote in one word",
+				Type:          model.PollTypeOpenText,
+				MaxSelections: 11,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -118,6 +238,175 @@ func TestSubmitVoteRequest_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid multiple selection",
+			request: model.SubmitPollVoteRequest{
+				PollID:        1,
+				ParticipantID: 1,
+				RoomID:        1,
+				OptionIDs:     []uint{3, 1, 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate option in selection",
+			request: model.SubmitPollVoteRequest{
+				PollID:        1,
+				ParticipantID: 1,
+				RoomID:        1,
+				OptionIDs:     []uint{1, 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid rating",
+			request: model.SubmitPollVoteRequest{
+				PollID:        1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Rating:        4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid text answer",
+			request: model.SubmitPollVoteRequest{
+				PollID:        1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Text:          "Inspiring",
+			},
+			wantErr: false,
+		},
+		{
+			name: "text answer too long",
+			request: model.SubmitPollVoteRequest{
+				PollID:        1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Text:          strings.Repeat("a", 101),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestUpdatePollRequest_Validation test validation untuk UpdatePollRequest
+func TestUpdatePollRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	question := "What is your favorite season?"
+	shortQuestion := "Hi"
+	timeLimit := 3
+
+	tests := []struct {
+		name    string
+		request model.UpdatePollRequest
+		wantErr bool
+	}{
+		{
+			name: "valid partial update",
+			request: model.UpdatePollRequest{
+				PollID:      1,
+				PresenterID: 1,
+				Question:    &question,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty body is valid",
+			request: model.UpdatePollRequest{
+				PollID:      1,
+				PresenterID: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "question too short",
+			request: model.UpdatePollRequest{
+				PollID:      1,
+				PresenterID: 1,
+				Question:    &shortQuestion,
+			},
+			wantErr: true,
+		},
+		{
+			name: "too few options",
+			request: model.UpdatePollRequest{
+				PollID:      1,
+				PresenterID: 1,
+				Options:     []string{"Summer"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "time limit too short",
+			request: model.UpdatePollRequest{
+				PollID:           1,
+				PresenterID:      1,
+				TimeLimitSeconds: &timeLimit,
+			},
+			wantErr: true,
+		},
+		{
+			name: "poll_id zero",
+			request: model.UpdatePollRequest{
+				PollID:      0,
+				PresenterID: 1,
+				Question:    &question,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestReorderPollOptionsRequest_Validation test validation untuk ReorderPollOptionsRequest
+func TestReorderPollOptionsRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name    string
+		request model.ReorderPollOptionsRequest
+		wantErr bool
+	}{
+		{
+			name:    "valid order",
+			request: model.ReorderPollOptionsRequest{PollID: 1, PresenterID: 1, OptionIDs: []uint{3, 1, 2}},
+			wantErr: false,
+		},
+		{
+			name:    "missing option ids",
+			request: model.ReorderPollOptionsRequest{PollID: 1, PresenterID: 1},
+			wantErr: true,
+		},
+		{
+			name:    "single option",
+			request: model.ReorderPollOptionsRequest{PollID: 1, PresenterID: 1, OptionIDs: []uint{1}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate option",
+			request: model.ReorderPollOptionsRequest{PollID: 1, PresenterID: 1, OptionIDs: []uint{1, 2, 1}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,3 +452,296 @@ func TestXPPointsConfiguration_Poll(t *testing.T) {
 		t.Errorf("Expected XP for vote to be 5, got %d", expectedXP)
 	}
 }
+
+// TestTallyInstantRunoff test perhitungan ranked choice dengan instant-runoff
+func TestTallyInstantRunoff(t *testing.T) {
+	options := []uint{1, 2, 3}
+
+	t.Run("majority in first round", func(t *testing.T) {
+		results := usecase.TallyInstantRunoff(options, [][]uint{{1, 2}, {1}, {2, 1}})
+		if results.WinnerOptionID == nil || *results.WinnerOptionID != 1 {
+			t.Fatalf("expected option 1 to win, got %v", results.WinnerOptionID)
+		}
+		if len(results.Rounds) != 1 {
+			t.Errorf("expected 1 round, got %d", len(results.Rounds))
+		}
+	})
+
+	t.Run("winner after elimination", func(t *testing.T) {
+		// round 1: 1=2, 2=2, 3=1 -> 3 dieliminasi, ballot {3,2} pindah ke 2
+		ballots := [][]uint{{1}, {1, 3}, {2}, {2, 1}, {3, 2}}
+		results := usecase.TallyInstantRunoff(options, ballots)
+		if results.WinnerOptionID == nil || *results.WinnerOptionID != 2 {
+			t.Fatalf("expected option 2 to win, got %v", results.WinnerOptionID)
+		}
+		if len(results.Rounds) != 2 {
+			t.Fatalf("expected 2 rounds, got %d", len(results.Rounds))
+		}
+		if got := results.Rounds[0].Eliminated; len(got) != 1 || got[0] != 3 {
+			t.Errorf("expected option 3 eliminated in round 1, got %v", got)
+		}
+	})
+
+	t.Run("all options tied", func(t *testing.T) {
+		// round 1: 1=1, 2=1, 3=1 -> semua seri
+		results := usecase.TallyInstantRunoff(options, [][]uint{{1}, {2}, {3}})
+		if results.WinnerOptionID != nil {
+			t.Fatalf("expected no winner, got %d", *results.WinnerOptionID)
+		}
+		if len(results.Tied) != 3 {
+			t.Errorf("expected 3 tied options, got %v", results.Tied)
+		}
+	})
+
+	t.Run("ballot exhausted after elimination", func(t *testing.T) {
+		ballots := [][]uint{{1}, {1}, {2}, {2}, {3}}
+		results := usecase.TallyInstantRunoff(options, ballots)
+		if len(results.Rounds) != 2 || results.Rounds[1].Exhausted != 1 {
+			t.Fatalf("expected exhausted ballot in round 2, got %+v", results.Rounds)
+		}
+		if len(results.Tied) != 2 {
+			t.Errorf("expected options 1 and 2 tied, got %v", results.Tied)
+		}
+	})
+
+	t.Run("no ballots", func(t *testing.T) {
+		results := usecase.TallyInstantRunoff(options, nil)
+		if results.WinnerOptionID != nil || len(results.Rounds) != 1 {
+			t.Errorf("expected single empty round without winner, got %+v", results)
+		}
+	})
+}
+
+// TestPollOptionsToRatingResults test rata-rata rating poll
+func TestPollOptionsToRatingResults(t *testing.T) {
+	options := []entity.PollOption{
+		{Order: 1, VoteCount: 1},
+		{Order: 2, VoteCount: 0},
+		{Order: 3, VoteCount: 1},
+		{Order: 4, VoteCount: 0},
+		{Order: 5, VoteCount: 1},
+	}
+
+	results := converter.PollOptionsToRatingResults(options)
+	if results.Count != 3 || results.Scale != 5 || results.Average != 3 {
+		t.Errorf("unexpected rating results: %+v", results)
+	}
+
+	empty := converter.PollOptionsToRatingResults(options[:0])
+	if empty.Count != 0 || empty.Average != 0 {
+		t.Errorf("expected empty rating results, got %+v", empty)
+	}
+}
+
+// TestNormalizeAnswer test normalisasi jawaban open-text untuk word cloud
+func TestNormalizeAnswer(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "case folding and trim", text: "  Inspiring  ", want: "inspiring"},
+		{name: "punctuation removed", text: "Great talk!!!", want: "great talk"},
+		{name: "english stop words", text: "The best of the day", want: "best day"},
+		{name: "indonesian stop words", text: "Materi yang sangat menarik", want: "materi menarik"},
+		{name: "collapse whitespace", text: "open\t\tsource   software", want: "open source software"},
+		{name: "keep hyphen and apostrophe inside words", text: "Real-time, don't stop", want: "real-time don't stop"},
+		{name: "only stop words", text: "and the", want: ""},
+		{name: "only punctuation", text: "?!...", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usecase.NormalizeAnswer(tt.text); got != tt.want {
+				t.Errorf("NormalizeAnswer(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestQuizPoints test perhitungan XP quiz berdasarkan jawaban benar dan kecepatan
+func TestQuizPoints(t *testing.T) {
+	limit := 20 * time.Second
+
+	tests := []struct {
+		name    string
+		correct bool
+		elapsed time.Duration
+		limit   time.Duration
+		want    int
+	}{
+		{name: "wrong answer", correct: false, elapsed: time.Second, limit: limit, want: 0},
+		{name: "instant correct answer", correct: true, elapsed: 0, limit: limit, want: usecase.XPQuizCorrect + usecase.XPQuizSpeedBonus},
+		{name: "correct at half time", correct: true, elapsed: 10 * time.Second, limit: limit, want: usecase.XPQuizCorrect + usecase.XPQuizSpeedBonus/2},
+		{name: "correct at deadline", correct: true, elapsed: limit, limit: limit, want: usecase.XPQuizCorrect},
+		{name: "correct within grace period", correct: true, elapsed: limit + time.Second, limit: limit, want: usecase.XPQuizCorrect},
+		{name: "correct without timer", correct: true, elapsed: time.Minute, limit: 0, want: usecase.XPQuizCorrect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usecase.QuizPoints(tt.correct, tt.elapsed, tt.limit); got != tt.want {
+				t.Errorf("QuizPoints() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestQuizToSummaryResponse test akurasi per pertanyaan dan ranking quiz
+func TestQuizToSummaryResponse(t *testing.T) {
+	quiz := &entity.Quiz{ID: 1, RoomID: 1, Title: "Trivia", Status: "finished"}
+	polls := []entity.Poll{
+		{ID: 10, Question: "Q1", Status: "closed", Options: []entity.PollOption{{ID: 100, IsCorrect: true}, {ID: 101}}},
+		{ID: 11, Question: "Q2", Status: "active", Options: []entity.PollOption{{ID: 110}, {ID: 111, IsCorrect: true}}},
+	}
+	questionStats := []model.QuizQuestionStat{
+		{PollID: 10, TotalAnswers: 3, CorrectAnswers: 2, AvgResponseMs: 4200.4},
+	}
+	ranking := []model.QuizParticipantStat{
+		{ParticipantID: 7, DisplayName: "Budi", TotalAnswers: 1, CorrectAnswers: 1, Points: 18},
+		{ParticipantID: 8, DisplayName: "Ani", TotalAnswers: 1, CorrectAnswers: 1, Points: 12},
+	}
+
+	summary := converter.QuizToSummaryResponse(quiz, polls, questionStats, ranking)
+
+	if len(summary.Questions) != 2 {
+		t.Fatalf("expected 2 questions, got %d", len(summary.Questions))
+	}
+	if summary.Questions[0].Accuracy != 66.67 {
+		t.Errorf("expected accuracy 66.67, got %v", summary.Questions[0].Accuracy)
+	}
+	if summary.Questions[0].AvgResponseMs != 4200 {
+		t.Errorf("expected avg response 4200, got %d", summary.Questions[0].AvgResponseMs)
+	}
+	if len(summary.Questions[0].CorrectOptionIDs) != 1 || summary.Questions[0].CorrectOptionIDs[0] != 100 {
+		t.Errorf("expected correct option 100, got %v", summary.Questions[0].CorrectOptionIDs)
+	}
+	// pertanyaan yang masih aktif tidak membuka jawaban benar
+	if summary.Questions[1].CorrectOptionIDs != nil {
+		t.Errorf("expected no correct options for active question, got %v", summary.Questions[1].CorrectOptionIDs)
+	}
+	if summary.Questions[1].Accuracy != 0 || summary.Questions[1].TotalAnswers != 0 {
+		t.Errorf("expected empty stats for unanswered question, got %+v", summary.Questions[1])
+	}
+	if summary.Ranking[0].Rank != 1 || summary.Ranking[0].ParticipantID != 7 || summary.Ranking[1].Rank != 2 {
+		t.Errorf("unexpected ranking: %+v", summary.Ranking)
+	}
+}
+
+// setupPollUseCaseTest setup test environment for PollUseCase
+func setupPollUseCaseTest(t *testing.T) (*usecase.PollUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.PollUseCase{
+		DB:                 gormDB,
+		Log:                log,
+		Validator:          validator.New(),
+		PollRepository:     &repository.PollRepository{Log: log},
+		RoomRepository:     &repository.RoomRepository{Log: log},
+		RoomRoleRepository: &repository.RoomRoleRepository{Log: log},
+		QuizRepository:     &repository.QuizRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// TestPollUseCase_Close_OtherRoom poll dari room lain (close lewat websocket room tertentu) dianggap tidak ada
+func TestPollUseCase_Close_OtherRoom(t *testing.T) {
+	uc, mockDB := setupPollUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT \* FROM "polls"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "status"}).AddRow(1, 2, "active"))
+	mockDB.ExpectQuery(`SELECT \* FROM "poll_options"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "poll_id"}))
+	mockDB.ExpectRollback()
+
+	_, err := uc.Close(context.Background(), &model.ClosePollRequest{PollID: 1, PresenterID: 1, RoomID: 3})
+
+	assert.Equal(t, fiber.ErrNotFound, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestPollUseCase_ActivateScheduled_DBErrorKeepsSchedule error database pada satu poll tidak membatalkan batch
+// dan jadwal poll tersebut tidak dihapus supaya dicoba lagi di tick berikutnya
+func TestPollUseCase_ActivateScheduled_DBErrorKeepsSchedule(t *testing.T) {
+	uc, mockDB := setupPollUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT "id" FROM "polls" .* FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	mockDB.ExpectExec(`SAVEPOINT activate_poll_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(`SELECT \* FROM "polls"`).WillReturnError(errors.New("connection reset"))
+	mockDB.ExpectExec(`ROLLBACK TO SAVEPOINT activate_poll_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mockDB.ExpectExec(`SAVEPOINT activate_poll_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(`SELECT \* FROM "polls"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "status"}).AddRow(2, 1, "draft"))
+	mockDB.ExpectQuery(`SELECT \* FROM "poll_options"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "poll_id"}))
+	mockDB.ExpectExec(`UPDATE "polls" SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(`SELECT \* FROM "polls"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "status"}).AddRow(2, 1, "active"))
+	mockDB.ExpectQuery(`SELECT \* FROM "poll_options"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "poll_id"}))
+	mockDB.ExpectCommit()
+
+	activated, err := uc.ActivateScheduled(context.Background(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Len(t, activated, 1)
+	assert.Equal(t, uint(2), activated[0].Poll.ID)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestPollUseCase_ActivateScheduled_UnschedulesFinishedQuiz pertanyaan quiz yang sudah selesai dihapus jadwalnya
+func TestPollUseCase_ActivateScheduled_UnschedulesFinishedQuiz(t *testing.T) {
+	uc, mockDB := setupPollUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(`SELECT "id" FROM "polls" .* FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	mockDB.ExpectExec(`SAVEPOINT activate_poll_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectQuery(`SELECT \* FROM "polls"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "quiz_id", "status"}).AddRow(1, 1, 7, "draft"))
+	mockDB.ExpectQuery(`SELECT \* FROM "poll_options"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "poll_id"}))
+	mockDB.ExpectQuery(`SELECT \* FROM "quizzes"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "status"}).AddRow(7, 1, "finished"))
+	mockDB.ExpectExec(`ROLLBACK TO SAVEPOINT activate_poll_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec(`UPDATE "polls" SET "scheduled_at"=\$1 WHERE id = \$2`).
+		WithArgs(nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	activated, err := uc.ActivateScheduled(context.Background(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Empty(t, activated)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestPollToResultsResponse_RoomID room poll ikut di hasil untuk tujuan broadcast, tapi tidak di payload
+func TestPollToResultsResponse_RoomID(t *testing.T) {
+	poll := &entity.Poll{ID: 4, RoomID: 9, Type: model.PollTypeOpenText}
+
+	results := converter.PollToResultsResponse(poll, 0, nil, nil)
+	assert.Equal(t, uint(9), results.RoomID)
+
+	payload, err := json.Marshal(results)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(payload), "room_id")
+}
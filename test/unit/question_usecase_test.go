@@ -4,6 +4,7 @@ import (
 	"reisify/internal/entity"
 	"reisify/internal/model"
 	"reisify/test/mocks"
+	"strings"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
@@ -270,6 +271,138 @@ func TestValidateQuestionRequest_Validation(t *testing.T) {
 	}
 }
 
+// TestReplyQuestionRequest_Validation test validation untuk ReplyQuestionRequest
+func TestReplyQuestionRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name        string
+		request     model.ReplyQuestionRequest
+		shouldError bool
+	}{
+		{
+			name: "valid reply",
+			request: model.ReplyQuestionRequest{
+				QuestionID:    1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Content:       "We ship every two weeks.",
+			},
+			shouldError: false,
+		},
+		{
+			name: "empty content",
+			request: model.ReplyQuestionRequest{
+				QuestionID:    1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Content:       "",
+			},
+			shouldError: true,
+		},
+		{
+			name: "content too long",
+			request: model.ReplyQuestionRequest{
+				QuestionID:    1,
+				ParticipantID: 1,
+				RoomID:        1,
+				Content:       strings.Repeat("a", 1001),
+			},
+			shouldError: true,
+		},
+		{
+			name: "missing question id",
+			request: model.ReplyQuestionRequest{
+				ParticipantID: 1,
+				RoomID:        1,
+				Content:       "Answer",
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if tt.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestModerateQuestionRequest_Validation test validation untuk ModerateQuestionRequest
+func TestModerateQuestionRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name        string
+		request     model.ModerateQuestionRequest
+		shouldError bool
+	}{
+		{
+			name: "approve",
+			request: model.ModerateQuestionRequest{
+				QuestionID:  1,
+				ModeratorID: 1,
+				Action:      "approve",
+			},
+			shouldError: false,
+		},
+		{
+			name: "approve with edited content",
+			request: model.ModerateQuestionRequest{
+				QuestionID:  1,
+				ModeratorID: 1,
+				Action:      "approve",
+				Content:     "Fixed typo",
+			},
+			shouldError: false,
+		},
+		{
+			name: "reject with reason",
+			request: model.ModerateQuestionRequest{
+				QuestionID:  1,
+				ModeratorID: 1,
+				Action:      "reject",
+				Reason:      "Off topic",
+			},
+			shouldError: false,
+		},
+		{
+			name: "edit without content",
+			request: model.ModerateQuestionRequest{
+				QuestionID:  1,
+				ModeratorID: 1,
+				Action:      "edit",
+			},
+			shouldError: true,
+		},
+		{
+			name: "invalid action",
+			request: model.ModerateQuestionRequest{
+				QuestionID:  1,
+				ModeratorID: 1,
+				Action:      "delete",
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if tt.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 // TestMockQuestionRepository_FindByIdWithParticipant test mock question repository
 func TestMockQuestionRepository_FindByIdWithParticipant(t *testing.T) {
 	mockQuestionRepo := new(mocks.MockQuestionRepository)
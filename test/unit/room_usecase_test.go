@@ -46,6 +46,8 @@ func setupRoomUseCaseTest(t *testing.T) (*usecase.RoomUseCase, sqlmock.Sqlmock)
 		Validate:              validate,
 		RoomRepository:        &repository.RoomRepository{Log: log},
 		ParticipantRepository: &repository.ParticipantRepository{Log: log},
+		RoomRoleRepository:    &repository.RoomRoleRepository{Log: log},
+		UserRepository:        &repository.UserRepository{Log: log},
 	}
 
 	return uc, mockDB
@@ -131,6 +133,136 @@ func TestRoomUseCase_UpdateToClosed_InvalidRequest(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestRoomUseCase_UpdateSettings_MissingField test update settings tanpa question_moderation dan participant_replies
+func TestRoomUseCase_UpdateSettings_MissingField(t *testing.T) {
+	uc, mockDB := setupRoomUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.UpdateRoomSettingsRequest{
+		PresenterID: 1,
+		RoomID:      1,
+	}
+
+	result, err := uc.UpdateSettings(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestRoomUseCase_AddModerator_InvalidRequest test add moderator tanpa participant_id
+func TestRoomUseCase_AddModerator_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupRoomUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.AddModeratorRequest{
+		PresenterID: 1,
+		RoomID:      1,
+	}
+
+	result, err := uc.AddModerator(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestRoomUseCase_GrantRole_InvalidRequest test grant role dengan role yang tidak dikenal
+func TestRoomUseCase_GrantRole_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupRoomUseCaseTest(t)
+
+	// expect begin transaction
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.GrantRoomRoleRequest{
+		ActorID:       1,
+		RoomID:        1,
+		ParticipantID: 2,
+		Role:          "owner",
+	}
+
+	result, err := uc.GrantRole(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestGrantRoomRoleRequest_Validation test grant role request validation
+func TestGrantRoomRoleRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	tests := []struct {
+		name        string
+		request     model.GrantRoomRoleRequest
+		shouldError bool
+	}{
+		{
+			name:        "co-host",
+			request:     model.GrantRoomRoleRequest{ActorID: 1, RoomID: 1, ParticipantID: 2, Role: "co_host"},
+			shouldError: false,
+		},
+		{
+			name:        "moderator",
+			request:     model.GrantRoomRoleRequest{ActorID: 1, RoomID: 1, ParticipantID: 2, Role: "moderator"},
+			shouldError: false,
+		},
+		{
+			name:        "owner cannot be granted",
+			request:     model.GrantRoomRoleRequest{ActorID: 1, RoomID: 1, ParticipantID: 2, Role: "owner"},
+			shouldError: true,
+		},
+		{
+			name:        "missing role",
+			request:     model.GrantRoomRoleRequest{ActorID: 1, RoomID: 1, ParticipantID: 2},
+			shouldError: true,
+		},
+		{
+			name:        "missing participant",
+			request:     model.GrantRoomRoleRequest{ActorID: 1, RoomID: 1, Role: "moderator"},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if tt.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAuth_RoomRolePermissions test permission helper per role
+func TestAuth_RoomRolePermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        model.Auth
+		canHost     bool
+		canModerate bool
+	}{
+		{name: "owner", auth: model.Auth{IsRoomOwner: true, RoomRole: model.RoomRoleOwner}, canHost: true, canModerate: true},
+		{name: "co-host", auth: model.Auth{RoomRole: model.RoomRoleCoHost}, canHost: true, canModerate: true},
+		{name: "moderator", auth: model.Auth{RoomRole: model.RoomRoleModerator}, canHost: false, canModerate: true},
+		{name: "participant", auth: model.Auth{RoomRole: model.RoomRoleParticipant}, canHost: false, canModerate: false},
+		{name: "token without role", auth: model.Auth{}, canHost: false, canModerate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.canHost, tt.auth.CanHost())
+			assert.Equal(t, tt.canModerate, tt.auth.CanModerate())
+		})
+	}
+}
+
 // TestCreateRoomRequest_Validation test create room request validation
 func TestCreateRoomRequest_Validation(t *testing.T) {
 	validate := validator.New()
@@ -0,0 +1,108 @@
+package unit
+
+import (
+	"context"
+	"reisify/internal/model"
+	"reisify/internal/model/converter"
+	"reisify/internal/usecase"
+	"reisify/internal/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupSessionUseCaseTest setup test environment for SessionUseCase
+func setupSessionUseCaseTest() *usecase.SessionUseCase {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	return &usecase.SessionUseCase{
+		Log:       log,
+		Validate:  validator.New(),
+		TokenUtil: &util.TokenUtil{SecretKey: "test-secret"},
+	}
+}
+
+// TestSessionUseCase_List_InvalidRequest test list sessions without user
+func TestSessionUseCase_List_InvalidRequest(t *testing.T) {
+	uc := setupSessionUseCaseTest()
+
+	_, err := uc.List(context.Background(), &model.ListSessionsRequest{})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// TestSessionUseCase_Revoke_InvalidSessionID test revoke with malformed session id
+func TestSessionUseCase_Revoke_InvalidSessionID(t *testing.T) {
+	uc := setupSessionUseCaseTest()
+
+	tests := []string{"", "abc", strings.Repeat("z", 32)}
+	for _, sessionID := range tests {
+		err := uc.Revoke(context.Background(), &model.RevokeSessionRequest{UserID: 1, SessionID: sessionID})
+		assert.Equal(t, fiber.ErrBadRequest, err, sessionID)
+	}
+}
+
+// TestDeviceName test label device dari User-Agent
+func TestDeviceName(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		expected  string
+	}{
+		{"", "Unknown device"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", "Chrome on Windows"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0", "Edge on Windows"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15", "Safari on macOS"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1", "Safari on iOS"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0", "Firefox on Linux"},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36", "Chrome on Android"},
+		{"curl/8.4.0", "curl"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, util.DeviceName(tt.userAgent), tt.userAgent)
+	}
+}
+
+// TestParseSessionHash test baca hash session dari Redis
+func TestParseSessionHash(t *testing.T) {
+	assert.Nil(t, util.ParseSessionHash("sid", map[string]string{}))
+	assert.Nil(t, util.ParseSessionHash("sid", map[string]string{"claims": "{}"}))
+
+	session := util.ParseSessionHash("sid", map[string]string{
+		"claims":         `{"user_id":7,"participant_id":3,"room_id":5,"role":"participant","is_anonymous":false,"is_room_owner":false}`,
+		"user_id":        "7",
+		"participant_id": "3",
+		"user_agent":     "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+		"ip":             "10.0.0.1",
+		"created_at":     "1700000000",
+		"last_active_at": "1700000600",
+	})
+
+	assert.NotNil(t, session)
+	assert.Equal(t, "sid", session.ID)
+	assert.Equal(t, uint(7), *session.UserID)
+	assert.Equal(t, uint(3), *session.ParticipantID)
+	assert.Equal(t, uint(5), *session.RoomID)
+	assert.Equal(t, "Firefox on Linux", session.Device)
+	assert.Equal(t, "10.0.0.1", session.IP)
+	assert.Equal(t, time.Unix(1700000000, 0), session.CreatedAt)
+	assert.Equal(t, time.Unix(1700000600, 0), session.LastActiveAt)
+}
+
+// TestSessionsToListResponse test current flag di list session
+func TestSessionsToListResponse(t *testing.T) {
+	response := converter.SessionsToListResponse([]model.SessionInfo{
+		{ID: "current", Device: "Chrome on Windows"},
+		{ID: "other", Device: "Safari on iOS"},
+	}, "current")
+
+	assert.Len(t, response.Sessions, 2)
+	assert.True(t, response.Sessions[0].Current)
+	assert.False(t, response.Sessions[1].Current)
+}
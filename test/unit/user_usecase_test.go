@@ -8,10 +8,12 @@ import (
 	"reisify/internal/usecase"
 	"reisify/internal/util"
 	"reisify/test/mocks"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -54,6 +56,7 @@ func setupUserUseCaseTest(t *testing.T) (*usecase.UserUseCase, sqlmock.Sqlmock,
 		UserRepository:        &repository.UserRepository{Log: log},
 		ParticipantRepository: &repository.ParticipantRepository{Log: log},
 		RoomRepository:        &repository.RoomRepository{Log: log},
+		RoomBanRepository:     &repository.RoomBanRepository{Log: log},
 		TokenUtil:             &util.TokenUtil{SecretKey: "test-secret"},
 	}
 
@@ -279,10 +282,10 @@ func TestMockTokenUtil_CreateToken(t *testing.T) {
 		Role:     "presenter",
 	}
 
-	expectedToken := "mock-jwt-token"
-	mockTokenUtil.On("CreateToken", mock.Anything, auth).Return(expectedToken, nil)
+	expectedToken := &model.TokenPair{AccessToken: "mock-jwt-token", RefreshToken: "mock-refresh-token"}
+	mockTokenUtil.On("CreateToken", mock.Anything, auth, mock.Anything).Return(expectedToken, nil)
 
-	token, err := mockTokenUtil.CreateToken(context.Background(), auth)
+	token, err := mockTokenUtil.CreateToken(context.Background(), auth, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToken, token)
@@ -322,3 +325,77 @@ func TestMockRoomRepository_FindByRoomCode_NotFound(t *testing.T) {
 	assert.Nil(t, room)
 	mockRoomRepo.AssertExpectations(t)
 }
+
+// TestUserUseCase_Refresh_EmptyToken test refresh without refresh token
+func TestUserUseCase_Refresh_EmptyToken(t *testing.T) {
+	uc, _, _, _, _, _ := setupUserUseCaseTest(t)
+
+	_, err := uc.Refresh(context.Background(), &model.RefreshTokenRequest{})
+
+	assert.Equal(t, fiber.ErrUnauthorized, err)
+}
+
+// TestUserUseCase_Refresh_MalformedToken test refresh token that is not "<sid>.<secret>"
+func TestUserUseCase_Refresh_MalformedToken(t *testing.T) {
+	uc, _, _, _, _, _ := setupUserUseCaseTest(t)
+
+	_, err := uc.Refresh(context.Background(), &model.RefreshTokenRequest{RefreshToken: "not-a-refresh-token"})
+
+	assert.Equal(t, fiber.ErrUnauthorized, err)
+}
+
+// TestUserUseCase_Logout_MissingSession test logout with token without session
+func TestUserUseCase_Logout_MissingSession(t *testing.T) {
+	uc, _, _, _, _, _ := setupUserUseCaseTest(t)
+
+	err := uc.Logout(context.Background(), &model.LogoutRequest{})
+
+	assert.Equal(t, fiber.ErrUnauthorized, err)
+}
+
+// TestUserUseCase_LogoutAll_InvalidRequest test logout all without user
+func TestUserUseCase_LogoutAll_InvalidRequest(t *testing.T) {
+	uc, _, _, _, _, _ := setupUserUseCaseTest(t)
+
+	_, err := uc.LogoutAll(context.Background(), &model.LogoutAllRequest{})
+
+	assert.Equal(t, fiber.ErrBadRequest, err)
+}
+
+// TestSplitRefreshToken test parsing refresh token "<sid>.<secret>"
+func TestSplitRefreshToken(t *testing.T) {
+	sid := strings.Repeat("a", 32)
+	secret := strings.Repeat("b", 64)
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{name: "valid", token: sid + "." + secret, ok: true},
+		{name: "empty", token: "", ok: false},
+		{name: "missing secret", token: sid, ok: false},
+		{name: "short session id", token: "abc." + secret, ok: false},
+		{name: "short secret", token: sid + ".abc", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSID, gotSecret, ok := util.SplitRefreshToken(tt.token)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, sid, gotSID)
+				assert.Equal(t, secret, gotSecret)
+			}
+		})
+	}
+}
+
+// TestHashToken test hash refresh token deterministic and not the raw token
+func TestHashToken(t *testing.T) {
+	hash := util.HashToken("secret")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, util.HashToken("secret"))
+	assert.NotEqual(t, hash, util.HashToken("other"))
+}
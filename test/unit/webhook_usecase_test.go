@@ -0,0 +1,252 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reisify/internal/entity"
+	"reisify/internal/model"
+	"reisify/internal/repository"
+	"reisify/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupWebhookUseCaseTest setup test environment for WebhookUseCase
+func setupWebhookUseCaseTest(t *testing.T) (*usecase.WebhookUseCase, sqlmock.Sqlmock) {
+	db, mockDB, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(t, err)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	uc := &usecase.WebhookUseCase{
+		DB:                        gormDB,
+		Log:                       log,
+		Validate:                  validator.New(),
+		HTTPClient:                &http.Client{Timeout: time.Second},
+		MaxAttempts:               usecase.DefaultWebhookMaxAttempts,
+		WebhookRepository:         &repository.WebhookRepository{Log: log},
+		WebhookDeliveryRepository: &repository.WebhookDeliveryRepository{Log: log},
+		RoomRepository:            &repository.RoomRepository{Log: log},
+	}
+
+	return uc, mockDB
+}
+
+// TestWebhookUseCase_Create_InvalidRequest test create webhook with unknown event type
+func TestWebhookUseCase_Create_InvalidRequest(t *testing.T) {
+	uc, mockDB := setupWebhookUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.CreateWebhookRequest{
+		UserID: 1,
+		URL:    "https://example.com/hook",
+		Events: []string{"poll:vote"}, // invalid: bukan event webhook
+	}
+
+	result, err := uc.Create(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestWebhookUseCase_Create_InvalidURL test create webhook with non http url
+func TestWebhookUseCase_Create_InvalidURL(t *testing.T) {
+	uc, mockDB := setupWebhookUseCaseTest(t)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectRollback()
+
+	request := &model.CreateWebhookRequest{
+		UserID: 1,
+		URL:    "ftp://example.com/hook",
+		Events: []string{"question:created"},
+	}
+
+	result, err := uc.Create(context.Background(), request)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestWebhookUseCase_Dispatch_IgnoresUnknownEvent event di luar WebhookEvents tidak menyentuh database
+func TestWebhookUseCase_Dispatch_IgnoresUnknownEvent(t *testing.T) {
+	uc, mockDB := setupWebhookUseCaseTest(t)
+
+	err := uc.Dispatch(context.Background(), 1, "poll:results_updated", []byte(`{}`))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+// TestSendWebhook_SignsPayload receiver menerima header event dan signature yang bisa diverifikasi
+func TestSendWebhook_SignsPayload(t *testing.T) {
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotEvent     string
+		gotDelivery  string
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(usecase.WebhookHeaderSignature)
+		gotEvent = r.Header.Get(usecase.WebhookHeaderEvent)
+		gotDelivery = r.Header.Get(usecase.WebhookHeaderDelivery)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	now := time.Unix(1760000000, 0)
+	delivery := &entity.WebhookDelivery{
+		ID:      42,
+		Event:   "question:created",
+		Payload: `{"id":"evt_1","event":"question:created","room_id":1}`,
+	}
+
+	status, body, err := usecase.SendWebhook(context.Background(), receiver.Client(), receiver.URL, "whsec_test", delivery, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, "ok", body)
+	assert.Equal(t, delivery.Payload, string(gotBody))
+	assert.Equal(t, "question:created", gotEvent)
+	assert.Equal(t, "42", gotDelivery)
+
+	expected := fmt.Sprintf("t=%d,v1=%s", now.Unix(), usecase.SignWebhookPayload("whsec_test", now.Unix(), gotBody))
+	assert.Equal(t, expected, gotSignature)
+	assert.NotEqual(t, usecase.SignWebhookPayload("other", now.Unix(), gotBody), usecase.SignWebhookPayload("whsec_test", now.Unix(), gotBody))
+}
+
+// TestSendWebhook_BlocksLoopbackTarget client webhook menolak koneksi ke 127.0.0.1
+func TestSendWebhook_BlocksLoopbackTarget(t *testing.T) {
+	hits := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	delivery := &entity.WebhookDelivery{ID: 1, Event: "question:created", Payload: `{}`}
+	client := usecase.NewWebhookHTTPClient(time.Second, false)
+
+	status, _, err := usecase.SendWebhook(context.Background(), client, receiver.URL, "whsec_test", delivery, time.Now())
+
+	assert.ErrorIs(t, err, usecase.ErrWebhookTargetBlocked)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, 0, hits)
+
+	// localhost di-resolve dulu, IP hasil resolve yang dicek
+	localhostURL := strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)
+	_, _, err = usecase.SendWebhook(context.Background(), client, localhostURL, "whsec_test", delivery, time.Now())
+	assert.Error(t, err)
+	assert.Equal(t, 0, hits)
+}
+
+// TestSendWebhook_DoesNotFollowRedirect redirect dari receiver tidak diikuti
+func TestSendWebhook_DoesNotFollowRedirect(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer internal.Close()
+	receiver := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer receiver.Close()
+
+	delivery := &entity.WebhookDelivery{ID: 1, Event: "question:created", Payload: `{}`}
+	client := usecase.NewWebhookHTTPClient(time.Second, true)
+
+	status, body, err := usecase.SendWebhook(context.Background(), client, receiver.URL, "whsec_test", delivery, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, status)
+	assert.NotContains(t, body, "secret")
+}
+
+// TestIsWebhookAddrBlocked test range alamat internal yang ditolak
+func TestIsWebhookAddrBlocked(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1",
+		"0.0.0.0", "224.0.0.1", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254",
+	}
+	for _, ip := range blocked {
+		assert.True(t, usecase.IsWebhookAddrBlocked(netip.MustParseAddr(ip)), ip)
+	}
+
+	allowed := []string{"93.184.216.34", "8.8.8.8", "2606:4700:4700::1111"}
+	for _, ip := range allowed {
+		assert.False(t, usecase.IsWebhookAddrBlocked(netip.MustParseAddr(ip)), ip)
+	}
+}
+
+// TestWebhookBackoff backoff eksponensial dengan batas atas
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, usecase.WebhookBackoff(1))
+	assert.Equal(t, time.Minute, usecase.WebhookBackoff(2))
+	assert.Equal(t, 4*time.Minute, usecase.WebhookBackoff(4))
+	assert.Equal(t, time.Hour, usecase.WebhookBackoff(20))
+}
+
+// TestApplyWebhookAttempt_Success 2xx menandai delivery success
+func TestApplyWebhookAttempt_Success(t *testing.T) {
+	now := time.Now()
+	next := now
+	delivery := &entity.WebhookDelivery{Status: model.WebhookDeliveryPending, NextAttemptAt: &next, LastError: "timeout"}
+
+	usecase.ApplyWebhookAttempt(delivery, http.StatusOK, "ok", nil, now, 3)
+
+	assert.Equal(t, model.WebhookDeliverySuccess, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+	assert.Empty(t, delivery.LastError)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+// TestApplyWebhookAttempt_Retry non-2xx dijadwalkan ulang dengan backoff
+func TestApplyWebhookAttempt_Retry(t *testing.T) {
+	now := time.Now()
+	delivery := &entity.WebhookDelivery{Status: model.WebhookDeliveryPending}
+
+	usecase.ApplyWebhookAttempt(delivery, http.StatusInternalServerError, "boom", nil, now, 3)
+
+	assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, "unexpected status 500", delivery.LastError)
+	assert.Equal(t, now.Add(usecase.WebhookBackoff(1)), *delivery.NextAttemptAt)
+	assert.Nil(t, delivery.DeliveredAt)
+}
+
+// TestApplyWebhookAttempt_Failed percobaan terakhir yang gagal menandai delivery failed
+func TestApplyWebhookAttempt_Failed(t *testing.T) {
+	now := time.Now()
+	delivery := &entity.WebhookDelivery{Status: model.WebhookDeliveryPending, Attempts: 2}
+
+	usecase.ApplyWebhookAttempt(delivery, 0, "", errors.New("connection refused"), now, 3)
+
+	assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Nil(t, delivery.ResponseStatus)
+	assert.Equal(t, "connection refused", delivery.LastError)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
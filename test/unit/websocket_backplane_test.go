@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"reisify/internal/delivery/websocket"
+	"reisify/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryBackplane_PublishReachesAllNodes test broadcast dari satu node diterima node lain
+func TestMemoryBackplane_PublishReachesAllNodes(t *testing.T) {
+	bus := websocket.NewMemoryBus()
+	nodeA := bus.NewBackplane()
+	nodeB := bus.NewBackplane()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan websocket.Envelope, 2)
+	require.NoError(t, nodeA.Subscribe(ctx, func(env websocket.Envelope) { received <- env }))
+	require.NoError(t, nodeB.Subscribe(ctx, func(env websocket.Envelope) { received <- env }))
+
+	err := nodeA.Publish(ctx, websocket.Envelope{
+		NodeID:  nodeA.NodeID(),
+		RoomID:  7,
+		Payload: []byte(`{"event":"question:created","data":{}}`),
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		select {
+		case env := <-received:
+			assert.Equal(t, uint(7), env.RoomID)
+			assert.Equal(t, nodeA.NodeID(), env.NodeID)
+			assert.JSONEq(t, `{"event":"question:created","data":{}}`, string(env.Payload))
+		case <-time.After(time.Second):
+			t.Fatal("envelope not delivered to every node")
+		}
+	}
+}
+
+// TestMemoryBackplane_PresenceAcrossNodes test participant dengan koneksi di dua node dihitung sekali
+func TestMemoryBackplane_PresenceAcrossNodes(t *testing.T) {
+	bus := websocket.NewMemoryBus()
+	nodeA := bus.NewBackplane()
+	nodeB := bus.NewBackplane()
+	ctx := context.Background()
+
+	require.NoError(t, nodeA.SetPresence(ctx, 1, 10, 1))
+	require.NoError(t, nodeB.SetPresence(ctx, 1, 10, 2))
+	require.NoError(t, nodeB.SetPresence(ctx, 1, 11, 1))
+	require.NoError(t, nodeB.SetPresence(ctx, 2, 12, 1))
+
+	online, err := nodeA.OnlineParticipants(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{10, 11}, online)
+
+	// participant 10 disconnect dari node A tapi masih online di node B
+	require.NoError(t, nodeA.SetPresence(ctx, 1, 10, 0))
+	online, err = nodeB.OnlineParticipants(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{10, 11}, online)
+
+	require.NoError(t, nodeB.SetPresence(ctx, 1, 10, 0))
+	online, err = nodeA.OnlineParticipants(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{11}, online)
+}
+
+// TestMemoryBackplane_StatusAcrossNodes test status presence diambil dari koneksi paling aktif di semua node
+func TestMemoryBackplane_StatusAcrossNodes(t *testing.T) {
+	bus := websocket.NewMemoryBus()
+	nodeA := bus.NewBackplane()
+	nodeB := bus.NewBackplane()
+	ctx := context.Background()
+
+	require.NoError(t, nodeA.SetStatus(ctx, 1, 10, model.PresenceAway))
+	require.NoError(t, nodeB.SetStatus(ctx, 1, 10, model.PresenceIdle))
+	require.NoError(t, nodeB.SetStatus(ctx, 1, 11, model.PresenceOnline))
+
+	statuses, err := nodeA.Statuses(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]string{10: model.PresenceIdle, 11: model.PresenceOnline}, statuses)
+
+	// tab di node B ditutup, tersisa koneksi away di node A
+	require.NoError(t, nodeB.SetStatus(ctx, 1, 10, model.PresenceOffline))
+	statuses, err = nodeB.Statuses(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.PresenceAway, statuses[10])
+
+	require.NoError(t, nodeA.SetStatus(ctx, 1, 10, model.PresenceOffline))
+	statuses, err = nodeA.Statuses(ctx, 1)
+	require.NoError(t, err)
+	assert.NotContains(t, statuses, uint(10))
+}
+
+// TestMemoryBackplane_UnsubscribeOnCancel test node berhenti menerima pesan setelah context dibatalkan
+func TestMemoryBackplane_UnsubscribeOnCancel(t *testing.T) {
+	bus := websocket.NewMemoryBus()
+	nodeA := bus.NewBackplane()
+	nodeB := bus.NewBackplane()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan websocket.Envelope, 1)
+	require.NoError(t, nodeB.Subscribe(ctx, func(env websocket.Envelope) { received <- env }))
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		_ = nodeA.Publish(context.Background(), websocket.Envelope{NodeID: nodeA.NodeID(), RoomID: 1, Payload: []byte(`{}`)})
+		select {
+		case <-received:
+			return false
+		default:
+			return true
+		}
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"reisify/internal/delivery/websocket"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewErrorPayload_FiberErrors test mapping fiber.Err* dari usecase ke kode error websocket
+func TestNewErrorPayload_FiberErrors(t *testing.T) {
+	cases := []struct {
+		err     error
+		code    string
+		status  int
+		message string
+	}{
+		{fiber.ErrBadRequest, websocket.ErrorCodeInvalidRequest, fiber.StatusBadRequest, "Bad Request"},
+		{fiber.ErrForbidden, websocket.ErrorCodeForbidden, fiber.StatusForbidden, "Forbidden"},
+		{fiber.ErrNotFound, websocket.ErrorCodeNotFound, fiber.StatusNotFound, "Not Found"},
+		{fiber.NewError(fiber.StatusConflict, "Already voted"), websocket.ErrorCodeConflict, fiber.StatusConflict, "Already voted"},
+		{fiber.ErrTooManyRequests, websocket.ErrorCodeRateLimited, fiber.StatusTooManyRequests, "Too Many Requests"},
+		{fiber.ErrInternalServerError, websocket.ErrorCodeInternal, fiber.StatusInternalServerError, "Internal Server Error"},
+		{fiber.ErrGone, websocket.ErrorCodeInvalidRequest, fiber.StatusGone, "Gone"},
+	}
+
+	for _, tc := range cases {
+		payload := websocket.NewErrorPayload("poll:vote", tc.err)
+		assert.Equal(t, "poll:vote", payload.Event)
+		assert.Equal(t, tc.code, payload.Code)
+		assert.Equal(t, tc.status, payload.Status)
+		assert.Equal(t, tc.message, payload.Message)
+	}
+}
+
+// TestNewErrorPayload_InvalidPayload test JSON rusak atau tipe salah jadi invalid_payload
+func TestNewErrorPayload_InvalidPayload(t *testing.T) {
+	var target struct {
+		PollID uint `json:"poll_id"`
+	}
+
+	err := json.Unmarshal([]byte(`{"poll_id":`), &target)
+	payload := websocket.NewErrorPayload("poll:vote", err)
+	assert.Equal(t, websocket.ErrorCodeInvalidPayload, payload.Code)
+	assert.Equal(t, fiber.StatusBadRequest, payload.Status)
+
+	err = json.Unmarshal([]byte(`{"poll_id":"abc"}`), &target)
+	payload = websocket.NewErrorPayload("poll:vote", err)
+	assert.Equal(t, websocket.ErrorCodeInvalidPayload, payload.Code)
+}
+
+// TestNewErrorPayload_InternalErrorHidden test error non-fiber tidak membocorkan detail ke client
+func TestNewErrorPayload_InternalErrorHidden(t *testing.T) {
+	payload := websocket.NewErrorPayload("message:send", errors.New("pq: connection refused"))
+	assert.Equal(t, websocket.ErrorCodeInternal, payload.Code)
+	assert.Equal(t, fiber.StatusInternalServerError, payload.Status)
+	assert.Equal(t, "Internal Server Error", payload.Message)
+}
@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"reisify/internal/delivery/websocket"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendReplayEvents(t *testing.T, buffer websocket.ReplayBuffer, roomID uint, n int) {
+	for i := 0; i < n; i++ {
+		_, err := buffer.Append(context.Background(), roomID, websocket.WSMessage{
+			Event: websocket.EventQuestionCreated,
+			Data:  json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+	}
+}
+
+// TestMemoryReplayBuffer_SeqPerRoom test seq naik per room dan ikut di payload
+func TestMemoryReplayBuffer_SeqPerRoom(t *testing.T) {
+	buffer := websocket.NewMemoryReplayBuffer(10, time.Minute)
+
+	appendReplayEvents(t, buffer, 1, 2)
+	payload, err := buffer.Append(context.Background(), 2, websocket.WSMessage{Event: websocket.EventPollClosed, Data: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+
+	var message websocket.WSMessage
+	require.NoError(t, json.Unmarshal(payload, &message))
+	assert.Equal(t, uint64(1), message.Seq)
+
+	events, current, ok, err := buffer.Since(context.Background(), 1, 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), current)
+	assert.Len(t, events, 2)
+}
+
+// TestMemoryReplayBuffer_ReturnsGap test hanya event setelah last_seq yang dikirim ulang
+func TestMemoryReplayBuffer_ReturnsGap(t *testing.T) {
+	buffer := websocket.NewMemoryReplayBuffer(10, time.Minute)
+	appendReplayEvents(t, buffer, 1, 5)
+
+	events, current, ok, err := buffer.Since(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), current)
+	require.Len(t, events, 2)
+
+	var message websocket.WSMessage
+	require.NoError(t, json.Unmarshal(events[0], &message))
+	assert.Equal(t, uint64(4), message.Seq)
+
+	events, _, ok, err = buffer.Since(context.Background(), 1, 5)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, events)
+}
+
+// TestMemoryReplayBuffer_ResyncRequired test gap yang sudah keluar dari buffer atau seq tidak dikenal
+func TestMemoryReplayBuffer_ResyncRequired(t *testing.T) {
+	buffer := websocket.NewMemoryReplayBuffer(3, time.Minute)
+	appendReplayEvents(t, buffer, 1, 6)
+
+	// event 2 dan 3 sudah keluar dari buffer
+	_, current, ok, err := buffer.Since(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(6), current)
+
+	events, _, ok, err := buffer.Since(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, events, 3)
+
+	// seq dari counter lama (misal sebelum server restart)
+	_, _, ok, err = buffer.Since(context.Background(), 1, 42)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, ok, err = buffer.Since(context.Background(), 99, 7)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestHub_BroadcastToRoomAssignsSeq test broadcast hub diberi seq dan bisa di-replay
+func TestHub_BroadcastToRoomAssignsSeq(t *testing.T) {
+	hub := websocket.NewHub(logrus.New(), nil)
+
+	hub.BroadcastToRoom(5, []byte(`{"event":"question:created","data":{"id":1}}`))
+	hub.BroadcastToRoom(5, []byte(`{"event":"question:upvoted","data":{"id":1}}`))
+	hub.SendToParticipants(5, []uint{1}, []byte(`{"event":"poll:voted","data":{}}`))
+
+	events, current, ok, err := hub.Replay(5, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), current)
+	require.Len(t, events, 1)
+
+	var message websocket.WSMessage
+	require.NoError(t, json.Unmarshal(events[0], &message))
+	assert.Equal(t, websocket.EventQuestionUpvoted, message.Event)
+	assert.Equal(t, uint64(2), message.Seq)
+}
+
+// TestHub_BroadcastToRoomSkipsEphemeralEvents test typing, presence dan join / left tidak diberi seq
+func TestHub_BroadcastToRoomSkipsEphemeralEvents(t *testing.T) {
+	hub := websocket.NewHub(logrus.New(), nil)
+
+	var (
+		mu       sync.Mutex
+		notified []string
+	)
+	hub.SetBroadcastListener(func(roomID uint, event string, data json.RawMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, event)
+	})
+
+	hub.BroadcastToRoom(5, []byte(`{"event":"question:created","data":{"id":1}}`))
+	hub.BroadcastToRoom(5, []byte(`{"event":"chat:typing","data":{"participant_id":2}}`))
+	hub.BroadcastToRoom(5, []byte(`{"event":"presence:update","data":{"participant_id":2}}`))
+	hub.BroadcastToRoom(5, []byte(`{"event":"room:user_joined","data":{"participant_id":2}}`))
+
+	seq, err := hub.Seq(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), seq)
+
+	events, _, ok, err := hub.Replay(5, 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, events, 1)
+
+	// listener dipanggil di goroutine
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(notified) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, []string{websocket.EventQuestionCreated, websocket.EventRoomUserJoin}, notified)
+}